@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSecretClaimStatus defines the observed state of ClusterSecretClaim
+type ClusterSecretClaimStatus struct {
+	SecretClaimStatus `json:",inline"`
+
+	// SourceNamespace is the controller namespace holding the SecretClaim and the
+	// source Secret the replicas are copied from.
+	// +optional
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterSecretClaim is a cluster-scoped SecretClaim for credentials shared by
+// many namespaces. The controller keeps a SecretClaim of the same name in its
+// own namespace and replicates the Secret to the namespaces in spec.targets.
+type ClusterSecretClaim struct {
+	metav1.TypeMeta `json:",inline"`
+
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecretClaimSpec `json:"spec"`
+
+	Status ClusterSecretClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterSecretClaimList contains a list of ClusterSecretClaim
+type ClusterSecretClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSecretClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSecretClaim{}, &ClusterSecretClaimList{})
+}
@@ -29,9 +29,121 @@ type SecretClaimSpec struct {
 
 	Data map[string]string `json:"data,omitempty"`
 
+	// EncryptedData holds Opaque values sealed to the controller's public key, so
+	// they are only readable in the created Secret. Values are bound to the claim's
+	// namespace, name and key; keys must not repeat keys of Data.
+	// +optional
+	EncryptedData map[string]string `json:"encryptedData,omitempty"`
+
+	// DataHashes holds salted hashes of Opaque values the API server wrote straight
+	// to the created Secret, so their plaintext is not kept in the claim. The
+	// controller checks the Secret against them; keys must not repeat keys of Data
+	// or EncryptedData.
+	// +optional
+	DataHashes map[string]string `json:"dataHashes,omitempty"`
+
 	Generation *GenerationConfig `json:"generation,omitempty"`
+
+	Rotation *RotationConfig `json:"rotation,omitempty"`
+
+	// Certificate configures the key pair and certificate of Certificate claims.
+	// +optional
+	Certificate *CertificateConfig `json:"certificate,omitempty"`
+
+	// Source is the external store External claims read their data from.
+	// +optional
+	Source *SourceConfig `json:"source,omitempty"`
+
+	// Templates maps extra Secret keys to Go text/template strings rendered from
+	// the claim's data values, e.g. "postgres://app:{{ .password }}@db:5432/app".
+	// Helpers: base64, bcrypt, htpasswd.
+	Templates map[string]string `json:"templates,omitempty"`
+
+	// SecretType is the type of the created Secret. Typed secrets must provide the
+	// keys Kubernetes requires, e.g. tls.crt and tls.key for kubernetes.io/tls.
+	// +kubebuilder:validation:Enum=Opaque;kubernetes.io/tls;kubernetes.io/dockerconfigjson;kubernetes.io/basic-auth;kubernetes.io/ssh-auth
+	// +optional
+	SecretType string `json:"secretType,omitempty"`
+
+	// DockerConfig is rendered into .dockerconfigjson for kubernetes.io/dockerconfigjson secrets.
+	// +optional
+	DockerConfig *DockerConfig `json:"dockerConfig,omitempty"`
+
+	// DeletionPolicy decides what happens to the Secret when the claim is deleted.
+	// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Targets replicates the Secret into other namespaces.
+	// +optional
+	Targets *TargetsConfig `json:"targets,omitempty"`
+
+	// Push writes the Secret data to an external store whenever it changes.
+	// +optional
+	Push *PushConfig `json:"push,omitempty"`
+}
+
+// TargetsConfig selects the namespaces the claim's Secret is replicated to. A
+// namespace listed in Namespaces or matching NamespaceSelector is a target; the
+// claim's own namespace never is. Replicas have the claim's name and are kept
+// identical to the Secret in the claim's namespace.
+type TargetsConfig struct {
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
+// Deletion policies for SecretClaimSpec.DeletionPolicy. An empty policy means Delete.
+const (
+	// DeletionPolicyDelete deletes the Secret together with the claim.
+	DeletionPolicyDelete = "Delete"
+	// DeletionPolicyRetain keeps the Secret, drops the owner reference and marks it
+	// with OrphanedFromAnnotation and OrphanedFromUIDAnnotation so a new claim with
+	// the same name adopts it again.
+	DeletionPolicyRetain = "Retain"
+	// DeletionPolicyOrphan keeps the Secret and drops the owner reference; the Secret
+	// is no longer managed by any claim.
+	DeletionPolicyOrphan = "Orphan"
+)
+
+const (
+	// SecretClaimFinalizer lets the controller apply the deletion policy before the claim is gone.
+	SecretClaimFinalizer = "secrets.myapp.io/finalizer"
+	// OrphanedFromAnnotation is set on retained Secrets to the name of the deleted claim.
+	OrphanedFromAnnotation = "secrets.myapp.io/orphaned-from"
+	// OrphanedFromUIDAnnotation is set on retained Secrets to the UID of the deleted
+	// claim; it names the controller's record of the retention.
+	OrphanedFromUIDAnnotation = "secrets.myapp.io/orphaned-from-uid"
+	// ReplicaOfAnnotation is set on replicas in target namespaces to <namespace>/<name>
+	// of the claim they are copied from.
+	ReplicaOfAnnotation = "secrets.myapp.io/replica-of"
+	// SourceUIDLabel holds the UID of the claim on its replicas. Replicas cannot carry
+	// an owner reference to a claim in another namespace.
+	SourceUIDLabel = "secrets.myapp.io/source-uid"
+	// ReplacementForLabel holds the UID of the claim on the copy of its Secret kept
+	// while the Secret is replaced for a type change.
+	ReplacementForLabel = "secrets.myapp.io/replacement-for"
+)
+
+// Sealing keys for SecretClaimSpec.EncryptedData. The controller keeps the key
+// pairs in Secrets labeled SealingKeyLabel in its namespace and publishes the
+// active public key in the SealingPublicKeyConfigMap ConfigMap next to them.
+const (
+	SealingKeyLabel = "secrets.myapp.io/sealing-key"
+	// SealingKeyCreatedAnnotation holds the RFC 3339 creation time of a key pair;
+	// the newest one is active.
+	SealingKeyCreatedAnnotation = "secrets.myapp.io/sealing-key-created"
+	SealingPublicKeyConfigMap   = "sealing-public-key"
+	// SealingPrivateKeyKey, SealingPublicKeyKey and SealingKeyIDKey are the data
+	// keys of the key pair Secrets and the public key ConfigMap.
+	SealingPrivateKeyKey = "private.pem"
+	SealingPublicKeyKey  = "public.pem"
+	SealingKeyIDKey      = "keyId"
+)
+
 type GenerationConfig struct {
 	Length int `json:"length"` // длина пароля
 
@@ -40,6 +152,201 @@ type GenerationConfig struct {
 	ReconcileTrigger string `json:"reconcileTrigger,omitempty"`
 
 	DataKeys []string `json:"dataKeys,omitempty"` // ключи для сгенерированных данных
+
+	// KeyTriggers works like ReconcileTrigger for a single data key:
+	// changing a key's value regenerates only that key.
+	KeyTriggers map[string]string `json:"keyTriggers,omitempty"`
+
+	// Keys overrides how individual data keys are generated. Keys not listed
+	// here are passwords built from Length and Encoding.
+	// +optional
+	Keys map[string]KeyGeneration `json:"keys,omitempty"`
+}
+
+// KeyGeneration selects the generator of a single data key and, for passwords,
+// the policy the generated value has to follow.
+type KeyGeneration struct {
+	// Format is password (default), ssh-ed25519, ssh-rsa, random-bytes, jwk-rsa,
+	// jwk-ec or jwk-ed25519. Key pairs also write the public key to <key>.pub:
+	// an authorized_keys line for SSH keys and the public JWK for JWK keys.
+	// random-bytes writes Length raw bytes. The password policy fields below only
+	// apply to the password format.
+	// +kubebuilder:validation:Enum=password;ssh-ed25519;ssh-rsa;random-bytes;jwk-rsa;jwk-ec;jwk-ed25519
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// Bits is the RSA key size of ssh-rsa and jwk-rsa keys: 2048, 3072 (default) or 4096.
+	// +optional
+	Bits int `json:"bits,omitempty"`
+
+	// Length overrides GenerationConfig.Length for this key. It counts the
+	// generated characters, without Prefix.
+	// +kubebuilder:validation:Minimum=8
+	// +optional
+	Length int `json:"length,omitempty"`
+
+	// Charset lists the characters the password is built from and replaces the
+	// set chosen by GenerationConfig.Encoding, e.g. to leave out symbols a
+	// database rejects.
+	// +optional
+	Charset string `json:"charset,omitempty"`
+
+	// MinDigits, MinSymbols and MinUppercase are the least number of characters
+	// of each class in the password.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinDigits int `json:"minDigits,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinSymbols int `json:"minSymbols,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinUppercase int `json:"minUppercase,omitempty"`
+
+	// ExcludeAmbiguous drops characters that are easily confused (0O1lI|).
+	// +optional
+	ExcludeAmbiguous bool `json:"excludeAmbiguous,omitempty"`
+
+	// Prefix is prepended to the generated password, e.g. sk_live_.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// CertificateConfig describes a TLS certificate generated by the controller. The
+// certificate is self-signed unless IssuerRef names another SecretClaim in the same
+// namespace whose Secret holds a CA key pair (tls.crt, tls.key).
+type CertificateConfig struct {
+	CommonName string `json:"commonName,omitempty"`
+
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// KeyAlgorithm is RSA or ECDSA (default).
+	// +kubebuilder:validation:Enum=RSA;ECDSA
+	// +optional
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// KeySize is 2048 (default), 3072 or 4096 for RSA and 256 (default) or 384 for ECDSA.
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// Duration is the certificate lifetime, 2160h by default.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before notAfter the certificate is renewed,
+	// a third of Duration by default.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// IsCA issues a CA certificate that other claims can reference in IssuerRef.
+	// +optional
+	IsCA bool `json:"isCA,omitempty"`
+
+	// IssuerRef is the name of the SecretClaim holding the signing CA.
+	// +optional
+	IssuerRef string `json:"issuerRef,omitempty"`
+}
+
+// SourceConfig describes where an External claim reads its data from. The
+// source is read again every RefreshInterval and the Secret is updated when the
+// fetched data changes.
+type SourceConfig struct {
+	// Provider is file, http or vault.
+	// +kubebuilder:validation:Enum=file;http;vault
+	Provider string `json:"provider"`
+
+	// Path is the file or directory below the operator's source root for file,
+	// the URL for http and the secret path inside the KV v2 mount for vault.
+	Path string `json:"path"`
+
+	// Address is the base URL of the Vault server.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Mount is the Vault KV v2 mount path, "secret" by default.
+	// +optional
+	Mount string `json:"mount,omitempty"`
+
+	// CredentialsSecretRef names a Secret in the claim's namespace whose "token"
+	// key is sent as bearer token (http) or X-Vault-Token (vault).
+	// +optional
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
+
+	// Keys maps Secret keys to keys of the fetched data. Every fetched key is
+	// copied as is when Keys is empty.
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
+
+	// RefreshInterval is how often the source is read again, 1h by default.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// SourceCredentialsKey is the key of the token in SourceConfig.CredentialsSecretRef
+// and PushConfig.CredentialsSecretRef.
+const SourceCredentialsKey = "token"
+
+// PushConfig describes the external store the claim's Secret data is written to,
+// so consumers outside the cluster share the generated values. Keys the claim
+// does not push are kept in the store.
+type PushConfig struct {
+	// Provider is http or vault.
+	// +kubebuilder:validation:Enum=http;vault
+	Provider string `json:"provider"`
+
+	// Path is the URL for http and the secret path inside the KV v2 mount for vault.
+	Path string `json:"path"`
+
+	// Address is the base URL of the Vault server.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Mount is the Vault KV v2 mount path, "secret" by default.
+	// +optional
+	Mount string `json:"mount,omitempty"`
+
+	// CredentialsSecretRef names a Secret in the claim's namespace whose "token"
+	// key is sent as bearer token (http) or X-Vault-Token (vault).
+	// +optional
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
+
+	// Keys maps Secret keys to keys in the store. Every Secret key is pushed as
+	// is when Keys is empty.
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
+
+	// ConflictPolicy decides what happens when the value in the store differs
+	// from the one the controller pushed last: Fail (default) leaves the store
+	// alone and reports the conflict, Overwrite replaces the value.
+	// +kubebuilder:validation:Enum=Fail;Overwrite
+	// +kubebuilder:default=Fail
+	// +optional
+	ConflictPolicy string `json:"conflictPolicy,omitempty"`
+}
+
+// Conflict policies for PushConfig.ConflictPolicy. An empty policy means Fail.
+const (
+	PushConflictFail      = "Fail"
+	PushConflictOverwrite = "Overwrite"
+)
+
+// DockerConfig holds image registry credentials. The password is the value of
+// the "password" data key (set, sealed, hashed or generated), so it is never
+// stored in the claim in plain text.
+type DockerConfig struct {
+	Registry string `json:"registry"`
+
+	Username string `json:"username"`
+}
+
+// RotationConfig defines scheduled regeneration of an AutoGenerated secret.
+// Schedule takes precedence over Interval when both are set.
+type RotationConfig struct {
+	Interval *metav1.Duration `json:"interval,omitempty"` // например "2160h" (90 дней)
+
+	Schedule string `json:"schedule,omitempty"` // cron-выражение из 5 полей
 }
 
 // SecretClaimStatus defines the observed state of SecretClaim.
@@ -53,10 +360,116 @@ type SecretClaimStatus struct {
 	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
 
 	LastReconcileTrigger string `json:"lastReconcileTrigger,omitempty"`
+
+	LastKeyTriggers map[string]string `json:"lastKeyTriggers,omitempty"`
+
+	// LastKeyFormats holds the non-password formats the generated keys were last
+	// written with, so a format change regenerates the key.
+	LastKeyFormats map[string]string `json:"lastKeyFormats,omitempty"`
+
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// NotAfter is the expiry of the certificate issued for a Certificate claim.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// SourceVersion is the version of the external data last written by an
+	// External claim, e.g. the Vault KV version.
+	SourceVersion string `json:"sourceVersion,omitempty"`
+
+	// Push reports the last write of the Secret data to the push store.
+	// +optional
+	Push *PushStatus `json:"push,omitempty"`
+
+	// TemplatesChecksum identifies the templates last rendered into the Secret.
+	TemplatesChecksum string `json:"templatesChecksum,omitempty"`
+
+	// ObservedGeneration is the .metadata.generation the status was last computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Targets reports the replication state per target namespace.
+	// +listType=map
+	// +listMapKey=namespace
+	// +optional
+	Targets []TargetStatus `json:"targets,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// TargetStatus is the replication state of the Secret in a target namespace.
+type TargetStatus struct {
+	Namespace string `json:"namespace"`
+
+	Synced bool `json:"synced"`
+
+	// Message explains why the replica is not synced.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastUpdate is when the replica was last written.
+	// +optional
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// PushStatus is the result of the last push of the Secret data.
+type PushStatus struct {
+	// Result is Pushed, Conflict or Failed.
+	Result string `json:"result"`
+
+	// Message explains why the push failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Version is the version the store reported for the last successful push.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Checksum is a salted hash of the data written by the last successful push.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// LastPushTime is when the data was last written to the store.
+	// +optional
+	LastPushTime *metav1.Time `json:"lastPushTime,omitempty"`
+}
+
+// Push results reported in PushStatus.Result.
+const (
+	PushResultPushed   = "Pushed"
+	PushResultConflict = "Conflict"
+	PushResultFailed   = "Failed"
+)
+
+// Condition types reported in SecretClaimStatus.Conditions.
+const (
+	// ConditionReady is True when the Secret matches the current spec.
+	ConditionReady = "Ready"
+	// ConditionSecretCreated is True when the Secret was created or updated successfully.
+	ConditionSecretCreated = "SecretCreated"
+	// ConditionGenerationValid is True when the generation and rotation spec is valid.
+	ConditionGenerationValid = "GenerationValid"
+	// ConditionOwnershipConflict is True when a Secret with the same name exists
+	// and is not controlled by the claim.
+	ConditionOwnershipConflict = "OwnershipConflict"
+	// ConditionTargetsSynced is True when the Secret is replicated to every target
+	// namespace. It is only set on claims with Targets.
+	ConditionTargetsSynced = "TargetsSynced"
+	// ConditionPushSynced is True when the store holds the current Secret data. It
+	// is only set on claims with Push.
+	ConditionPushSynced = "PushSynced"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // SecretClaim is the Schema for the secretclaims API
 type SecretClaim struct {
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiKeyRole       string
+	apiKeyNamespaces []string
+	apiKeyVerbs      []string
+	apiKeyExpiresIn  time.Duration
+)
+
+// apiKeyCmd groups the "ksec apikey" commands
+var apiKeyCmd = &cobra.Command{
+	Use:     "apikey",
+	Aliases: []string{"apikeys"},
+	Short:   "Manage API keys for automation",
+	Long: `API keys are long-lived credentials for CI pipelines and other automation,
+limited to a role, namespaces and optionally verbs. Pass them with --token or
+KSEC_TOKEN like a JWT. Managing them needs the admin role by default.`,
+}
+
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create an API key and print it",
+	Long: `Creates an API key. The key is printed once and cannot be retrieved again.
+This corresponds to the POST /api-keys API endpoint.`,
+	Example: `  ksec apikey create ci-deploy --role operator -n staging --verbs get,update,rotate --expires-in 2160h`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runCreateAPIKey,
+}
+
+var apiKeyListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List API keys",
+	Long:    `Lists the API keys without the keys themselves. This corresponds to the GET /api-keys API endpoint.`,
+	Example: `  ksec apikey list`,
+	Args:    cobra.NoArgs,
+	RunE:    runListAPIKeys,
+}
+
+var apiKeyDeleteCmd = &cobra.Command{
+	Use:     "delete ID",
+	Aliases: []string{"del", "rm", "revoke"},
+	Short:   "Revoke an API key",
+	Long:    `Deletes an API key, which is rejected from then on. This corresponds to the DELETE /api-keys/{id} API endpoint.`,
+	Example: `  ksec apikey delete 3f9c2a7d41b06e58`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runDeleteAPIKey,
+}
+
+func init() {
+	rootCmd.AddCommand(apiKeyCmd)
+	apiKeyCmd.AddCommand(apiKeyCreateCmd, apiKeyListCmd, apiKeyDeleteCmd)
+
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyRole, "role", "", "Role the key acts with (required)")
+	apiKeyCreateCmd.Flags().StringSliceVarP(&apiKeyNamespaces, "namespace", "n", nil, "Allowed namespaces of the key (required)")
+	apiKeyCreateCmd.Flags().StringSliceVar(&apiKeyVerbs, "verbs", nil, "Limit the key to these verbs")
+	apiKeyCreateCmd.Flags().DurationVar(&apiKeyExpiresIn, "expires-in", 0, "Lifetime of the key, e.g. 720h (default: no expiry)")
+	apiKeyCreateCmd.MarkFlagRequired("role")
+	apiKeyCreateCmd.MarkFlagRequired("namespace")
+}
+
+func runCreateAPIKey(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	createReq := api.CreateAPIKeyRequest{Name: args[0], Role: apiKeyRole, Namespaces: apiKeyNamespaces}
+	if len(apiKeyVerbs) > 0 {
+		createReq.Verbs = &apiKeyVerbs
+	}
+	if apiKeyExpiresIn > 0 {
+		seconds := int64(apiKeyExpiresIn / time.Second)
+		createReq.ExpiresIn = &seconds
+	}
+	reqBody, err := json.Marshal(createReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", fmt.Sprintf("%s/api-keys", serverURL), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	responseBytes, err := expectAPIResponse(httpReq, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+	var created api.CreateAPIKeyResponse
+	if err := json.Unmarshal(responseBytes, &created); err != nil {
+		return fmt.Errorf("failed to decode successful response: %w", err)
+	}
+
+	fmt.Printf("✅ API key '%s' created (ID: %s).\n", created.ApiKey.Name, created.ApiKey.Id)
+	fmt.Println("Store it now, it cannot be shown again:")
+	fmt.Println(created.Key)
+	return nil
+}
+
+func runListAPIKeys(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	httpReq, err := http.NewRequest("GET", fmt.Sprintf("%s/api-keys", serverURL), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	responseBytes, err := expectAPIResponse(httpReq, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	var list api.ListAPIKeysResponse
+	if err := json.Unmarshal(responseBytes, &list); err != nil {
+		return fmt.Errorf("failed to decode successful response: %w", err)
+	}
+
+	if len(list.Items) == 0 {
+		fmt.Println("No API keys found.")
+		return nil
+	}
+	fmt.Printf("\n%-18s %-20s %-12s %-25s %-20s %s\n", "ID", "NAME", "ROLE", "NAMESPACES", "VERBS", "EXPIRES")
+	fmt.Println("--------------------------------------------------------------------------------------------------------------")
+	for _, k := range list.Items {
+		verbs := "*"
+		if k.Verbs != nil {
+			verbs = strings.Join(*k.Verbs, ",")
+		}
+		expires := "never"
+		if k.ExpiresAt != nil {
+			expires = k.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%-18s %-20s %-12s %-25s %-20s %s\n", k.Id, k.Name, k.Role, strings.Join(k.Namespaces, ","), verbs, expires)
+	}
+	return nil
+}
+
+func runDeleteAPIKey(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	httpReq, err := http.NewRequest("DELETE", fmt.Sprintf("%s/api-keys/%s", serverURL, args[0]), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if _, err := expectAPIResponse(httpReq, http.StatusOK); err != nil {
+		return err
+	}
+	fmt.Printf("✅ API key '%s' revoked.\n", args[0])
+	return nil
+}
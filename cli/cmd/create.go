@@ -8,6 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
@@ -19,9 +22,52 @@ var (
 	createNamespace string
 	createType      string
 	createDataFile  string
+	createEncrypt   bool
 	createDataKeys  []string
 	createLength    int
 	createEncoding  string
+
+	createKeyFormats []string
+
+	createRotateInterval string
+	createRotateSchedule string
+
+	createTemplates []string
+
+	createDeletionPolicy string
+
+	createSecretType     string
+	createTLSCertFile    string
+	createTLSKeyFile     string
+	createSSHKeyFile     string
+	createUsername       string
+	createPassword       string
+	createDockerRegistry string
+	createDockerUsername string
+
+	// Certificate flags are shared by create and update.
+	certCommonName   string
+	certDNSNames     []string
+	certIPAddresses  []string
+	certKeyAlgorithm string
+	certKeySize      int
+	certDuration     string
+	certRenewBefore  string
+	certIsCA         bool
+	certIssuer       string
+
+	// Password policy flags are shared by create and update.
+	keyLengths          []string
+	keyCharsets         []string
+	keyPrefixes         []string
+	keyMinDigits        []string
+	keyMinSymbols       []string
+	keyMinUppercase     []string
+	keyExcludeAmbiguous []string
+
+	// Replication flags are shared by create and update.
+	targetNamespaces []string
+	targetSelector   []string
 )
 
 // createCmd represents the create command
@@ -30,12 +76,50 @@ var createCmd = &cobra.Command{
 	Aliases: []string{"add", "new"},
 	Short:   "Create a new SecretClaim resource",
 	Long: `Creates a new SecretClaim resource in the API. Requires the resource name and type. 
-Type can be 'Opaque' (for passing static data) or 'AutoGenerated' (for dynamic generation).`,
+Type can be 'Opaque' (for passing static data), 'AutoGenerated' (for dynamic generation)
+or 'Certificate' (for a TLS certificate issued by the operator).`,
 	Example: `# Create an Opaque secret with data from a file
   ./ksec create my-db-secret --type Opaque -n staging --data-file ./secret-data.json
 
   # Create an AutoGenerated secret
-  ./ksec create my-password-secret --type AutoGenerated --length 32 --encoding alphanumeric --keys '["db_pass","db_login"]'`,
+  ./ksec create my-password-secret --type AutoGenerated --length 32 --encoding alphanumeric --keys '["db_pass","db_login"]'
+
+  # Create an AutoGenerated secret rotated every 90 days
+  ./ksec create my-password-secret --type AutoGenerated --length 32 --key db_pass --rotate-interval 2160h
+
+  # Create an AutoGenerated secret with a connection string built from the generated password
+  ./ksec create my-db-secret --type AutoGenerated --length 32 --key password --template 'dsn=postgres://app:{{ .password | urlquery }}@db:5432/app'
+
+  # Create an SSH deploy key usable as kubernetes.io/ssh-auth secret, the public key is stored as ssh-privatekey.pub
+  ./ksec create deploy-key --type AutoGenerated --length 32 --key ssh-privatekey --key-format ssh-privatekey=ssh-ed25519 --secret-type kubernetes.io/ssh-auth
+
+  # Create a JWT signing key and a 4096 bit RSA SSH key
+  ./ksec create signing-keys --type AutoGenerated --length 32 --key jwt,ssh --key-format jwt=jwk-ec --key-format ssh=ssh-rsa:4096
+
+  # Create a database password without symbols the driver rejects, with at least 2 digits and 1 uppercase letter
+  ./ksec create my-db-secret --type AutoGenerated --length 32 --key db_pass --key-charset 'db_pass=abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789-_' --min-digits db_pass=2 --min-uppercase db_pass=1
+
+  # Create an API token with a prefix and no ambiguous characters
+  ./ksec create my-api-token --type AutoGenerated --length 32 --key token --key-prefix token=sk_live_ --exclude-ambiguous token
+
+  # Create an Opaque secret whose values are sealed locally and only readable by the controller
+  ./ksec create my-db-secret --type Opaque -n staging --data-file ./secret-data.json --encrypt
+
+  # Create a TLS secret from existing PEM files
+  ./ksec create my-tls --type Opaque --secret-type kubernetes.io/tls --tls-cert ./tls.crt --tls-key ./tls.key
+
+  # Create an image pull secret with a generated registry password
+  ./ksec create my-pull-secret --type AutoGenerated --length 32 --key password --secret-type kubernetes.io/dockerconfigjson --docker-registry registry.example.com --docker-username ci
+
+  # Create a CA and a server certificate signed by it
+  ./ksec create internal-ca --type Certificate --common-name internal-ca --ca --duration 87600h
+  ./ksec create api-tls --type Certificate --common-name api --dns-name api.default.svc --issuer internal-ca --renew-before 360h
+
+  # Create a secret that survives deletion of the claim
+  ./ksec create my-db-secret --type AutoGenerated --length 32 --key password --deletion-policy Retain
+
+  # Create a registry secret replicated to two namespaces and every namespace labeled team=payments
+  ./ksec create my-pull-secret --type Opaque -f ./pull-secret.json --target-namespace ci --target-namespace staging --target-selector team=payments`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCreateSecret,
 }
@@ -43,22 +127,39 @@ Type can be 'Opaque' (for passing static data) or 'AutoGenerated' (for dynamic g
 func init() {
 	rootCmd.AddCommand(createCmd)
 
-	createCmd.Flags().StringVarP(&createType, "type", "", "", "Secret type: Opaque or AutoGenerated")
+	createCmd.Flags().StringVarP(&createType, "type", "", "", "Secret type: Opaque, AutoGenerated or Certificate")
 	createCmd.Flags().StringVarP(&createNamespace, "namespace", "n", "default", "Target Kubernetes namespace")
 
 	createCmd.Flags().StringVarP(&createDataFile, "data-file", "f", "", "Path to a JSON file with 'data' or 'generationConfig'")
+	createCmd.Flags().BoolVar(&createEncrypt, "encrypt", false, "Seal the data values to the controller's public key before sending them (type=Opaque)")
 
 	createCmd.Flags().IntVarP(&createLength, "length", "l", 0, "Length of the generated secret (if type=AutoGenerated)")
 	createCmd.Flags().StringVar(&createEncoding, "encoding", "alphanumeric", "Encoding for the generated secret (symbols, digits, alphanumeric)")
 	createCmd.Flags().StringSliceVarP(&createDataKeys, "key", "k", []string{}, "Keys to generate (comma-separated: db_pass,db_user)")
+	createCmd.Flags().StringArrayVar(&createKeyFormats, "key-format", []string{}, "Generation format of a key as KEY=FORMAT[:BITS]: password, ssh-ed25519, ssh-rsa, random-bytes, jwk-rsa, jwk-ec or jwk-ed25519. Can be specified multiple times.")
+	createCmd.Flags().StringVar(&createRotateInterval, "rotate-interval", "", "Regenerate the secret on this interval, e.g. 2160h (if type=AutoGenerated)")
+	createCmd.Flags().StringVar(&createRotateSchedule, "rotate-schedule", "", "Regenerate the secret on this cron schedule, e.g. '0 3 1 * *' (if type=AutoGenerated)")
+	createCmd.Flags().StringArrayVar(&createTemplates, "template", []string{}, "Templated key to render from the data values (e.g., dsn='postgres://app:{{ .password }}@db/app'). Can be specified multiple times.")
+	createCmd.Flags().StringVar(&createDeletionPolicy, "deletion-policy", "", "What happens to the Kubernetes Secret when the claim is deleted: Delete, Retain or Orphan")
+	createCmd.Flags().StringVar(&createSecretType, "secret-type", "", "Kubernetes Secret type: Opaque, kubernetes.io/tls, kubernetes.io/dockerconfigjson, kubernetes.io/basic-auth or kubernetes.io/ssh-auth")
+	createCmd.Flags().StringVar(&createTLSCertFile, "tls-cert", "", "Path to a PEM certificate stored as tls.crt (type=Opaque)")
+	createCmd.Flags().StringVar(&createTLSKeyFile, "tls-key", "", "Path to a PEM private key stored as tls.key (type=Opaque)")
+	createCmd.Flags().StringVar(&createSSHKeyFile, "ssh-key", "", "Path to a private key stored as ssh-privatekey (type=Opaque)")
+	createCmd.Flags().StringVar(&createUsername, "username", "", "Value stored as username (type=Opaque)")
+	createCmd.Flags().StringVar(&createPassword, "password", "", "Value stored as password (type=Opaque)")
+	createCmd.Flags().StringVar(&createDockerRegistry, "docker-registry", "", "Registry host for .dockerconfigjson")
+	createCmd.Flags().StringVar(&createDockerUsername, "docker-username", "", "Registry user for .dockerconfigjson")
+	addPasswordPolicyFlags(createCmd)
+	addCertificateFlags(createCmd)
+	addTargetFlags(createCmd)
 	createCmd.MarkFlagRequired("type")
 }
 
 func runCreateSecret(cmd *cobra.Command, args []string) error {
 	createName = args[0]
 
-	if createType != "Opaque" && createType != "AutoGenerated" {
-		return fmt.Errorf("invalid secret type: %s. Must be 'Opaque' or 'AutoGenerated'", createType)
+	if createType != "Opaque" && createType != "AutoGenerated" && createType != "Certificate" {
+		return fmt.Errorf("invalid secret type: %s. Must be 'Opaque', 'AutoGenerated' or 'Certificate'", createType)
 	}
 
 	req := api.CreateSecretRequest{
@@ -67,9 +168,13 @@ func runCreateSecret(cmd *cobra.Command, args []string) error {
 		Type:      api.CreateSecretRequestType(createType),
 	}
 
-	hasGenerationFlags := createLength != 0 || createEncoding != "" || len(createDataKeys) > 0
-	if createType == "Opaque" && hasGenerationFlags {
-		return fmt.Errorf("generation flags (--length, --key, --encoding) are only valid for AutoGenerated type")
+	hasGenerationFlags := createLength != 0 || cmd.Flags().Changed("encoding") || len(createDataKeys) > 0 || len(createKeyFormats) > 0 || hasPasswordPolicyFlags() || createRotateInterval != "" || createRotateSchedule != ""
+	if createType != "AutoGenerated" && hasGenerationFlags {
+		return fmt.Errorf("generation flags (--length, --key, --key-format, --encoding, --min-digits, ...) are only valid for AutoGenerated type")
+	}
+
+	if createType != "Certificate" && hasCertificateFlags() {
+		return fmt.Errorf("certificate flags (--common-name, --dns-name, --issuer, ...) are only valid for Certificate type")
 	}
 
 	switch createType {
@@ -134,9 +239,72 @@ func runCreateSecret(cmd *cobra.Command, args []string) error {
 				Encoding: &generationEncoding,
 				DataKeys: &dataKeys,
 			}
+			keys, err := keyGenerationsFromFlags(createKeyFormats)
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				genConfig.Keys = &keys
+			}
 			req.GenerationConfig = &genConfig
 		}
 
+		if createRotateInterval != "" || createRotateSchedule != "" {
+			rotation := api.RotationConfig{}
+			if createRotateInterval != "" {
+				rotation.Interval = &createRotateInterval
+			}
+			if createRotateSchedule != "" {
+				rotation.Schedule = &createRotateSchedule
+			}
+			req.Rotation = &rotation
+		}
+
+	case "Certificate":
+		if certCommonName == "" && len(certDNSNames) == 0 {
+			return fmt.Errorf("flag --common-name or --dns-name is required for type Certificate")
+		}
+		req.Certificate = certificateFromFlags()
+	}
+
+	if len(createTemplates) > 0 {
+		templatesMap := make(map[string]string)
+		if err := parseKeyValues(createTemplates, templatesMap); err != nil {
+			return fmt.Errorf("invalid template format: %w", err)
+		}
+		req.Templates = &templatesMap
+	}
+
+	if err := applySecretTypeFlags(&req); err != nil {
+		return err
+	}
+
+	if createEncrypt {
+		if req.Data == nil {
+			return fmt.Errorf("--encrypt is only valid for Opaque type")
+		}
+		if token == "" {
+			return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+		}
+		sealed, err := sealData(createNamespace, createName, *req.Data)
+		if err != nil {
+			return err
+		}
+		req.Data = nil
+		req.EncryptedData = &sealed
+	}
+
+	if createDeletionPolicy != "" {
+		policy := api.DeletionPolicy(createDeletionPolicy)
+		req.DeletionPolicy = &policy
+	}
+
+	if hasTargetFlags() {
+		targets, err := targetsFromFlags()
+		if err != nil {
+			return err
+		}
+		req.Targets = targets
 	}
 
 	if token == "" {
@@ -179,3 +347,229 @@ func runCreateSecret(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// parseKeyFormats parses KEY=FORMAT[:BITS] values of --key-format.
+func parseKeyFormats(values []string) (map[string]api.KeyGeneration, error) {
+	raw := make(map[string]string)
+	if err := parseKeyValues(values, raw); err != nil {
+		return nil, fmt.Errorf("invalid key format: %w", err)
+	}
+
+	keys := make(map[string]api.KeyGeneration, len(raw))
+	for key, value := range raw {
+		formatName, bitsValue, hasBits := strings.Cut(value, ":")
+		format := api.KeyGenerationFormat(formatName)
+		gen := api.KeyGeneration{Format: &format}
+		if hasBits {
+			bits, err := strconv.Atoi(bitsValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key size for %s: %s", key, bitsValue)
+			}
+			gen.Bits = &bits
+		}
+		keys[key] = gen
+	}
+	return keys, nil
+}
+
+// addPasswordPolicyFlags registers the per-key password policy flags of create and update on cmd.
+func addPasswordPolicyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&keyLengths, "key-length", []string{}, "Length of a single key as KEY=N, overrides --length. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&keyCharsets, "key-charset", []string{}, "Characters a key is generated from as KEY=CHARS, overrides --encoding. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&keyPrefixes, "key-prefix", []string{}, "Prefix prepended to a generated key as KEY=PREFIX. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&keyMinDigits, "min-digits", []string{}, "Least number of digits in a key as KEY=N. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&keyMinSymbols, "min-symbols", []string{}, "Least number of symbols in a key as KEY=N. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&keyMinUppercase, "min-uppercase", []string{}, "Least number of uppercase letters in a key as KEY=N. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&keyExcludeAmbiguous, "exclude-ambiguous", []string{}, "Key generated without easily confused characters (0O1lI|). Can be specified multiple times.")
+}
+
+func hasPasswordPolicyFlags() bool {
+	return len(keyLengths) > 0 || len(keyCharsets) > 0 || len(keyPrefixes) > 0 || len(keyMinDigits) > 0 ||
+		len(keyMinSymbols) > 0 || len(keyMinUppercase) > 0 || len(keyExcludeAmbiguous) > 0
+}
+
+// keyGenerationsFromFlags merges --key-format values and the password policy flags
+// into per-key generation settings.
+func keyGenerationsFromFlags(formats []string) (map[string]api.KeyGeneration, error) {
+	keys, err := parseKeyFormats(formats)
+	if err != nil {
+		return nil, err
+	}
+
+	intFlags := []struct {
+		name   string
+		values []string
+		set    func(gen *api.KeyGeneration, value *int)
+	}{
+		{"--key-length", keyLengths, func(gen *api.KeyGeneration, value *int) { gen.Length = value }},
+		{"--min-digits", keyMinDigits, func(gen *api.KeyGeneration, value *int) { gen.MinDigits = value }},
+		{"--min-symbols", keyMinSymbols, func(gen *api.KeyGeneration, value *int) { gen.MinSymbols = value }},
+		{"--min-uppercase", keyMinUppercase, func(gen *api.KeyGeneration, value *int) { gen.MinUppercase = value }},
+	}
+	for _, flag := range intFlags {
+		raw := make(map[string]string)
+		if err := parseKeyValues(flag.values, raw); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", flag.name, err)
+		}
+		for key, value := range raw {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s for %s: %s", flag.name, key, value)
+			}
+			gen := keys[key]
+			flag.set(&gen, &n)
+			keys[key] = gen
+		}
+	}
+
+	stringFlags := []struct {
+		name   string
+		values []string
+		set    func(gen *api.KeyGeneration, value *string)
+	}{
+		{"--key-charset", keyCharsets, func(gen *api.KeyGeneration, value *string) { gen.Charset = value }},
+		{"--key-prefix", keyPrefixes, func(gen *api.KeyGeneration, value *string) { gen.Prefix = value }},
+	}
+	for _, flag := range stringFlags {
+		raw := make(map[string]string)
+		if err := parseKeyValues(flag.values, raw); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", flag.name, err)
+		}
+		for key, value := range raw {
+			gen := keys[key]
+			flag.set(&gen, &value)
+			keys[key] = gen
+		}
+	}
+
+	for _, key := range keyExcludeAmbiguous {
+		exclude := true
+		gen := keys[key]
+		gen.ExcludeAmbiguous = &exclude
+		keys[key] = gen
+	}
+	return keys, nil
+}
+
+// addTargetFlags registers the replication flags of create and update on cmd.
+func addTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&targetNamespaces, "target-namespace", []string{}, "Namespace the Kubernetes Secret is replicated to. Can be specified multiple times.")
+	cmd.Flags().StringArrayVar(&targetSelector, "target-selector", []string{}, "Label a namespace must have to receive a replica as KEY=VALUE (admin only). Can be specified multiple times.")
+}
+
+func hasTargetFlags() bool {
+	return len(targetNamespaces) > 0 || len(targetSelector) > 0
+}
+
+// targetsFromFlags builds the replication targets from --target-namespace and --target-selector.
+func targetsFromFlags() (*api.TargetsConfig, error) {
+	targets := &api.TargetsConfig{}
+	if len(targetNamespaces) > 0 {
+		namespaces := slices.Clone(targetNamespaces)
+		targets.Namespaces = &namespaces
+	}
+	if len(targetSelector) > 0 {
+		selector := make(map[string]string)
+		if err := parseKeyValues(targetSelector, selector); err != nil {
+			return nil, fmt.Errorf("invalid --target-selector: %w", err)
+		}
+		targets.NamespaceSelector = &selector
+	}
+	return targets, nil
+}
+
+// addCertificateFlags registers the certificate flags of create and update on cmd.
+func addCertificateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&certCommonName, "common-name", "", "Certificate subject common name (type=Certificate)")
+	cmd.Flags().StringSliceVar(&certDNSNames, "dns-name", []string{}, "Certificate DNS names (type=Certificate). Can be specified multiple times.")
+	cmd.Flags().StringSliceVar(&certIPAddresses, "ip", []string{}, "Certificate IP addresses (type=Certificate). Can be specified multiple times.")
+	cmd.Flags().StringVar(&certKeyAlgorithm, "key-algorithm", "", "Certificate key algorithm: RSA or ECDSA (default ECDSA)")
+	cmd.Flags().IntVar(&certKeySize, "key-size", 0, "Certificate key size: 2048, 3072, 4096 for RSA, 256 or 384 for ECDSA")
+	cmd.Flags().StringVar(&certDuration, "duration", "", "Certificate validity, e.g. 2160h (type=Certificate)")
+	cmd.Flags().StringVar(&certRenewBefore, "renew-before", "", "Renew the certificate this long before it expires, e.g. 360h (type=Certificate)")
+	cmd.Flags().BoolVar(&certIsCA, "ca", false, "Issue a CA certificate other Certificate claims can use as issuer")
+	cmd.Flags().StringVar(&certIssuer, "issuer", "", "Name of the CA Certificate claim signing the certificate, self-signed if empty")
+}
+
+func hasCertificateFlags() bool {
+	return certCommonName != "" || len(certDNSNames) > 0 || len(certIPAddresses) > 0 || certKeyAlgorithm != "" ||
+		certKeySize != 0 || certDuration != "" || certRenewBefore != "" || certIsCA || certIssuer != ""
+}
+
+// certificateFromFlags builds the certificate settings from the certificate flags.
+func certificateFromFlags() *api.CertificateConfig {
+	certificate := api.CertificateConfig{}
+	if certCommonName != "" {
+		certificate.CommonName = &certCommonName
+	}
+	if len(certDNSNames) > 0 {
+		certificate.DnsNames = &certDNSNames
+	}
+	if len(certIPAddresses) > 0 {
+		certificate.IpAddresses = &certIPAddresses
+	}
+	if certKeyAlgorithm != "" {
+		algorithm := api.CertificateConfigKeyAlgorithm(strings.ToUpper(certKeyAlgorithm))
+		certificate.KeyAlgorithm = &algorithm
+	}
+	if certKeySize != 0 {
+		certificate.KeySize = &certKeySize
+	}
+	if certDuration != "" {
+		certificate.Duration = &certDuration
+	}
+	if certRenewBefore != "" {
+		certificate.RenewBefore = &certRenewBefore
+	}
+	if certIsCA {
+		certificate.IsCA = &certIsCA
+	}
+	if certIssuer != "" {
+		certificate.IssuerRef = &certIssuer
+	}
+	return &certificate
+}
+
+// applySecretTypeFlags fills the secret type, the well-known data keys and the
+// registry credentials from the typed-secret flags.
+func applySecretTypeFlags(req *api.CreateSecretRequest) error {
+	if createSecretType != "" {
+		secretType := api.SecretType(createSecretType)
+		req.SecretType = &secretType
+	}
+
+	files := map[string]string{"tls.crt": createTLSCertFile, "tls.key": createTLSKeyFile, "ssh-privatekey": createSSHKeyFile}
+	values := map[string]string{"username": createUsername, "password": createPassword}
+	for key, path := range files {
+		if path == "" {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		values[key] = string(content)
+	}
+
+	for key, value := range values {
+		if value == "" {
+			continue
+		}
+		if req.Data == nil {
+			return fmt.Errorf("flags for key %s are only valid for Opaque type", key)
+		}
+		(*req.Data)[key] = value
+	}
+
+	if createDockerRegistry != "" || createDockerUsername != "" {
+		if createDockerRegistry == "" || createDockerUsername == "" {
+			return fmt.Errorf("--docker-registry and --docker-username are required for dockerconfigjson")
+		}
+		req.DockerConfig = &api.DockerConfig{Registry: createDockerRegistry, Username: createDockerUsername}
+		if req.SecretType == nil {
+			secretType := api.SecretTypeKubernetesIoDockerconfigjson
+			req.SecretType = &secretType
+		}
+	}
+	return nil
+}
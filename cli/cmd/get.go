@@ -6,7 +6,10 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
 	"github.com/spf13/cobra"
@@ -14,21 +17,26 @@ import (
 
 var getNamespace string
 var getSecretName string
+var getReveal bool
 
 var getCmd = &cobra.Command{
 	Use:   "get NAME",
 	Short: "Get full details of a SecretClaim resource",
 	Long: `Retrieves the full specification and current status, including secret data 
-(if synchronized), for a specific SecretClaim resource by its name.`,
-	Example: `  ./ksec get my-db-secret -n staging`,
-	Args:    cobra.ExactArgs(1),
-	RunE:    runGetSecret,
+(if synchronized), for a specific SecretClaim resource by its name.
+Secret values are masked unless --reveal is set; revealing them requires the
+secrets:reveal permission and is recorded in the server audit log.`,
+	Example: `  ./ksec get my-db-secret -n staging
+  ./ksec get my-db-secret -n staging --reveal`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGetSecret,
 }
 
 func init() {
 	rootCmd.AddCommand(getCmd)
 
 	getCmd.Flags().StringVarP(&getNamespace, "namespace", "n", "default", "Target Kubernetes namespace")
+	getCmd.Flags().BoolVar(&getReveal, "reveal", false, "Show plaintext secret values (requires the secrets:reveal permission; audited)")
 
 }
 
@@ -66,6 +74,44 @@ func runGetSecret(cmd *cobra.Command, args []string) error {
 
 	printSecretDetails(successResponse)
 
+	if getReveal {
+		return revealSecret()
+	}
+
+	return nil
+}
+
+// revealSecret fetches and prints the plaintext values of the Secret.
+func revealSecret() error {
+	revealURL := fmt.Sprintf("%s/secrets/%s/reveal?namespace=%s", serverURL, getSecretName, getNamespace)
+
+	httpReq, err := http.NewRequest("GET", revealURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	responseBytes, statusCode, err := doAPIRequest(httpReq)
+	if err != nil {
+		return err
+	}
+
+	if statusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if json.Unmarshal(responseBytes, &errResp) == nil {
+			return fmt.Errorf("reveal failed (Status: %d, Code: %s): %s", errResp.StatusCode, errResp.ErrorCode, errResp.ErrorMessage)
+		}
+		return fmt.Errorf("reveal failed with unexpected status: %d %s", statusCode, http.StatusText(statusCode))
+	}
+
+	var revealed api.RevealSecretResponse
+	if err := json.Unmarshal(responseBytes, &revealed); err != nil {
+		return fmt.Errorf("failed to decode reveal response (Status %d): %w", statusCode, err)
+	}
+
+	fmt.Println("\n--- Revealed Data ---")
+	for _, k := range slices.Sorted(maps.Keys(revealed.Data)) {
+		fmt.Printf("  %s: %s\n", k, revealed.Data[k])
+	}
 	return nil
 }
 
@@ -81,8 +127,17 @@ func printSecretDetails(s api.SecretResponse) {
 	fmt.Printf("Name:        %s\n", s.Name)
 	fmt.Printf("Namespace:   %s\n", namespace)
 	fmt.Printf("Type:        %s\n", s.Type)
+	if s.SecretType != nil {
+		fmt.Printf("Secret Type: %s\n", *s.SecretType)
+	}
+	if s.DockerConfig != nil {
+		fmt.Printf("Registry:    %s (user %s)\n", s.DockerConfig.Registry, s.DockerConfig.Username)
+	}
 	fmt.Printf("UID:         %s\n", *s.Uid)
 	fmt.Printf("Created At:  %s\n", created)
+	if s.DeletionPolicy != nil {
+		fmt.Printf("On Delete:   %s\n", *s.DeletionPolicy)
+	}
 
 	fmt.Println("\n--- Status ---")
 	fmt.Printf("Current Status: %s\n", s.Status.CurrentStatus)
@@ -100,18 +155,119 @@ func printSecretDetails(s api.SecretResponse) {
 		errMessage := *s.Status.ErrorMessage
 		fmt.Printf("Error Message:  %s\n", errMessage)
 	}
+	if s.Status.LastRotationTime != nil {
+		fmt.Printf("Last Rotation:   %s\n", s.Status.LastRotationTime.Format("2006-01-02 15:04:05"))
+	}
+	if s.Status.NextRotationTime != nil {
+		fmt.Printf("Next Rotation:   %s\n", s.Status.NextRotationTime.Format("2006-01-02 15:04:05"))
+	}
+	if s.Status.NotAfter != nil {
+		fmt.Printf("Not After:       %s\n", s.Status.NotAfter.Format("2006-01-02 15:04:05"))
+	}
+	if s.Status.ObservedGeneration != nil {
+		fmt.Printf("Observed Gen:    %d\n", *s.Status.ObservedGeneration)
+	}
+
+	if s.Status.Targets != nil && len(*s.Status.Targets) > 0 {
+		fmt.Println("\n--- Replicas ---")
+		for _, t := range *s.Status.Targets {
+			message, updated := "", ""
+			if t.Message != nil {
+				message = *t.Message
+			}
+			if t.LastUpdate != nil {
+				updated = t.LastUpdate.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("  %-20s synced=%-5t %-19s %s\n", t.Namespace, t.Synced, updated, message)
+		}
+	}
+
+	if s.Status.Conditions != nil && len(*s.Status.Conditions) > 0 {
+		fmt.Println("\n--- Conditions ---")
+		for _, c := range *s.Status.Conditions {
+			reason, message, since := "", "", ""
+			if c.Reason != nil {
+				reason = *c.Reason
+			}
+			if c.Message != nil {
+				message = *c.Message
+			}
+			if c.LastTransitionTime != nil {
+				since = c.LastTransitionTime.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("  %-18s %-7s %-20s %s  %s\n", c.Type, c.Status, reason, since, message)
+		}
+	}
 
 	if s.Type == "Opaque" && s.Data != nil && len(*s.Data) > 0 {
-		fmt.Println("\n--- Data (Base64 Encoded in K8s) ---")
+		fmt.Println("\n--- Data (masked, use --reveal) ---")
 		for k, v := range *s.Data {
 			fmt.Printf("  %s: %s\n", k, v)
 		}
-	} else if s.Type == "AutoGenerated" && s.GenerationConfig != nil {
+	}
+	if s.EncryptedData != nil && len(*s.EncryptedData) > 0 {
+		fmt.Println("\n--- Sealed Keys ---")
+		for k := range *s.EncryptedData {
+			fmt.Printf("  %s\n", k)
+		}
+	}
+	if s.Type == "AutoGenerated" && s.GenerationConfig != nil {
 		encoding := *s.GenerationConfig.Encoding
 		fmt.Println("\n--- Generation Config ---")
 		fmt.Printf("  Length:    %d\n", s.GenerationConfig.Length)
 		fmt.Printf("  Encoding:  %s\n", encoding)
 		fmt.Printf("  Data Keys: %v\n", s.GenerationConfig.DataKeys)
+		if s.GenerationConfig.Keys != nil {
+			for key, gen := range *s.GenerationConfig.Keys {
+				fmt.Printf("  Key %s:%s\n", key, describeKeyGeneration(gen))
+			}
+		}
+	}
+
+	if s.Certificate != nil {
+		fmt.Println("\n--- Certificate ---")
+		if s.Certificate.CommonName != nil && *s.Certificate.CommonName != "" {
+			fmt.Printf("  Common Name: %s\n", *s.Certificate.CommonName)
+		}
+		if s.Certificate.DnsNames != nil {
+			fmt.Printf("  DNS Names:   %v\n", *s.Certificate.DnsNames)
+		}
+		if s.Certificate.IpAddresses != nil {
+			fmt.Printf("  IPs:         %v\n", *s.Certificate.IpAddresses)
+		}
+		if s.Certificate.IsCA != nil && *s.Certificate.IsCA {
+			fmt.Println("  CA:          true")
+		}
+		if s.Certificate.IssuerRef != nil {
+			fmt.Printf("  Issuer:      %s\n", *s.Certificate.IssuerRef)
+		}
+	}
+
+	if s.Rotation != nil {
+		fmt.Println("\n--- Rotation ---")
+		if s.Rotation.Interval != nil {
+			fmt.Printf("  Interval:  %s\n", *s.Rotation.Interval)
+		}
+		if s.Rotation.Schedule != nil {
+			fmt.Printf("  Schedule:  %s\n", *s.Rotation.Schedule)
+		}
+	}
+
+	if s.Targets != nil {
+		fmt.Println("\n--- Targets ---")
+		if s.Targets.Namespaces != nil {
+			fmt.Printf("  Namespaces: %v\n", *s.Targets.Namespaces)
+		}
+		if s.Targets.NamespaceSelector != nil {
+			fmt.Printf("  Selector:   %v\n", *s.Targets.NamespaceSelector)
+		}
+	}
+
+	if s.Templates != nil && len(*s.Templates) > 0 {
+		fmt.Println("\n--- Templates ---")
+		for k, v := range *s.Templates {
+			fmt.Printf("  %s: %s\n", k, v)
+		}
 	}
 
 	if s.Labels != nil && len(*s.Labels) > 0 {
@@ -121,3 +277,36 @@ func printSecretDetails(s api.SecretResponse) {
 		}
 	}
 }
+
+// describeKeyGeneration lists the per-key generation settings that are set.
+func describeKeyGeneration(gen api.KeyGeneration) string {
+	var b strings.Builder
+	if gen.Format != nil {
+		fmt.Fprintf(&b, " format=%s", *gen.Format)
+	}
+	if gen.Bits != nil {
+		fmt.Fprintf(&b, " bits=%d", *gen.Bits)
+	}
+	if gen.Length != nil {
+		fmt.Fprintf(&b, " length=%d", *gen.Length)
+	}
+	if gen.Charset != nil {
+		fmt.Fprintf(&b, " charset=%q", *gen.Charset)
+	}
+	if gen.MinDigits != nil {
+		fmt.Fprintf(&b, " min-digits=%d", *gen.MinDigits)
+	}
+	if gen.MinSymbols != nil {
+		fmt.Fprintf(&b, " min-symbols=%d", *gen.MinSymbols)
+	}
+	if gen.MinUppercase != nil {
+		fmt.Fprintf(&b, " min-uppercase=%d", *gen.MinUppercase)
+	}
+	if gen.ExcludeAmbiguous != nil && *gen.ExcludeAmbiguous {
+		b.WriteString(" exclude-ambiguous")
+	}
+	if gen.Prefix != nil {
+		fmt.Fprintf(&b, " prefix=%s", *gen.Prefix)
+	}
+	return b.String()
+}
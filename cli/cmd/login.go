@@ -17,8 +17,12 @@ import (
 
 // Переменные для флагов loginCmd
 var (
-	loginUsername string
-	loginPassword string
+	loginUsername  string
+	loginPassword  string
+	loginOIDC      bool
+	loginIssuer    string
+	loginClientID  string
+	loginNoBrowser bool
 )
 
 // ErrorBadRequest (используется для обработки ошибок)
@@ -33,13 +37,20 @@ var loginCmd = &cobra.Command{
 	Short: "Authenticate and retrieve a JWT token",
 	Long: `Login attempts to authenticate the user using the provided username and password 
 and stores the resulting JWT token for subsequent API calls. If the password is not 
-provided via a flag, the CLI will securely prompt for it.`,
+provided via a flag, the CLI will securely prompt for it.
+
+With --oidc the CLI logs in through the identity provider the server is
+configured with: it prints a code to approve in a browser and stores the
+issued ID token.`,
 	Example: `
   ./ksec login -u admin 
   > password:
 
   # Pass password directly (less secure)
-  ./ksec login -u admin -p secure_pass`,
+  ./ksec login -u admin -p secure_pass
+
+  # Log in through the configured identity provider
+  ./ksec login --oidc`,
 	RunE: runLogin,
 }
 
@@ -47,10 +58,29 @@ func init() {
 	rootCmd.AddCommand(loginCmd)
 	loginCmd.Flags().StringVarP(&loginUsername, "username", "u", "", "Your API username")
 	loginCmd.Flags().StringVarP(&loginPassword, "password", "p", "", "Your API password (passed directly)")
-	loginCmd.MarkFlagRequired("username")
+	loginCmd.Flags().BoolVar(&loginOIDC, "oidc", false, "Log in through the server's OIDC identity provider")
+	loginCmd.Flags().StringVar(&loginIssuer, "issuer", "", "OIDC issuer URL (defaults to the server's)")
+	loginCmd.Flags().StringVar(&loginClientID, "client-id", "", "OIDC client ID (defaults to the server's)")
+	loginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "Do not open a browser for --oidc, only print the code")
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
+	if !loginOIDC && loginUsername == "" {
+		return fmt.Errorf("--username is required unless --oidc is set")
+	}
+
+	if serverURL != "" {
+		if err := saveServerUrl(serverURL); err != nil {
+			fmt.Printf("⚠️ Warning: Failed to save server URL: %s\n", err)
+		} else {
+			fmt.Printf("✅ Server URL saved: %s\n", serverURL)
+		}
+	}
+
+	if loginOIDC {
+		return runOIDCLogin()
+	}
+
 	if loginPassword == "" {
 		fmt.Printf("Enter password for %s: ", loginUsername)
 
@@ -67,14 +97,6 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if serverURL != "" {
-		if err := saveServerUrl(serverURL); err != nil {
-			fmt.Printf("⚠️ Warning: Failed to save server URL: %s\n", err)
-		} else {
-			fmt.Printf("✅ Server URL saved: %s\n", serverURL)
-		}
-	}
-
 	authReq := api.AuthUserRequest{
 		Username: loginUsername,
 		Password: loginPassword,
@@ -114,7 +136,11 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 
 	token = successResponse.Token
-	if err := saveToken(token); err != nil {
+	refreshToken = ""
+	if successResponse.RefreshToken != nil {
+		refreshToken = *successResponse.RefreshToken
+	}
+	if err := saveToken(token, refreshToken); err != nil {
 		fmt.Printf("⚠️ Warning: Failed to save token to disk: %s\n", err)
 	}
 	fmt.Println("✅ Login successful!")
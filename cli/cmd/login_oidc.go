@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+var defaultOIDCScopes = []string{"openid", "email", "profile", "groups"}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type oidcTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// runOIDCLogin logs in with the OAuth 2.0 device authorization grant: the user
+// approves the login in a browser and the CLI stores the issued ID token.
+func runOIDCLogin() error {
+	issuer, clientID, scopes := loginIssuer, loginClientID, defaultOIDCScopes
+	if issuer == "" || clientID == "" {
+		oidcConfig, err := fetchOIDCConfig()
+		if err != nil {
+			return err
+		}
+		if issuer == "" {
+			issuer = oidcConfig.Issuer
+		}
+		if clientID == "" {
+			clientID = oidcConfig.ClientId
+		}
+		if oidcConfig.Scopes != nil && len(*oidcConfig.Scopes) > 0 {
+			scopes = *oidcConfig.Scopes
+		}
+	}
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: 10 * time.Second}
+	discovery, err := auth.DiscoverOIDC(ctx, client, issuer)
+	if err != nil {
+		return err
+	}
+	if discovery.DeviceAuthorizationEndpoint == "" {
+		return fmt.Errorf("issuer %s does not support the device authorization grant", issuer)
+	}
+
+	var device deviceAuthorizationResponse
+	status, err := postForm(client, discovery.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {clientID},
+		"scope":     {strings.Join(scopes, " ")},
+	}, &device)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK || device.DeviceCode == "" {
+		return fmt.Errorf("device authorization failed with status %d", status)
+	}
+
+	verificationURL := device.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = device.VerificationURI
+	}
+	fmt.Printf("To log in, open %s and enter the code: %s\n", device.VerificationURI, device.UserCode)
+	if !loginNoBrowser {
+		if err := openBrowser(verificationURL); err != nil {
+			fmt.Printf("⚠️ Warning: Failed to open a browser: %s\n", err)
+		}
+	}
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+	if device.ExpiresIn <= 0 {
+		deadline = time.Now().Add(10 * time.Minute)
+	}
+
+	fmt.Println("Waiting for the login to be approved...")
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		var tokenResp oidcTokenResponse
+		status, err := postForm(client, discovery.TokenEndpoint, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {device.DeviceCode},
+			"client_id":   {clientID},
+		}, &tokenResp)
+		if err != nil {
+			return err
+		}
+
+		switch tokenResp.Error {
+		case "":
+			if status != http.StatusOK || tokenResp.IDToken == "" {
+				return fmt.Errorf("token request failed with status %d", status)
+			}
+			token = tokenResp.IDToken
+			if err := saveToken(token, ""); err != nil {
+				fmt.Printf("⚠️ Warning: Failed to save token to disk: %s\n", err)
+			}
+			fmt.Println("✅ Login successful!")
+			fmt.Printf("ID token received and stored (Expires in: %d seconds).\n", tokenResp.ExpiresIn)
+			return nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return fmt.Errorf("login was denied")
+		case "expired_token":
+			return fmt.Errorf("login code expired, run ksec login --oidc again")
+		default:
+			return fmt.Errorf("token request failed: %s %s", tokenResp.Error, tokenResp.ErrorDescription)
+		}
+	}
+	return fmt.Errorf("login code expired, run ksec login --oidc again")
+}
+
+func fetchOIDCConfig() (*api.OIDCConfigResponse, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user/oidc", serverURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	responseBytes, statusCode, err := doAPIRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if json.Unmarshal(responseBytes, &errResp) == nil {
+			return nil, fmt.Errorf("API call failed (Status: %d, Code: %s): %s", errResp.StatusCode, errResp.ErrorCode, errResp.ErrorMessage)
+		}
+		return nil, fmt.Errorf("API call failed with unexpected status: %d %s", statusCode, http.StatusText(statusCode))
+	}
+
+	var oidcConfig api.OIDCConfigResponse
+	if err := json.Unmarshal(responseBytes, &oidcConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC configuration: %w", err)
+	}
+	return &oidcConfig, nil
+}
+
+func postForm(client *http.Client, endpoint string, form url.Values, out interface{}) (int, error) {
+	resp, err := client.PostForm(endpoint, form)
+	if err != nil {
+		return 0, fmt.Errorf("request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response from %s (Status %d): %w", endpoint, resp.StatusCode, err)
+	}
+	return resp.StatusCode, nil
+}
+
+func openBrowser(target string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", target).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", target).Start()
+	default:
+		return exec.Command("xdg-open", target).Start()
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// logoutCmd implements the "ksec logout" command
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Revoke the stored tokens and forget them",
+	Long: `Logout revokes the stored access and refresh tokens on the server and removes
+them from the local config. This corresponds to the POST /user/logout API endpoint.`,
+	Example: `  ksec logout`,
+	Args:    cobra.NoArgs,
+	RunE:    runLogout,
+}
+
+func init() {
+	rootCmd.AddCommand(logoutCmd)
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing, nothing to log out")
+	}
+
+	responseBytes, statusCode, err := postLogout()
+	if err != nil {
+		return err
+	}
+	// The body names the refresh token, so an expired access token is
+	// refreshed here rather than by doAPIRequest, which would send the
+	// refresh token it just used up.
+	if statusCode == http.StatusUnauthorized && refreshToken != "" && refreshSession() == nil {
+		if responseBytes, statusCode, err = postLogout(); err != nil {
+			return err
+		}
+	}
+
+	// An expired or already revoked token cannot be used anymore, so the
+	// local tokens are dropped in that case too.
+	if statusCode != http.StatusOK && statusCode != http.StatusUnauthorized {
+		var errResp ErrorResponse
+		if json.Unmarshal(responseBytes, &errResp) == nil {
+			return fmt.Errorf("API call failed (Status: %d, Code: %s): %s", errResp.StatusCode, errResp.ErrorCode, errResp.ErrorMessage)
+		}
+		return fmt.Errorf("API call failed with unexpected status: %d %s", statusCode, http.StatusText(statusCode))
+	}
+
+	token, refreshToken = "", ""
+	if err := saveToken("", ""); err != nil {
+		return fmt.Errorf("failed to remove tokens from disk: %w", err)
+	}
+	fmt.Println("✅ Logged out.")
+	return nil
+}
+
+func postLogout() ([]byte, int, error) {
+	logoutReq := api.LogoutUserRequest{}
+	if refreshToken != "" {
+		logoutReq.RefreshToken = &refreshToken
+	}
+	reqBody, err := json.Marshal(logoutReq)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user/logout", serverURL), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return sendAPIRequest(req)
+}
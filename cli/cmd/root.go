@@ -4,6 +4,7 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,13 +19,15 @@ import (
 )
 
 var (
-	serverURL string
-	token     string
+	serverURL    string
+	token        string
+	refreshToken string
 )
 
 type Config struct {
-	ServerURL string `json:"server_url"`
-	Token     string `json:"token"`
+	ServerURL    string `json:"server_url"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -70,7 +73,7 @@ func init() {
 		"token",
 		"t",
 		token,
-		"JWT Bearer token for authentication",
+		"JWT Bearer token or API key for authentication",
 	)
 
 	if err := rootCmd.PersistentFlags().SetAnnotation("server", "env", []string{"KSEC_SERVER_URL"}); err != nil {
@@ -90,7 +93,48 @@ func init() {
 
 }
 
+// doAPIRequest sends req with the stored token. When the token has expired and
+// a refresh token is stored, the tokens are refreshed and req is sent again.
 func doAPIRequest(req *http.Request) ([]byte, int, error) {
+	responseBytes, statusCode, err := sendAPIRequest(req)
+	if err != nil || statusCode != http.StatusUnauthorized || refreshToken == "" || req.URL.Path == "/user/refresh" {
+		return responseBytes, statusCode, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.Body != nil {
+		if req.GetBody == nil {
+			return responseBytes, statusCode, nil
+		}
+		if retry.Body, err = req.GetBody(); err != nil {
+			return responseBytes, statusCode, nil
+		}
+	}
+	if err := refreshSession(); err != nil {
+		fmt.Printf("⚠️ Warning: Failed to refresh the token: %s\n", err)
+		return responseBytes, statusCode, nil
+	}
+	return sendAPIRequest(retry)
+}
+
+// expectAPIResponse sends req and returns the response body, or an error
+// built from the error response if the status is not wantStatus.
+func expectAPIResponse(req *http.Request, wantStatus int) ([]byte, error) {
+	responseBytes, statusCode, err := doAPIRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != wantStatus {
+		var errResp ErrorResponse
+		if json.Unmarshal(responseBytes, &errResp) == nil {
+			return nil, fmt.Errorf("API call failed (Status: %d, Code: %s): %s", errResp.StatusCode, errResp.ErrorCode, errResp.ErrorMessage)
+		}
+		return nil, fmt.Errorf("API call failed with unexpected status: %d %s", statusCode, http.StatusText(statusCode))
+	}
+	return responseBytes, nil
+}
+
+func sendAPIRequest(req *http.Request) ([]byte, int, error) {
 
 	requestID := uuid.New().String()
 	req.Header.Set("X-Request-ID", requestID)
@@ -121,6 +165,42 @@ func doAPIRequest(req *http.Request) ([]byte, int, error) {
 	return responseBytes, resp.StatusCode, nil
 }
 
+// refreshSession exchanges the stored refresh token for new tokens and saves
+// them.
+func refreshSession() error {
+	reqBody, err := json.Marshal(api.RefreshUserTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user/refresh", serverURL), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	responseBytes, statusCode, err := sendAPIRequest(req)
+	if err != nil {
+		return err
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("refresh failed with status %d, run ksec login again", statusCode)
+	}
+
+	var tokens api.AuthUserResponse
+	if err := json.Unmarshal(responseBytes, &tokens); err != nil {
+		return fmt.Errorf("failed to decode refresh response: %w", err)
+	}
+	token = tokens.Token
+	refreshToken = ""
+	if tokens.RefreshToken != nil {
+		refreshToken = *tokens.RefreshToken
+	}
+	if err := saveToken(token, refreshToken); err != nil {
+		fmt.Printf("⚠️ Warning: Failed to save token to disk: %s\n", err)
+	}
+	return nil
+}
+
 func getAppConfigPath() (string, error) {
 	ex, err := os.Executable()
 	if err != nil {
@@ -133,13 +213,13 @@ func getAppConfigPath() (string, error) {
 	return configFile, nil
 }
 
-func saveToken(t string) error {
+func saveToken(t, refresh string) error {
 	configFile, err := getAppConfigPath()
 	if err != nil {
 		return err
 	}
 
-	cfg := Config{Token: t}
+	cfg := Config{Token: t, RefreshToken: refresh}
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
@@ -196,6 +276,7 @@ func loadToken() {
 			token = cfg.Token
 			fmt.Println(" [Config] Token loaded from file.")
 		}
+		refreshToken = cfg.RefreshToken
 	}
 }
 
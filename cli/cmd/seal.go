@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"crypto/ecdh"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sealing"
+)
+
+// fetchSealingKey downloads the public key the controller opens encryptedData values with.
+func fetchSealingKey() (*ecdh.PublicKey, error) {
+	httpReq, err := http.NewRequest("GET", fmt.Sprintf("%s/sealing-key", serverURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	responseBytes, statusCode, err := doAPIRequest(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if json.Unmarshal(responseBytes, &errResp) == nil {
+			return nil, fmt.Errorf("failed to get sealing key (Status: %d, Code: %s): %s", errResp.StatusCode, errResp.ErrorCode, errResp.ErrorMessage)
+		}
+		return nil, fmt.Errorf("failed to get sealing key: %d %s", statusCode, http.StatusText(statusCode))
+	}
+
+	var key api.SealingKeyResponse
+	if err := json.Unmarshal(responseBytes, &key); err != nil {
+		return nil, fmt.Errorf("failed to decode sealing key: %w", err)
+	}
+	pub, err := sealing.ParsePublicKey([]byte(key.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealing key %s: %w", key.KeyId, err)
+	}
+	if sealing.KeyID(pub) != key.KeyId {
+		return nil, fmt.Errorf("sealing key does not match its ID %s", key.KeyId)
+	}
+	return pub, nil
+}
+
+// sealData seals every value of data for the claim namespace/name, so the values
+// are only readable by the controller and cannot be reused in another claim.
+func sealData(namespace, name string, data map[string]string) (map[string]string, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("--encrypt requires data, pass --data-file or the typed-secret flags")
+	}
+	pub, err := fetchSealingKey()
+	if err != nil {
+		return nil, err
+	}
+
+	scope := sealing.Scope(namespace, name)
+	sealed := make(map[string]string, len(data))
+	for key, value := range data {
+		sealed[key], err = sealing.Seal(pub, scope, key, []byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to seal %s: %w", key, err)
+		}
+	}
+	return sealed, nil
+}
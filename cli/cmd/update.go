@@ -19,10 +19,23 @@ var (
 	updateType       string
 
 	updateDataFile    string
+	updateEncrypt     bool
 	updateLength      int
 	updateEncoding    string
 	updateDataKeyVals []string
+	updateKeyFormats  []string
 	updateRegenerate  bool
+	updateRegenKeys   []string
+
+	updateRotateInterval string
+	updateRotateSchedule string
+	updateNoRotation     bool
+
+	updateTemplates   []string
+	updateNoTemplates bool
+
+	updateDeletionPolicy string
+	updateNoTargets      bool
 
 	updateLabels      []string
 	updateAnnotations []string
@@ -46,14 +59,30 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 
 	updateCmd.Flags().StringVarP(&updateNamespace, "namespace", "n", "default", "Target Kubernetes namespace")
-	updateCmd.Flags().StringVarP(&updateType, "type", "", "", "Change the secret type: Opaque or AutoGenerated")
+	updateCmd.Flags().StringVarP(&updateType, "type", "", "", "Change the secret type: Opaque, AutoGenerated or Certificate")
 
 	updateCmd.Flags().StringVarP(&updateDataFile, "data-file", "f", "", "Path to a JSON file with 'data' payload for Opaque type")
+	updateCmd.Flags().BoolVar(&updateEncrypt, "encrypt", false, "Seal the --data-file values to the controller's public key, replacing the plain data")
 
 	updateCmd.Flags().IntVarP(&updateLength, "length", "l", 0, "New length of the generated secret")
 	updateCmd.Flags().StringVar(&updateEncoding, "encoding", "", "New encoding for the generated secret")
 	updateCmd.Flags().StringArrayVar(&updateDataKeyVals, "keys", []string{}, "New comma-separated list of keys to generate")
+	updateCmd.Flags().StringArrayVar(&updateKeyFormats, "key-format", []string{}, "Generation format of a key as KEY=FORMAT[:BITS], requires --length and --keys. Together with the password policy flags it replaces all existing per-key settings. Can be specified multiple times.")
 	updateCmd.Flags().BoolVarP(&updateRegenerate, "regenerate", "r", false, "Force regeneration of the secret value (AutoGenerated only)")
+	updateCmd.Flags().StringArrayVar(&updateRegenKeys, "regenerate-key", []string{}, "Regenerate only this data key, keeping the others (AutoGenerated only). Can be specified multiple times.")
+	updateCmd.Flags().StringVar(&updateRotateInterval, "rotate-interval", "", "Regenerate the secret on this interval, e.g. 2160h (AutoGenerated only)")
+	updateCmd.Flags().StringVar(&updateRotateSchedule, "rotate-schedule", "", "Regenerate the secret on this cron schedule, e.g. '0 3 1 * *' (AutoGenerated only)")
+	updateCmd.Flags().BoolVar(&updateNoRotation, "no-rotation", false, "Disable scheduled rotation (AutoGenerated only)")
+
+	updateCmd.Flags().StringArrayVar(&updateTemplates, "template", []string{}, "Templated key to render from the data values (e.g., dsn='postgres://app:{{ .password }}@db/app'). Replaces all existing templates. Can be specified multiple times.")
+	updateCmd.Flags().BoolVar(&updateNoTemplates, "no-templates", false, "Remove all templated keys")
+
+	addPasswordPolicyFlags(updateCmd)
+	addCertificateFlags(updateCmd)
+	addTargetFlags(updateCmd)
+	updateCmd.Flags().BoolVar(&updateNoTargets, "no-targets", false, "Stop replicating the Kubernetes Secret to other namespaces and delete the replicas")
+
+	updateCmd.Flags().StringVar(&updateDeletionPolicy, "deletion-policy", "", "What happens to the Kubernetes Secret when the claim is deleted: Delete, Retain or Orphan")
 
 	updateCmd.Flags().StringArrayVar(&updateLabels, "label", []string{}, "Label to set on the resource (e.g., key=value). Can be specified multiple times.")
 	updateCmd.Flags().StringArrayVar(&updateAnnotations, "annotation", []string{}, "Annotation to set on the resource (e.g., key=value). Can be specified multiple times.")
@@ -79,9 +108,17 @@ func runUpdateSecret(cmd *cobra.Command, args []string) error {
 		req.Regenerate = &regenerateValue
 	}
 
+	if len(updateRegenKeys) > 0 {
+		if updateType != "AutoGenerated" {
+			return fmt.Errorf("--regenerate-key is only valid for 'AutoGenerated' type secrets")
+		}
+		fieldsSet = true
+		req.RegenerateKeys = &updateRegenKeys
+	}
+
 	if updateType != "" {
-		if updateType != "Opaque" && updateType != "AutoGenerated" {
-			return fmt.Errorf("invalid secret type: %s. Must be 'Opaque' or 'AutoGenerated'", updateType)
+		if updateType != "Opaque" && updateType != "AutoGenerated" && updateType != "Certificate" {
+			return fmt.Errorf("invalid secret type: %s. Must be 'Opaque', 'AutoGenerated' or 'Certificate'", updateType)
 		}
 		reqType := api.UpdateSecretRequestType(updateType)
 		req.Type = &reqType
@@ -113,6 +150,15 @@ func runUpdateSecret(cmd *cobra.Command, args []string) error {
 				return err
 			}
 			req.Data = &dataMap
+			if updateEncrypt {
+				sealed, err := sealData(updateNamespace, updateSecretName, dataMap)
+				if err != nil {
+					return err
+				}
+				emptyMap := make(map[string]string)
+				req.Data = &emptyMap
+				req.EncryptedData = &sealed
+			}
 		} else { // AutoGenerated
 			localConfig, err := readGenerationConfigFromFile(updateDataFile)
 			if err != nil {
@@ -126,6 +172,10 @@ func runUpdateSecret(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if updateEncrypt && req.EncryptedData == nil {
+		return fmt.Errorf("--encrypt requires --data-file with Opaque data")
+	}
+
 	if updateLength != 0 || updateEncoding != "" || len(updateDataKeyVals) > 0 {
 		if updateType != "AutoGenerated" && updateType != "" {
 			return fmt.Errorf("generation flags (--length, --encoding, --keys) only valid for AutoGenerated type")
@@ -139,6 +189,73 @@ func runUpdateSecret(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(updateKeyFormats) > 0 || hasPasswordPolicyFlags() {
+		if req.GenerationConfig == nil {
+			return fmt.Errorf("--key-format and the password policy flags require the generation flags (--length, --encoding, --keys)")
+		}
+		keys, err := keyGenerationsFromFlags(updateKeyFormats)
+		if err != nil {
+			return err
+		}
+		req.GenerationConfig.Keys = &keys
+	}
+
+	if updateRotateInterval != "" || updateRotateSchedule != "" || updateNoRotation {
+		if updateType != "AutoGenerated" && updateType != "" {
+			return fmt.Errorf("rotation flags (--rotate-interval, --rotate-schedule, --no-rotation) only valid for AutoGenerated type")
+		}
+		if updateNoRotation && (updateRotateInterval != "" || updateRotateSchedule != "") {
+			return fmt.Errorf("--no-rotation cannot be combined with --rotate-interval or --rotate-schedule")
+		}
+		fieldsSet = true
+		rotation := api.RotationConfig{}
+		if updateRotateInterval != "" {
+			rotation.Interval = &updateRotateInterval
+		}
+		if updateRotateSchedule != "" {
+			rotation.Schedule = &updateRotateSchedule
+		}
+		req.Rotation = &rotation
+	}
+
+	if hasCertificateFlags() {
+		if updateType != "Certificate" && updateType != "" {
+			return fmt.Errorf("certificate flags (--common-name, --dns-name, --issuer, ...) only valid for Certificate type")
+		}
+		fieldsSet = true
+		req.Certificate = certificateFromFlags()
+	}
+
+	if len(updateTemplates) > 0 || updateNoTemplates {
+		if updateNoTemplates && len(updateTemplates) > 0 {
+			return fmt.Errorf("--no-templates cannot be combined with --template")
+		}
+		fieldsSet = true
+		templatesMap := make(map[string]string)
+		req.Templates = &templatesMap
+		if err := parseKeyValues(updateTemplates, templatesMap); err != nil {
+			return fmt.Errorf("invalid template format: %w", err)
+		}
+	}
+
+	if updateDeletionPolicy != "" {
+		fieldsSet = true
+		policy := api.DeletionPolicy(updateDeletionPolicy)
+		req.DeletionPolicy = &policy
+	}
+
+	if hasTargetFlags() || updateNoTargets {
+		if updateNoTargets && hasTargetFlags() {
+			return fmt.Errorf("--no-targets cannot be combined with --target-namespace or --target-selector")
+		}
+		fieldsSet = true
+		targets, err := targetsFromFlags()
+		if err != nil {
+			return err
+		}
+		req.Targets = targets
+	}
+
 	if updateType != "" && !fieldsSet {
 		if updateType == "AutoGenerated" {
 			return fmt.Errorf("AutoGenerated: nothing to update. Provide --length, --keys, --data-file, or --regenerate")
@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	userRole             string
+	userNamespaces       []string
+	userPermissions      []string
+	userKubernetesUser   string
+	userKubernetesGroups []string
+	userPasswordStdin    bool
+	userResetPassword    bool
+)
+
+// userCmd groups the "ksec user" commands
+var userCmd = &cobra.Command{
+	Use:     "user",
+	Aliases: []string{"users"},
+	Short:   "Manage users that log in with a password",
+	Long: `Creates, changes and removes the users that log in with 'ksec login'. Users
+of the server config file are listed but can only be changed there. Managing
+users needs the admin role by default; 'ksec user passwd' works for everyone.`,
+}
+
+var userCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create a user",
+	Long: `Creates a user. The password is prompted for, or read from the first line of
+stdin with --password-stdin. This corresponds to the POST /users API endpoint.`,
+	Example: `  ksec user create jane --role developer -n staging,team-a
+  echo "$PASSWORD" | ksec user create ci-bot --role operator -n staging --password-stdin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCreateUser,
+}
+
+var userListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List users",
+	Long:    `Lists the users of the config file and the users created through the API. This corresponds to the GET /users API endpoint.`,
+	Example: `  ksec user list`,
+	Args:    cobra.NoArgs,
+	RunE:    runListUsers,
+}
+
+var userGetCmd = &cobra.Command{
+	Use:     "get NAME",
+	Short:   "Show a user",
+	Long:    `Shows a user. This corresponds to the GET /users/{username} API endpoint.`,
+	Example: `  ksec user get jane`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runGetUser,
+}
+
+var userUpdateCmd = &cobra.Command{
+	Use:   "update NAME",
+	Short: "Change a user",
+	Long: `Changes the role, namespaces, permissions or Kubernetes identity of a user, or
+sets a new password with --reset-password. Flags that are not passed are kept.
+This corresponds to the PUT /users/{username} API endpoint.`,
+	Example: `  ksec user update jane --role operator
+  ksec user update jane --reset-password`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUpdateUser,
+}
+
+var userDeleteCmd = &cobra.Command{
+	Use:     "delete NAME",
+	Aliases: []string{"del", "rm"},
+	Short:   "Delete a user",
+	Long:    `Deletes a user; its refresh tokens are rejected from then on. This corresponds to the DELETE /users/{username} API endpoint.`,
+	Example: `  ksec user delete jane`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runDeleteUser,
+}
+
+var userPasswdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Change your own password",
+	Long: `Changes the password of the logged in user. Other sessions have to log in
+again once their access token expires. This corresponds to the PUT /user/password
+API endpoint.`,
+	Example: `  ksec user passwd`,
+	Args:    cobra.NoArgs,
+	RunE:    runChangePassword,
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userCreateCmd, userListCmd, userGetCmd, userUpdateCmd, userDeleteCmd, userPasswdCmd)
+
+	for _, c := range []*cobra.Command{userCreateCmd, userUpdateCmd} {
+		c.Flags().StringVar(&userRole, "role", "", "Role of the user")
+		c.Flags().StringSliceVarP(&userNamespaces, "namespace", "n", nil, "Allowed namespaces of the user")
+		c.Flags().StringSliceVar(&userPermissions, "permissions", nil, "Permissions on top of the role, e.g. secrets:reveal")
+		c.Flags().StringVar(&userKubernetesUser, "kubernetes-user", "", "User SubjectAccessReviews are made for (default: the username)")
+		c.Flags().StringSliceVar(&userKubernetesGroups, "kubernetes-groups", nil, "Groups SubjectAccessReviews are made for")
+		c.Flags().BoolVar(&userPasswordStdin, "password-stdin", false, "Read the password from stdin instead of prompting")
+	}
+	userUpdateCmd.Flags().BoolVar(&userResetPassword, "reset-password", false, "Set a new password")
+	userCreateCmd.MarkFlagRequired("role")
+	userCreateCmd.MarkFlagRequired("namespace")
+}
+
+func runCreateUser(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	password, err := readNewPassword(fmt.Sprintf("Password for %s: ", args[0]), userPasswordStdin)
+	if err != nil {
+		return err
+	}
+	createReq := api.CreateUserRequest{
+		Username:          args[0],
+		Password:          password,
+		Role:              userRole,
+		AllowedNamespaces: userNamespaces,
+	}
+	if len(userPermissions) > 0 {
+		createReq.Permissions = &userPermissions
+	}
+	if userKubernetesUser != "" {
+		createReq.KubernetesUser = &userKubernetesUser
+	}
+	if len(userKubernetesGroups) > 0 {
+		createReq.KubernetesGroups = &userKubernetesGroups
+	}
+
+	user, err := sendUserRequest("POST", "/users", createReq, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ User '%s' created with role %s.\n", user.Username, user.Role)
+	return nil
+}
+
+func runListUsers(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	httpReq, err := http.NewRequest("GET", fmt.Sprintf("%s/users", serverURL), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	responseBytes, err := expectAPIResponse(httpReq, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	var list api.ListUsersResponse
+	if err := json.Unmarshal(responseBytes, &list); err != nil {
+		return fmt.Errorf("failed to decode successful response: %w", err)
+	}
+
+	if len(list.Items) == 0 {
+		fmt.Println("No users found.")
+		return nil
+	}
+	fmt.Printf("\n%-20s %-12s %-30s %-20s %s\n", "USERNAME", "ROLE", "NAMESPACES", "PERMISSIONS", "SOURCE")
+	fmt.Println("--------------------------------------------------------------------------------------------")
+	for _, u := range list.Items {
+		fmt.Printf("%-20s %-12s %-30s %-20s %s\n", u.Username, u.Role, strings.Join(u.AllowedNamespaces, ","), joinOptional(u.Permissions), userSource(u))
+	}
+	return nil
+}
+
+func runGetUser(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	user, err := sendUserRequest("GET", "/users/"+url.PathEscape(args[0]), nil, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Username:    %s\n", user.Username)
+	fmt.Printf("ID:          %s\n", user.Id)
+	fmt.Printf("Role:        %s\n", user.Role)
+	fmt.Printf("Namespaces:  %s\n", strings.Join(user.AllowedNamespaces, ", "))
+	fmt.Printf("Permissions: %s\n", joinOptional(user.Permissions))
+	if user.KubernetesUser != nil || user.KubernetesGroups != nil {
+		kubernetesUser := user.Username
+		if user.KubernetesUser != nil {
+			kubernetesUser = *user.KubernetesUser
+		}
+		fmt.Printf("Kubernetes:  %s (groups: %s)\n", kubernetesUser, joinOptional(user.KubernetesGroups))
+	}
+	fmt.Printf("Source:      %s\n", userSource(*user))
+	return nil
+}
+
+func runUpdateUser(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	var updateReq api.UpdateUserRequest
+	flags := cmd.Flags()
+	if flags.Changed("role") {
+		updateReq.Role = &userRole
+	}
+	if flags.Changed("namespace") {
+		updateReq.AllowedNamespaces = &userNamespaces
+	}
+	if flags.Changed("permissions") {
+		updateReq.Permissions = &userPermissions
+	}
+	if flags.Changed("kubernetes-user") {
+		updateReq.KubernetesUser = &userKubernetesUser
+	}
+	if flags.Changed("kubernetes-groups") {
+		updateReq.KubernetesGroups = &userKubernetesGroups
+	}
+	if userResetPassword || userPasswordStdin {
+		password, err := readNewPassword(fmt.Sprintf("New password for %s: ", args[0]), userPasswordStdin)
+		if err != nil {
+			return err
+		}
+		updateReq.Password = &password
+	}
+	if updateReq == (api.UpdateUserRequest{}) {
+		return fmt.Errorf("nothing to update: pass --role, --namespace, --permissions, --kubernetes-user, --kubernetes-groups or --reset-password")
+	}
+
+	user, err := sendUserRequest("PUT", "/users/"+url.PathEscape(args[0]), updateReq, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ User '%s' updated.\n", user.Username)
+	return nil
+}
+
+func runDeleteUser(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	httpReq, err := http.NewRequest("DELETE", fmt.Sprintf("%s/users/%s", serverURL, url.PathEscape(args[0])), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if _, err := expectAPIResponse(httpReq, http.StatusOK); err != nil {
+		return err
+	}
+	fmt.Printf("✅ User '%s' deleted.\n", args[0])
+	return nil
+}
+
+func runChangePassword(cmd *cobra.Command, args []string) error {
+	if token == "" {
+		return fmt.Errorf("authentication token is missing. Please run 'ksec login' first")
+	}
+
+	fmt.Print("Current password: ")
+	current, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return fmt.Errorf("error reading password: %w", err)
+	}
+	newPassword, err := readNewPassword("New password: ", false)
+	if err != nil {
+		return err
+	}
+
+	reqBody, err := json.Marshal(api.ChangePasswordRequest{CurrentPassword: string(current), NewPassword: newPassword})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	httpReq, err := http.NewRequest("PUT", fmt.Sprintf("%s/user/password", serverURL), bytes.NewBuffer(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if _, err := expectAPIResponse(httpReq, http.StatusOK); err != nil {
+		return err
+	}
+	fmt.Println("✅ Password changed. Other sessions have to log in again.")
+	return nil
+}
+
+// readNewPassword reads the first line of stdin if fromStdin is set, and
+// otherwise prompts for the password twice.
+func readNewPassword(prompt string, fromStdin bool) (string, error) {
+	if fromStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			return "", fmt.Errorf("no password on stdin: %v", err)
+		}
+		return password, nil
+	}
+
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("error reading password: %w", err)
+	}
+	fmt.Print("Repeat the password: ")
+	repeated, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("error reading password: %w", err)
+	}
+	if string(password) != string(repeated) {
+		return "", fmt.Errorf("passwords do not match")
+	}
+	if len(password) == 0 {
+		return "", fmt.Errorf("password cannot be empty")
+	}
+	return string(password), nil
+}
+
+// sendUserRequest sends body, if any, to path and decodes the user returned.
+func sendUserRequest(method, path string, body any, wantStatus int) (*api.UserResponse, error) {
+	var reader io.Reader
+	if body != nil {
+		reqBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewBuffer(reqBody)
+	}
+
+	httpReq, err := http.NewRequest(method, serverURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	responseBytes, err := expectAPIResponse(httpReq, wantStatus)
+	if err != nil {
+		return nil, err
+	}
+	var user api.UserResponse
+	if err := json.Unmarshal(responseBytes, &user); err != nil {
+		return nil, fmt.Errorf("failed to decode successful response: %w", err)
+	}
+	return &user, nil
+}
+
+func joinOptional(values *[]string) string {
+	if values == nil || len(*values) == 0 {
+		return "-"
+	}
+	return strings.Join(*values, ",")
+}
+
+func userSource(u api.UserResponse) string {
+	if u.FromConfig {
+		return "config"
+	}
+	return "api"
+}
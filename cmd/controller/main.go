@@ -20,6 +20,8 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -37,6 +39,7 @@ import (
 
 	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
 	"github.com/mogilyoy/k8s-secret-manager/internal/controller"
+	webhookv1alpha1 "github.com/mogilyoy/k8s-secret-manager/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -61,6 +64,13 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var clusterClaimsNamespace string
+	var sourceRoot string
+	var httpSourceURLs string
+	var vaultAddresses string
+	var sealingKeysNamespace string
+	var sealingKeyRotation time.Duration
+	var retentionNamespace string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -79,6 +89,23 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&clusterClaimsNamespace, "cluster-claims-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace holding the SecretClaims and source Secrets of ClusterSecretClaims. Defaults to the controller namespace.")
+	flag.StringVar(&sourceRoot, "source-root", "",
+		"The directory file sources of External claims are read from, in the subdirectory named after the namespace of the claim. "+
+			"File sources are disabled when empty.")
+	flag.StringVar(&httpSourceURLs, "http-source-urls", "",
+		"Comma-separated base URLs http sources and pushes may use. HTTP sources are disabled when empty.")
+	flag.StringVar(&vaultAddresses, "vault-addresses", "",
+		"Comma-separated Vault server addresses vault sources and pushes may use. Vault sources are disabled when empty.")
+	flag.StringVar(&sealingKeysNamespace, "sealing-keys-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace holding the key pairs encryptedData is sealed to and the published public key. "+
+			"Defaults to the controller namespace; encryptedData is rejected when empty.")
+	flag.DurationVar(&sealingKeyRotation, "sealing-key-rotation", controller.DefaultSealingKeyRotation,
+		"How often a new sealing key pair is generated. Older key pairs are kept to open existing values. Use 0 to disable rotation.")
+	flag.StringVar(&retentionNamespace, "retention-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace holding the records of Secrets retained by deleted SecretClaims. Defaults to the controller namespace; "+
+			"retained Secrets are not adopted again when empty.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -179,12 +206,48 @@ func main() {
 	}
 
 	if err := (&controller.SecretClaimReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		SourceRoot:             sourceRoot,
+		HTTPSourceURLs:         splitList(httpSourceURLs),
+		VaultAddresses:         splitList(vaultAddresses),
+		SealingKeysNamespace:   sealingKeysNamespace,
+		ClusterClaimsNamespace: clusterClaimsNamespace,
+		RetentionNamespace:     retentionNamespace,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SecretClaim")
 		os.Exit(1)
 	}
+	if err := (&controller.ClusterSecretClaimReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Namespace: clusterClaimsNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterSecretClaim")
+		os.Exit(1)
+	}
+	if sealingKeysNamespace != "" {
+		if err := mgr.Add(&controller.SealingKeyManager{
+			Client:           mgr.GetClient(),
+			Reader:           mgr.GetAPIReader(),
+			Namespace:        sealingKeysNamespace,
+			RotationInterval: sealingKeyRotation,
+		}); err != nil {
+			setupLog.Error(err, "unable to add sealing key manager")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupSecretClaimWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecretClaim")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupClusterSecretClaimWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterSecretClaim")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -202,3 +265,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
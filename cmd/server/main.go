@@ -2,19 +2,25 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"reflect"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
 	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
 	"github.com/mogilyoy/k8s-secret-manager/internal/handlers"
 	"github.com/mogilyoy/k8s-secret-manager/internal/k8s"
 	authMiddleware "github.com/mogilyoy/k8s-secret-manager/internal/middleware"
 	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func main() {
@@ -24,10 +30,6 @@ func main() {
 		slog.Error("❌ FATAL: Failed to load config: %v", slog.Any("error", err))
 		os.Exit(1)
 	}
-	if config.JWT.Secret == "" {
-		slog.Error("❌ FATAL: JWT secret is empty. Set via config.yaml or JWT_SECRET environment variable.")
-		os.Exit(1)
-	}
 	slog.Info("✅ Configuration loaded successfully.")
 
 	tp := observability.InitTracer()
@@ -49,10 +51,51 @@ func main() {
 	if err != nil {
 		slog.Error("❌ FATAL: Failed to initialize Kubernetes manager: %v", slog.Any("error", err))
 	}
+	k8sManager.HashOpaqueData = config.Secrets.HashOpaqueData
 	slog.Info("✅ Kubernetes Client initialized successfully.")
 
 	tracer := tp.Tracer(cfg.AppConfig.Service.Name)
-	secretHandler := handlers.NewSecretHandler(k8sManager, *config, logger, tracer)
+	// The authorizer and signing keys are set for each config by apply.
+	secretHandler := handlers.NewSecretHandler(k8sManager, *config, nil, logger, tracer)
+
+	switch config.JWT.Revocation.Store {
+	case "", cfg.RevocationStoreMemory:
+	case cfg.RevocationStoreKubernetes:
+		if config.JWT.Revocation.Namespace == "" {
+			slog.Error("❌ FATAL: jwt.revocation.namespace is required for the kubernetes revocation store")
+			os.Exit(1)
+		}
+		secretHandler.Revocations = k8s.NewConfigMapRevocationStore(k8sManager.Client, config.JWT.Revocation.Namespace, config.JWT.Revocation.ConfigMap)
+	default:
+		slog.Error("❌ FATAL: Unknown revocation store", slog.String("store", config.JWT.Revocation.Store))
+		os.Exit(1)
+	}
+
+	switch config.APIKeys.Store {
+	case "", cfg.APIKeyStoreMemory:
+	case cfg.APIKeyStoreKubernetes:
+		if config.APIKeys.Namespace == "" {
+			slog.Error("❌ FATAL: api_keys.namespace is required for the kubernetes api key store")
+			os.Exit(1)
+		}
+		secretHandler.APIKeys = k8s.NewSecretAPIKeyStore(k8sManager.Client, config.APIKeys.Namespace, config.APIKeys.Secret)
+	default:
+		slog.Error("❌ FATAL: Unknown api key store", slog.String("store", config.APIKeys.Store))
+		os.Exit(1)
+	}
+
+	switch config.UserStore.Store {
+	case "", cfg.UserStoreMemory:
+	case cfg.UserStoreKubernetes:
+		if config.UserStore.Namespace == "" {
+			slog.Error("❌ FATAL: user_store.namespace is required for the kubernetes user store")
+			os.Exit(1)
+		}
+		secretHandler.Users = auth.NewConfigUserStore(config.Users, k8s.NewSecretUserStore(k8sManager.Client, config.UserStore.Namespace, config.UserStore.Secret))
+	default:
+		slog.Error("❌ FATAL: Unknown user store", slog.String("store", config.UserStore.Store))
+		os.Exit(1)
+	}
 
 	router := chi.NewRouter()
 	router.Use(middleware.Recoverer)
@@ -61,20 +104,21 @@ func main() {
 	router.Use(observability.NewSlogMiddleware(logger))
 	router.Use(observability.SlogRequestLogger())
 
-	strictServer := api.NewStrictHandler(secretHandler, nil)
+	router.Handle("/metrics", promhttp.Handler())
 
-	baseAPIMux := chi.NewMux()
-	api.HandlerFromMux(strictServer, baseAPIMux)
-
-	router.Post("/user/auth", func(w http.ResponseWriter, r *http.Request) {
-		baseAPIMux.ServeHTTP(w, r)
-	})
+	reloader := &configReloader{base: secretHandler, client: k8sManager.Client, logger: logger}
+	if err := reloader.apply(config); err != nil {
+		slog.Error("❌ FATAL: Invalid configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	router.Mount("/", reloader.handler)
 
-	router.Group(func(r chi.Router) {
-		jwtMiddlewareFunc := authMiddleware.JWTAuthMiddleware(config.JWT.Secret)
-		r.Use(jwtMiddlewareFunc)
-		r.Mount("/", baseAPIMux)
-	})
+	watcher := cfg.NewWatcher(os.Getenv("CONFIG_PATH"), config, reloader.apply, logger)
+	go func() {
+		if err := watcher.Run(context.Background()); err != nil {
+			slog.Error("❌ Config file changes will not be applied", slog.Any("error", err))
+		}
+	}()
 
 	// 7. Запуск сервера
 	srv := &http.Server{
@@ -90,3 +134,80 @@ func main() {
 		slog.Error("❌ Could not listen on %s: %v", slog.Any("port", cfg.AppConfig.Service.Port), slog.Any("error", err))
 	}
 }
+
+// configReloader builds the routes that depend on the config file and swaps
+// them in when the file changes. Requests in flight finish with the routes
+// they started with.
+type configReloader struct {
+	base    *handlers.SecretHandler
+	client  client.Client
+	logger  *slog.Logger
+	handler *handlers.ReloadableHandler
+
+	config *cfg.Config
+	oidc   *auth.OIDCVerifier
+}
+
+// apply builds the authorizer, signing keys, token verifiers and API routes
+// for config and swaps them in. Nothing is changed when one of them fails.
+// Calls must not overlap.
+func (r *configReloader) apply(config *cfg.Config) error {
+	authorizer, err := policy.FromConfig(config.Authorization, config.Roles, r.client, r.logger)
+	if err != nil {
+		return fmt.Errorf("invalid authorization configuration: %w", err)
+	}
+	keys, err := auth.LoadKeySet(config.JWT)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT signing keys, set jwt.keys or the JWT_SECRET environment variable: %w", err)
+	}
+
+	var verifiers []auth.TokenVerifier
+	oidcVerifier := r.oidc
+	if config.OIDC.Issuer == "" {
+		oidcVerifier = nil
+	} else if r.config == nil || !reflect.DeepEqual(r.config.OIDC, config.OIDC) {
+		oidcVerifier, err = auth.NewOIDCVerifier(context.Background(), config.OIDC, &http.Client{Timeout: 10 * time.Second})
+		if err != nil {
+			return fmt.Errorf("failed to initialize OIDC verifier: %w", err)
+		}
+		slog.Info("✅ OIDC login enabled.", slog.String("issuer", config.OIDC.Issuer))
+	}
+	if oidcVerifier != nil {
+		verifiers = append(verifiers, oidcVerifier)
+	}
+	if config.ServiceAccounts.Enabled {
+		verifiers = append(verifiers, k8s.NewTokenReviewVerifier(r.client, config.ServiceAccounts))
+	}
+
+	secretHandler := r.base.WithConfig(*config, authorizer, keys)
+	strictServer := api.NewStrictHandler(secretHandler, nil)
+
+	baseAPIMux := chi.NewMux()
+	api.HandlerFromMux(strictServer, baseAPIMux)
+
+	router := chi.NewRouter()
+	router.Post("/user/auth", baseAPIMux.ServeHTTP)
+	router.Get("/user/oidc", baseAPIMux.ServeHTTP)
+	router.Post("/user/refresh", baseAPIMux.ServeHTTP)
+	router.Get("/.well-known/jwks.json", baseAPIMux.ServeHTTP)
+	router.Group(func(r chi.Router) {
+		jwtMiddlewareFunc := authMiddleware.JWTAuthMiddleware(secretHandler.Keys, secretHandler.Revocations, secretHandler.APIKeys, verifiers...)
+		r.Use(jwtMiddlewareFunc)
+		r.Mount("/", baseAPIMux)
+	})
+
+	if r.handler == nil {
+		r.handler = handlers.NewReloadableHandler(router)
+		if config.ServiceAccounts.Enabled {
+			slog.Info("✅ ServiceAccount token authentication enabled.")
+		}
+	} else {
+		if sections := r.config.RestartRequired(config); len(sections) > 0 {
+			slog.Warn("⚠️ Config changes that need a restart were not applied", slog.Any("sections", sections))
+		}
+		r.handler.Swap(router)
+	}
+	r.config = config
+	r.oidc = oidcVerifier
+	return nil
+}
@@ -19,12 +19,35 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for CertificateConfigKeyAlgorithm.
+const (
+	ECDSA CertificateConfigKeyAlgorithm = "ECDSA"
+	RSA   CertificateConfigKeyAlgorithm = "RSA"
+)
+
+// Defines values for CreateClusterSecretRequestType.
+const (
+	CreateClusterSecretRequestTypeAutoGenerated CreateClusterSecretRequestType = "AutoGenerated"
+	CreateClusterSecretRequestTypeCertificate   CreateClusterSecretRequestType = "Certificate"
+	CreateClusterSecretRequestTypeExternal      CreateClusterSecretRequestType = "External"
+	CreateClusterSecretRequestTypeOpaque        CreateClusterSecretRequestType = "Opaque"
+)
+
 // Defines values for CreateSecretRequestType.
 const (
 	CreateSecretRequestTypeAutoGenerated CreateSecretRequestType = "AutoGenerated"
+	CreateSecretRequestTypeCertificate   CreateSecretRequestType = "Certificate"
+	CreateSecretRequestTypeExternal      CreateSecretRequestType = "External"
 	CreateSecretRequestTypeOpaque        CreateSecretRequestType = "Opaque"
 )
 
+// Defines values for DeletionPolicy.
+const (
+	Delete DeletionPolicy = "Delete"
+	Orphan DeletionPolicy = "Orphan"
+	Retain DeletionPolicy = "Retain"
+)
+
 // Defines values for GenerationConfigEncoding.
 const (
 	Alphanumeric GenerationConfigEncoding = "alphanumeric"
@@ -32,6 +55,38 @@ const (
 	Symbols      GenerationConfigEncoding = "symbols"
 )
 
+// Defines values for KeyGenerationFormat.
+const (
+	JwkEc       KeyGenerationFormat = "jwk-ec"
+	JwkEd25519  KeyGenerationFormat = "jwk-ed25519"
+	JwkRsa      KeyGenerationFormat = "jwk-rsa"
+	Password    KeyGenerationFormat = "password"
+	RandomBytes KeyGenerationFormat = "random-bytes"
+	SshEd25519  KeyGenerationFormat = "ssh-ed25519"
+	SshRsa      KeyGenerationFormat = "ssh-rsa"
+)
+
+// Defines values for PushConfigConflictPolicy.
+const (
+	Fail      PushConfigConflictPolicy = "Fail"
+	Overwrite PushConfigConflictPolicy = "Overwrite"
+)
+
+// Defines values for PushConfigProvider.
+const (
+	PushConfigProviderHttp  PushConfigProvider = "http"
+	PushConfigProviderVault PushConfigProvider = "vault"
+)
+
+// Defines values for SecretType.
+const (
+	SecretTypeKubernetesIoBasicAuth        SecretType = "kubernetes.io/basic-auth"
+	SecretTypeKubernetesIoDockerconfigjson SecretType = "kubernetes.io/dockerconfigjson"
+	SecretTypeKubernetesIoSshAuth          SecretType = "kubernetes.io/ssh-auth"
+	SecretTypeKubernetesIoTls              SecretType = "kubernetes.io/tls"
+	SecretTypeOpaque                       SecretType = "Opaque"
+)
+
 // Defines values for SecretStatusCurrentStatus.
 const (
 	SecretStatusCurrentStatusError    SecretStatusCurrentStatus = "Error"
@@ -48,12 +103,35 @@ const (
 	SimpleSecretStatusCurrentStatusReady    SimpleSecretStatusCurrentStatus = "Ready"
 )
 
+// Defines values for SourceConfigProvider.
+const (
+	SourceConfigProviderFile  SourceConfigProvider = "file"
+	SourceConfigProviderHttp  SourceConfigProvider = "http"
+	SourceConfigProviderVault SourceConfigProvider = "vault"
+)
+
 // Defines values for UpdateSecretRequestType.
 const (
 	UpdateSecretRequestTypeAutoGenerated UpdateSecretRequestType = "AutoGenerated"
+	UpdateSecretRequestTypeCertificate   UpdateSecretRequestType = "Certificate"
+	UpdateSecretRequestTypeExternal      UpdateSecretRequestType = "External"
 	UpdateSecretRequestTypeOpaque        UpdateSecretRequestType = "Opaque"
 )
 
+// APIKeyResponse API key without the key itself
+type APIKeyResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CreatedBy Username of the caller that created the key
+	CreatedBy  string     `json:"createdBy"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Namespaces []string   `json:"namespaces"`
+	Role       string     `json:"role"`
+	Verbs      *[]string  `json:"verbs,omitempty"`
+}
+
 // AuthUserRequest Login password auth
 type AuthUserRequest struct {
 	Password string `json:"password"`
@@ -65,18 +143,176 @@ type AuthUserResponse struct {
 	// ExpiresIn Timeframe in seconds
 	ExpiresIn *int64 `json:"expiresIn,omitempty"`
 
+	// RefreshExpiresIn Timeframe of the refresh token in seconds
+	RefreshExpiresIn *int64 `json:"refreshExpiresIn,omitempty"`
+
+	// RefreshToken Token for /user/refresh
+	RefreshToken *string `json:"refreshToken,omitempty"`
+
 	// Token JWT-token
 	Token string `json:"token"`
 }
 
+// CertificateConfig TLS certificate issued by the operator for Certificate claims
+type CertificateConfig struct {
+	// CommonName Subject common name of the certificate
+	CommonName *string `json:"commonName,omitempty"`
+
+	// DnsNames DNS subject alternative names
+	DnsNames *[]string `json:"dnsNames,omitempty"`
+
+	// Duration Go duration the certificate is valid for, 2160h by default
+	Duration *string `json:"duration,omitempty"`
+
+	// IpAddresses IP subject alternative names
+	IpAddresses *[]string `json:"ipAddresses,omitempty"`
+
+	// IsCA Issue a CA certificate that other Certificate claims can reference as issuer
+	IsCA *bool `json:"isCA,omitempty"`
+
+	// IssuerRef Name of a CA Certificate claim in the same namespace that signs this certificate. Self-signed if empty
+	IssuerRef *string `json:"issuerRef,omitempty"`
+
+	// KeyAlgorithm Private key algorithm
+	KeyAlgorithm *CertificateConfigKeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize RSA modulus size (2048, 3072, 4096) or ECDSA curve size (256, 384)
+	KeySize *int `json:"keySize,omitempty"`
+
+	// RenewBefore Go duration before notAfter when the certificate is renewed. By default after 2/3 of its lifetime
+	RenewBefore *string `json:"renewBefore,omitempty"`
+}
+
+// CertificateConfigKeyAlgorithm Private key algorithm
+type CertificateConfigKeyAlgorithm string
+
+// ChangePasswordRequest Password change of the calling user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+
+	// NewPassword At least 8 characters
+	NewPassword string `json:"newPassword"`
+}
+
+// Condition A single status condition of the SecretClaim
+type Condition struct {
+	// LastTransitionTime The timestamp of the last status change
+	LastTransitionTime *time.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message Human-readable details of the last transition
+	Message *string `json:"message,omitempty"`
+
+	// Reason Machine-readable reason of the last transition
+	Reason *string `json:"reason,omitempty"`
+
+	// Status One of True, False, Unknown
+	Status string `json:"status"`
+
+	// Type Condition type, e.g. Ready
+	Type string `json:"type"`
+}
+
+// CreateAPIKeyRequest New API key
+type CreateAPIKeyRequest struct {
+	// ExpiresIn Lifetime in seconds; the key does not expire if unset
+	ExpiresIn *int64 `json:"expiresIn,omitempty"`
+
+	// Name Name of the key, shown as apikey:<name> in logs
+	Name string `json:"name"`
+
+	// Namespaces Allowed namespaces of the key, each of them allowed for the caller too
+	Namespaces []string `json:"namespaces"`
+
+	// Role Role the key acts with
+	Role string `json:"role"`
+
+	// Verbs Verbs the key is limited to on top of its role; empty allows every verb of the role
+	Verbs *[]string `json:"verbs,omitempty"`
+}
+
+// CreateAPIKeyResponse Created API key
+type CreateAPIKeyResponse struct {
+	// ApiKey API key without the key itself
+	ApiKey APIKeyResponse `json:"apiKey"`
+
+	// Key The API key; it cannot be retrieved again
+	Key string `json:"key"`
+}
+
+// CreateClusterSecretRequest Create new cluster-wide secret replicated to the target namespaces
+type CreateClusterSecretRequest struct {
+	// Annotations Key-value pairs that are attached to the ClusterSecretClaim object
+	Annotations *map[string]string `json:"annotations,omitempty"`
+
+	// Certificate TLS certificate issued by the operator for Certificate claims
+	Certificate *CertificateConfig `json:"certificate,omitempty"`
+
+	// Data Key-value data if Opaque else empty
+	Data *map[string]string `json:"data,omitempty"`
+
+	// DeletionPolicy What happens to the Kubernetes Secret when the SecretClaim is deleted (Delete by default)
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DockerConfig Image registry credentials rendered into .dockerconfigjson. The password is the value of the 'password' data key
+	DockerConfig *DockerConfig `json:"dockerConfig,omitempty"`
+
+	// EncryptedData Values sealed to the key from GET /sealing-key for this cluster secret name if Opaque else empty. Keys must not be in data
+	EncryptedData *map[string]string `json:"encryptedData,omitempty"`
+
+	// GenerationConfig Secret generation settings
+	GenerationConfig *GenerationConfig `json:"generationConfig,omitempty"`
+
+	// Labels Key-value pairs that are attached to the ClusterSecretClaim object
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// Name Secret name, unique for the cluster
+	Name string `json:"name"`
+
+	// Push External store the Kubernetes Secret data is written to whenever it changes
+	Push *PushConfig `json:"push,omitempty"`
+
+	// Rotation Scheduled regeneration settings, AutoGenerated only
+	Rotation *RotationConfig `json:"rotation,omitempty"`
+
+	// SecretType Type of the created Kubernetes Secret (Opaque by default)
+	SecretType *SecretType `json:"secretType,omitempty"`
+
+	// Source External store the values of External claims are read from
+	Source *SourceConfig `json:"source,omitempty"`
+
+	// Targets Namespaces the Kubernetes Secret is replicated to, in addition to the claim's namespace
+	Targets TargetsConfig `json:"targets"`
+
+	// Templates Extra Secret keys rendered with Go text/template from the data values (helpers base64, bcrypt, htpasswd)
+	Templates *map[string]string `json:"templates,omitempty"`
+
+	// Type Opaque if secret is set, AutoGenerated if the secret needs to be generated, Certificate for an issued TLS certificate, External for values read from an external store
+	Type CreateClusterSecretRequestType `json:"type"`
+}
+
+// CreateClusterSecretRequestType Opaque if secret is set, AutoGenerated if the secret needs to be generated, Certificate for an issued TLS certificate, External for values read from an external store
+type CreateClusterSecretRequestType string
+
 // CreateSecretRequest Create new k8s secret
 type CreateSecretRequest struct {
 	// Annotations Key-value pairs that are attached to the SecretClaim object
 	Annotations *map[string]string `json:"annotations,omitempty"`
 
+	// Certificate TLS certificate issued by the operator for Certificate claims
+	Certificate *CertificateConfig `json:"certificate,omitempty"`
+
 	// Data Key-value data if Opaque else empty
 	Data *map[string]string `json:"data,omitempty"`
 
+	// DeletionPolicy What happens to the Kubernetes Secret when the SecretClaim is deleted (Delete by default)
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DockerConfig Image registry credentials rendered into .dockerconfigjson
+	DockerConfig *DockerConfig `json:"dockerConfig,omitempty"`
+
+	// EncryptedData Values sealed to the key from GET /sealing-key for this secret name and namespace if Opaque else empty. Keys must not be in data
+	EncryptedData *map[string]string `json:"encryptedData,omitempty"`
+
 	// GenerationConfig Secret generation settings
 	GenerationConfig *GenerationConfig `json:"generationConfig,omitempty"`
 
@@ -89,13 +325,61 @@ type CreateSecretRequest struct {
 	// Namespace Namespace name, where the secret will be created
 	Namespace string `json:"namespace"`
 
-	// Type Opaque if secret is set, AutoGenerated if the secret needs to be generated
+	// Push External store the Kubernetes Secret data is written to whenever it changes
+	Push *PushConfig `json:"push,omitempty"`
+
+	// Rotation Scheduled regeneration settings, AutoGenerated only
+	Rotation *RotationConfig `json:"rotation,omitempty"`
+
+	// SecretType Type of the created Kubernetes Secret (Opaque by default)
+	SecretType *SecretType `json:"secretType,omitempty"`
+
+	// Source External store the values of External claims are read from
+	Source *SourceConfig `json:"source,omitempty"`
+
+	// Targets Namespaces the Kubernetes Secret is replicated to, in addition to the claim's namespace
+	Targets *TargetsConfig `json:"targets,omitempty"`
+
+	// Templates Extra Secret keys rendered with Go text/template from the data values (helpers base64, bcrypt, htpasswd)
+	Templates *map[string]string `json:"templates,omitempty"`
+
+	// Type Opaque if secret is set, AutoGenerated if the secret needs to be generated, Certificate for an issued TLS certificate, External for values read from an external store
 	Type CreateSecretRequestType `json:"type"`
 }
 
-// CreateSecretRequestType Opaque if secret is set, AutoGenerated if the secret needs to be generated
+// CreateSecretRequestType Opaque if secret is set, AutoGenerated if the secret needs to be generated, Certificate for an issued TLS certificate, External for values read from an external store
 type CreateSecretRequestType string
 
+// CreateUserRequest New user
+type CreateUserRequest struct {
+	// AllowedNamespaces Namespaces of the user, each of them allowed for the caller too
+	AllowedNamespaces []string  `json:"allowedNamespaces"`
+	KubernetesGroups  *[]string `json:"kubernetesGroups,omitempty"`
+
+	// KubernetesUser User SubjectAccessReviews are made for; defaults to ksm: and the username
+	KubernetesUser *string `json:"kubernetesUser,omitempty"`
+
+	// Password At least 8 characters
+	Password string `json:"password"`
+
+	// Permissions Permissions on top of the role, such as secrets:reveal; the caller must have them
+	Permissions *[]string `json:"permissions,omitempty"`
+	Role        string    `json:"role"`
+	Username    string    `json:"username"`
+}
+
+// DeletionPolicy What happens to the Kubernetes Secret when the SecretClaim is deleted (Delete by default)
+type DeletionPolicy string
+
+// DockerConfig Image registry credentials rendered into .dockerconfigjson
+type DockerConfig struct {
+	// Registry Registry host, e.g. registry.example.com
+	Registry string `json:"registry"`
+
+	// Username Registry user name
+	Username string `json:"username"`
+}
+
 // ErrorBadRequest defines model for ErrorBadRequest.
 type ErrorBadRequest struct {
 	ErrorCode    *string `json:"errorCode,omitempty"`
@@ -146,6 +430,9 @@ type GenerationConfig struct {
 	// Encoding password type
 	Encoding *GenerationConfigEncoding `json:"encoding,omitempty"`
 
+	// Keys Generation format per data key, keys not listed are passwords
+	Keys *map[string]KeyGeneration `json:"keys,omitempty"`
+
 	// Length Generated secret len
 	Length int32 `json:"length"`
 }
@@ -153,27 +440,211 @@ type GenerationConfig struct {
 // GenerationConfigEncoding password type
 type GenerationConfigEncoding string
 
+// JSONWebKey Public signing key
+type JSONWebKey struct {
+	Alg *string `json:"alg,omitempty"`
+
+	// Crv Curve of EC and OKP keys
+	Crv *string `json:"crv,omitempty"`
+
+	// E RSA exponent, base64url
+	E *string `json:"e,omitempty"`
+
+	// Kid Key ID, matches the kid header of the tokens it signed
+	Kid string `json:"kid"`
+
+	// Kty Key type
+	Kty string `json:"kty"`
+
+	// N RSA modulus, base64url
+	N   *string `json:"n,omitempty"`
+	Use *string `json:"use,omitempty"`
+
+	// X Public key of EC and OKP keys, base64url
+	X *string `json:"x,omitempty"`
+
+	// Y Y coordinate of EC keys, base64url
+	Y *string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet JSON Web Key Set (RFC 7517)
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeyGeneration Generator of a single data key
+type KeyGeneration struct {
+	// Bits RSA key size of ssh-rsa and jwk-rsa keys
+	Bits *int `json:"bits,omitempty"`
+
+	// Charset Characters the password is built from, replaces encoding
+	Charset *string `json:"charset,omitempty"`
+
+	// ExcludeAmbiguous Leave out easily confused characters (0O1lI|)
+	ExcludeAmbiguous *bool `json:"excludeAmbiguous,omitempty"`
+
+	// Format Key pairs also write the public key to <key>.pub
+	Format *KeyGenerationFormat `json:"format,omitempty"`
+
+	// Length Overrides the generation length for this key, without prefix
+	Length *int `json:"length,omitempty"`
+
+	// MinDigits Least number of digits in the password
+	MinDigits *int `json:"minDigits,omitempty"`
+
+	// MinSymbols Least number of symbols in the password
+	MinSymbols *int `json:"minSymbols,omitempty"`
+
+	// MinUppercase Least number of uppercase letters in the password
+	MinUppercase *int `json:"minUppercase,omitempty"`
+
+	// Prefix Prepended to the generated password
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// KeyGenerationFormat Key pairs also write the public key to <key>.pub
+type KeyGenerationFormat string
+
+// ListAPIKeysResponse All API keys
+type ListAPIKeysResponse struct {
+	Items []APIKeyResponse `json:"items"`
+}
+
 // ListSecretsResponse All SecretClaims in the Namespace
 type ListSecretsResponse struct {
 	Items []SecretSummary `json:"items"`
 }
 
+// ListUsersResponse All users
+type ListUsersResponse struct {
+	Items []UserResponse `json:"items"`
+}
+
+// LogoutUserRequest Tokens to revoke besides the access token of the call
+type LogoutUserRequest struct {
+	RefreshToken *string `json:"refreshToken,omitempty"`
+}
+
+// OIDCConfigResponse OIDC login settings
+type OIDCConfigResponse struct {
+	// ClientId Client ID ID tokens are issued to
+	ClientId string `json:"clientId"`
+
+	// Issuer Issuer URL; the device authorization and token endpoints are discovered from it
+	Issuer string `json:"issuer"`
+
+	// Scopes Scopes to request
+	Scopes *[]string `json:"scopes,omitempty"`
+}
+
 // OkResponse defines model for OkResponse.
 type OkResponse struct {
 	Ok *bool `json:"ok,omitempty"`
 }
 
+// PushConfig External store the Kubernetes Secret data is written to whenever it changes
+type PushConfig struct {
+	// Address Base URL of the Vault server, vault only
+	Address *string `json:"address,omitempty"`
+
+	// ConflictPolicy Fail leaves values changed outside of the claim alone and reports a conflict, Overwrite replaces them (Fail by default)
+	ConflictPolicy *PushConfigConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// CredentialsSecretRef Secret in the claim namespace whose 'token' key authenticates the requests
+	CredentialsSecretRef *string `json:"credentialsSecretRef,omitempty"`
+
+	// Keys Secret keys mapped to their names in the store. All Secret keys are pushed if empty
+	Keys *map[string]string `json:"keys,omitempty"`
+
+	// Mount KV v2 mount path, vault only
+	Mount *string `json:"mount,omitempty"`
+
+	// Path The URL for http, the secret path inside the KV v2 mount for vault
+	Path string `json:"path"`
+
+	// Provider http (PUT of a JSON object) or vault (KV v2 API)
+	Provider PushConfigProvider `json:"provider"`
+}
+
+// PushConfigConflictPolicy Fail leaves values changed outside of the claim alone and reports a conflict, Overwrite replaces them (Fail by default)
+type PushConfigConflictPolicy string
+
+// PushConfigProvider http (PUT of a JSON object) or vault (KV v2 API)
+type PushConfigProvider string
+
+// PushStatus Result of the last write of the Kubernetes Secret data to the push store
+type PushStatus struct {
+	// LastPushTime The timestamp when the data was last written to the store
+	LastPushTime *time.Time `json:"lastPushTime,omitempty"`
+
+	// Message Why the push failed
+	Message *string `json:"message,omitempty"`
+
+	// Result Pushed, Conflict or Failed
+	Result string `json:"result"`
+
+	// Version Version reported by the store for the last successful push
+	Version *string `json:"version,omitempty"`
+}
+
+// RefreshUserTokenRequest Refresh token to exchange
+type RefreshUserTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RevealSecretResponse Plaintext data of the Secret of a claim
+type RevealSecretResponse struct {
+	// Data Key-value data of the *actual* Kubernetes Secret
+	Data map[string]string `json:"data"`
+
+	// Name Secret name
+	Name string `json:"name"`
+
+	// Namespace Namespace name, empty for cluster secrets
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// RotationConfig Scheduled regeneration settings, AutoGenerated only
+type RotationConfig struct {
+	// Interval Go duration between rotations, e.g. 2160h for 90 days
+	Interval *string `json:"interval,omitempty"`
+
+	// Schedule Standard 5-field cron expression, takes precedence over interval
+	Schedule *string `json:"schedule,omitempty"`
+}
+
+// SealingKeyResponse Public key encryptedData values are sealed to
+type SealingKeyResponse struct {
+	// KeyId ID of the key, stored in every value sealed to it
+	KeyId string `json:"keyId"`
+
+	// PublicKey PEM encoded X25519 public key
+	PublicKey string `json:"publicKey"`
+}
+
 // SecretResponse Response of a k8s secret resources
 type SecretResponse struct {
 	// Annotations Key-value pairs that are attached to the SecretClaim object
 	Annotations *map[string]string `json:"annotations,omitempty"`
 
+	// Certificate TLS certificate issued by the operator for Certificate claims
+	Certificate *CertificateConfig `json:"certificate,omitempty"`
+
 	// CreationTimestamp The timestamp when the SecretClaim object was created
 	CreationTimestamp *time.Time `json:"creationTimestamp,omitempty"`
 
-	// Data Key-value data of the *actual* Kubernetes Secret. Only returned if Synced is true
+	// Data Keys of the *actual* Kubernetes Secret with masked values. Only returned if Synced is true. Plaintext values are returned by the reveal endpoint
 	Data *map[string]string `json:"data,omitempty"`
 
+	// DeletionPolicy What happens to the Kubernetes Secret when the SecretClaim is deleted (Delete by default)
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DockerConfig Image registry credentials rendered into .dockerconfigjson
+	DockerConfig *DockerConfig `json:"dockerConfig,omitempty"`
+
+	// EncryptedData Sealed values of the SecretClaim if Opaque
+	EncryptedData *map[string]string `json:"encryptedData,omitempty"`
+
 	// GenerationConfig Secret generation settings
 	GenerationConfig *GenerationConfig `json:"generationConfig,omitempty"`
 
@@ -186,33 +657,78 @@ type SecretResponse struct {
 	// Namespace Namespace name
 	Namespace *string `json:"namespace,omitempty"`
 
+	// Push External store the Kubernetes Secret data is written to whenever it changes
+	Push *PushConfig `json:"push,omitempty"`
+
 	// ResourceVersion Internal version of this object
-	ResourceVersion *string      `json:"resourceVersion,omitempty"`
-	Status          SecretStatus `json:"status"`
+	ResourceVersion *string `json:"resourceVersion,omitempty"`
+
+	// Rotation Scheduled regeneration settings, AutoGenerated only
+	Rotation *RotationConfig `json:"rotation,omitempty"`
+
+	// SecretType Type of the created Kubernetes Secret (Opaque by default)
+	SecretType *SecretType `json:"secretType,omitempty"`
 
-	// Type Opaque or AutoGenerated
+	// Source External store the values of External claims are read from
+	Source *SourceConfig `json:"source,omitempty"`
+	Status SecretStatus  `json:"status"`
+
+	// Targets Namespaces the Kubernetes Secret is replicated to, in addition to the claim's namespace
+	Targets *TargetsConfig `json:"targets,omitempty"`
+
+	// Templates Extra Secret keys rendered with Go text/template from the data values (helpers base64, bcrypt, htpasswd)
+	Templates *map[string]string `json:"templates,omitempty"`
+
+	// Type Opaque, AutoGenerated, Certificate or External
 	Type string `json:"type"`
 
 	// Uid Unique ID of the SecretClaim object
 	Uid *string `json:"uid,omitempty"`
 }
 
+// SecretType Type of the created Kubernetes Secret (Opaque by default)
+type SecretType string
+
 // SecretStatus defines model for SecretStatus.
 type SecretStatus struct {
+	// Conditions Kubernetes conditions reported by the operator (Ready, SecretCreated, GenerationValid, OwnershipConflict, TargetsSynced, PushSynced)
+	Conditions *[]Condition `json:"conditions,omitempty"`
+
 	// CurrentStatus High-level status determined by the operator (Pending, Ready, Error, NotFound)
 	CurrentStatus SecretStatusCurrentStatus `json:"currentStatus"`
 
 	// ErrorMessage Detailed error message from the operator if currentStatus is Error
 	ErrorMessage *string `json:"errorMessage,omitempty"`
 
+	// LastRotationTime The timestamp when the generated values were last regenerated
+	LastRotationTime *time.Time `json:"lastRotationTime,omitempty"`
+
 	// LastSyncTime The timestamp of the last successful synchronization
 	LastSyncTime *time.Time `json:"lastSyncTime,omitempty"`
 
+	// NextRotationTime The timestamp of the next scheduled rotation, certificate renewal or source refresh
+	NextRotationTime *time.Time `json:"nextRotationTime,omitempty"`
+
+	// NotAfter Expiry of the issued certificate, Certificate claims only
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+
+	// ObservedGeneration The SecretClaim generation the status was computed for
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// Push Result of the last write of the Kubernetes Secret data to the push store
+	Push *PushStatus `json:"push,omitempty"`
+
 	// SecretName The name of the actual Kubernetes Secret created by the operator
 	SecretName *string `json:"secretName,omitempty"`
 
+	// SourceVersion Version of the external data last written, External claims only
+	SourceVersion *string `json:"sourceVersion,omitempty"`
+
 	// Synced True if the actual Kubernetes Secret has been successfully created and synchronized
 	Synced bool `json:"synced"`
+
+	// Targets Replication state per target namespace
+	Targets *[]TargetStatus `json:"targets,omitempty"`
 }
 
 // SecretStatusCurrentStatus High-level status determined by the operator (Pending, Ready, Error, NotFound)
@@ -230,7 +746,7 @@ type SecretSummary struct {
 	Namespace *string            `json:"namespace,omitempty"`
 	Status    SimpleSecretStatus `json:"status"`
 
-	// Type Opaque or AutoGenerated
+	// Type Opaque, AutoGenerated, Certificate or External
 	Type string `json:"type"`
 }
 
@@ -246,23 +762,107 @@ type SimpleSecretStatus struct {
 // SimpleSecretStatusCurrentStatus High-level status determined by the operator
 type SimpleSecretStatusCurrentStatus string
 
+// SourceConfig External store the values of External claims are read from
+type SourceConfig struct {
+	// Address Base URL of the Vault server, vault only
+	Address *string `json:"address,omitempty"`
+
+	// CredentialsSecretRef Secret in the claim namespace whose 'token' key authenticates http and vault requests
+	CredentialsSecretRef *string `json:"credentialsSecretRef,omitempty"`
+
+	// Keys Secret keys mapped to keys of the fetched data. All fetched keys are copied if empty
+	Keys *map[string]string `json:"keys,omitempty"`
+
+	// Mount KV v2 mount path, vault only
+	Mount *string `json:"mount,omitempty"`
+
+	// Path File or directory below the operator source root, the URL for http, the secret path inside the KV v2 mount for vault
+	Path string `json:"path"`
+
+	// Provider file (mounted into the operator), http (endpoint returning a JSON object) or vault (KV v2 API)
+	Provider SourceConfigProvider `json:"provider"`
+
+	// RefreshInterval Go duration between two reads of the source, 1h by default
+	RefreshInterval *string `json:"refreshInterval,omitempty"`
+}
+
+// SourceConfigProvider file (mounted into the operator), http (endpoint returning a JSON object) or vault (KV v2 API)
+type SourceConfigProvider string
+
+// TargetStatus Replication state of the Kubernetes Secret in a target namespace
+type TargetStatus struct {
+	// LastUpdate The timestamp when the replica was last written
+	LastUpdate *time.Time `json:"lastUpdate,omitempty"`
+
+	// Message Why the replica is not synced
+	Message *string `json:"message,omitempty"`
+
+	// Namespace Target namespace name
+	Namespace string `json:"namespace"`
+
+	// Synced True if the replica matches the Kubernetes Secret
+	Synced bool `json:"synced"`
+}
+
+// TargetsConfig Namespaces the Kubernetes Secret is replicated to, in addition to the claim's namespace
+type TargetsConfig struct {
+	// NamespaceSelector Labels a namespace must have to be a target. Requires the admin role
+	NamespaceSelector *map[string]string `json:"namespaceSelector,omitempty"`
+
+	// Namespaces Names of the target namespaces
+	Namespaces *[]string `json:"namespaces,omitempty"`
+}
+
 // UpdateSecretRequest Request body to update the data of an existing Secret
 type UpdateSecretRequest struct {
 	// Annotations New set of key-value annotations to overwrite existing annotations. Pass empty object to clear
 	Annotations *map[string]string `json:"annotations,omitempty"`
 
+	// Certificate TLS certificate issued by the operator for Certificate claims
+	Certificate *CertificateConfig `json:"certificate,omitempty"`
+
 	// Data New key-value data if type='Opaque'. Pass empty object to clear
 	Data *map[string]string `json:"data,omitempty"`
 
+	// DeletionPolicy What happens to the Kubernetes Secret when the SecretClaim is deleted (Delete by default)
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DockerConfig Image registry credentials rendered into .dockerconfigjson
+	DockerConfig *DockerConfig `json:"dockerConfig,omitempty"`
+
+	// EncryptedData New sealed values if type='Opaque'. Pass empty object to clear
+	EncryptedData *map[string]string `json:"encryptedData,omitempty"`
+
 	// GenerationConfig Secret generation settings
 	GenerationConfig *GenerationConfig `json:"generationConfig,omitempty"`
 
 	// Labels New set of key-value labels to overwrite existing labels. Pass empty object to clear
 	Labels *map[string]string `json:"labels,omitempty"`
 
+	// Push External store the Kubernetes Secret data is written to whenever it changes
+	Push *PushConfig `json:"push,omitempty"`
+
 	// Regenerate Value to regenerate value in update request. Only if 'AutoGenerated'
 	Regenerate *bool `json:"regenerate,omitempty"`
 
+	// RegenerateKeys Regenerate only these data keys, other generated values are kept. Only if 'AutoGenerated'
+	RegenerateKeys *[]string `json:"regenerateKeys,omitempty"`
+
+	// Rotation Scheduled regeneration settings, AutoGenerated only
+	Rotation *RotationConfig `json:"rotation,omitempty"`
+
+	// SecretType Type of the created Kubernetes Secret (Opaque by default)
+	SecretType *SecretType `json:"secretType,omitempty"`
+
+	// Source External store the values of External claims are read from
+	Source *SourceConfig `json:"source,omitempty"`
+
+	// Targets Namespaces the Kubernetes Secret is replicated to, in addition to the claim's namespace
+	Targets *TargetsConfig `json:"targets,omitempty"`
+
+	// Templates New set of templated keys to overwrite existing templates. Pass empty object to clear
+	Templates *map[string]string `json:"templates,omitempty"`
+
 	// Type Pass to change the secret type
 	Type *UpdateSecretRequestType `json:"type,omitempty"`
 }
@@ -270,12 +870,44 @@ type UpdateSecretRequest struct {
 // UpdateSecretRequestType Pass to change the secret type
 type UpdateSecretRequestType string
 
+// UpdateUserRequest Changes of a user; fields that are not set are kept
+type UpdateUserRequest struct {
+	AllowedNamespaces *[]string `json:"allowedNamespaces,omitempty"`
+	KubernetesGroups  *[]string `json:"kubernetesGroups,omitempty"`
+	KubernetesUser    *string   `json:"kubernetesUser,omitempty"`
+
+	// Password At least 8 characters
+	Password    *string   `json:"password,omitempty"`
+	Permissions *[]string `json:"permissions,omitempty"`
+	Role        *string   `json:"role,omitempty"`
+}
+
+// UserResponse User without the password hash
+type UserResponse struct {
+	AllowedNamespaces []string `json:"allowedNamespaces"`
+
+	// FromConfig Defined in the config file and read-only through the API
+	FromConfig       bool      `json:"fromConfig"`
+	Id               string    `json:"id"`
+	KubernetesGroups *[]string `json:"kubernetesGroups,omitempty"`
+	KubernetesUser   *string   `json:"kubernetesUser,omitempty"`
+	Permissions      *[]string `json:"permissions,omitempty"`
+	Role             string    `json:"role"`
+	Username         string    `json:"username"`
+}
+
+// APIKeyID defines model for APIKeyID.
+type APIKeyID = string
+
 // Namespace defines model for Namespace.
 type Namespace = string
 
 // ResourceName defines model for ResourceName.
 type ResourceName = string
 
+// Username defines model for Username.
+type Username = string
+
 // XRequestID defines model for XRequestID.
 type XRequestID = string
 
@@ -297,52 +929,189 @@ type NotFound = ErrorNotFound
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = ErrorUnauthorized
 
-// ListSecretsParams defines parameters for ListSecrets.
-type ListSecretsParams struct {
-	Namespace Namespace `form:"namespace" json:"namespace"`
-
+// GetJWKSParams defines parameters for GetJWKS.
+type GetJWKSParams struct {
 	// XRequestID Correlation ID for tracing
 	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
 }
 
-// CreateSecretParams defines parameters for CreateSecret.
-type CreateSecretParams struct {
+// ListAPIKeysParams defines parameters for ListAPIKeys.
+type ListAPIKeysParams struct {
 	// XRequestID Correlation ID for tracing
 	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
 }
 
-// DeleteSecretParams defines parameters for DeleteSecret.
-type DeleteSecretParams struct {
-	Namespace Namespace `form:"namespace" json:"namespace"`
+// CreateAPIKeyParams defines parameters for CreateAPIKey.
+type CreateAPIKeyParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
 
+// DeleteAPIKeyParams defines parameters for DeleteAPIKey.
+type DeleteAPIKeyParams struct {
 	// XRequestID Correlation ID for tracing
 	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
 }
 
-// GetSecretParams defines parameters for GetSecret.
-type GetSecretParams struct {
-	Namespace Namespace `form:"namespace" json:"namespace"`
+// ListClusterSecretsParams defines parameters for ListClusterSecrets.
+type ListClusterSecretsParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
 
+// CreateClusterSecretParams defines parameters for CreateClusterSecret.
+type CreateClusterSecretParams struct {
 	// XRequestID Correlation ID for tracing
 	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
 }
 
-// UpdateSecretParams defines parameters for UpdateSecret.
-type UpdateSecretParams struct {
-	Namespace Namespace `form:"namespace" json:"namespace"`
+// DeleteClusterSecretParams defines parameters for DeleteClusterSecret.
+type DeleteClusterSecretParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
 
+// GetClusterSecretParams defines parameters for GetClusterSecret.
+type GetClusterSecretParams struct {
 	// XRequestID Correlation ID for tracing
 	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
 }
 
-// AuthUserParams defines parameters for AuthUser.
-type AuthUserParams struct {
+// UpdateClusterSecretParams defines parameters for UpdateClusterSecret.
+type UpdateClusterSecretParams struct {
 	// XRequestID Correlation ID for tracing
 	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
 }
 
-// CreateSecretJSONRequestBody defines body for CreateSecret for application/json ContentType.
-type CreateSecretJSONRequestBody = CreateSecretRequest
+// RevealClusterSecretParams defines parameters for RevealClusterSecret.
+type RevealClusterSecretParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// GetSealingKeyParams defines parameters for GetSealingKey.
+type GetSealingKeyParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// ListSecretsParams defines parameters for ListSecrets.
+type ListSecretsParams struct {
+	Namespace Namespace `form:"namespace" json:"namespace"`
+
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// CreateSecretParams defines parameters for CreateSecret.
+type CreateSecretParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// DeleteSecretParams defines parameters for DeleteSecret.
+type DeleteSecretParams struct {
+	Namespace Namespace `form:"namespace" json:"namespace"`
+
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// GetSecretParams defines parameters for GetSecret.
+type GetSecretParams struct {
+	Namespace Namespace `form:"namespace" json:"namespace"`
+
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// UpdateSecretParams defines parameters for UpdateSecret.
+type UpdateSecretParams struct {
+	Namespace Namespace `form:"namespace" json:"namespace"`
+
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// RevealSecretParams defines parameters for RevealSecret.
+type RevealSecretParams struct {
+	Namespace Namespace `form:"namespace" json:"namespace"`
+
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// AuthUserParams defines parameters for AuthUser.
+type AuthUserParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// LogoutUserParams defines parameters for LogoutUser.
+type LogoutUserParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// GetOIDCConfigParams defines parameters for GetOIDCConfig.
+type GetOIDCConfigParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// ChangePasswordParams defines parameters for ChangePassword.
+type ChangePasswordParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// RefreshUserTokenParams defines parameters for RefreshUserToken.
+type RefreshUserTokenParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// CreateUserParams defines parameters for CreateUser.
+type CreateUserParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// DeleteUserParams defines parameters for DeleteUser.
+type DeleteUserParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// GetUserParams defines parameters for GetUser.
+type GetUserParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// UpdateUserParams defines parameters for UpdateUser.
+type UpdateUserParams struct {
+	// XRequestID Correlation ID for tracing
+	XRequestID *XRequestID `json:"X-Request-ID,omitempty"`
+}
+
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = CreateAPIKeyRequest
+
+// CreateClusterSecretJSONRequestBody defines body for CreateClusterSecret for application/json ContentType.
+type CreateClusterSecretJSONRequestBody = CreateClusterSecretRequest
+
+// UpdateClusterSecretJSONRequestBody defines body for UpdateClusterSecret for application/json ContentType.
+type UpdateClusterSecretJSONRequestBody = UpdateSecretRequest
+
+// CreateSecretJSONRequestBody defines body for CreateSecret for application/json ContentType.
+type CreateSecretJSONRequestBody = CreateSecretRequest
 
 // UpdateSecretJSONRequestBody defines body for UpdateSecret for application/json ContentType.
 type UpdateSecretJSONRequestBody = UpdateSecretRequest
@@ -350,8 +1119,56 @@ type UpdateSecretJSONRequestBody = UpdateSecretRequest
 // AuthUserJSONRequestBody defines body for AuthUser for application/json ContentType.
 type AuthUserJSONRequestBody = AuthUserRequest
 
+// LogoutUserJSONRequestBody defines body for LogoutUser for application/json ContentType.
+type LogoutUserJSONRequestBody = LogoutUserRequest
+
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = ChangePasswordRequest
+
+// RefreshUserTokenJSONRequestBody defines body for RefreshUserToken for application/json ContentType.
+type RefreshUserTokenJSONRequestBody = RefreshUserTokenRequest
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = CreateUserRequest
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UpdateUserRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get the token signing keys
+	// (GET /.well-known/jwks.json)
+	GetJWKS(w http.ResponseWriter, r *http.Request, params GetJWKSParams)
+	// List API keys
+	// (GET /api-keys)
+	ListAPIKeys(w http.ResponseWriter, r *http.Request, params ListAPIKeysParams)
+	// Create an API key
+	// (POST /api-keys)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request, params CreateAPIKeyParams)
+	// Revoke an API key
+	// (DELETE /api-keys/{id})
+	DeleteAPIKey(w http.ResponseWriter, r *http.Request, id APIKeyID, params DeleteAPIKeyParams)
+	// List cluster secrets
+	// (GET /cluster-secrets)
+	ListClusterSecrets(w http.ResponseWriter, r *http.Request, params ListClusterSecretsParams)
+	// Create a cluster secret
+	// (POST /cluster-secrets)
+	CreateClusterSecret(w http.ResponseWriter, r *http.Request, params CreateClusterSecretParams)
+	// Delete cluster secret
+	// (DELETE /cluster-secrets/{name})
+	DeleteClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params DeleteClusterSecretParams)
+	// Get cluster secret
+	// (GET /cluster-secrets/{name})
+	GetClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params GetClusterSecretParams)
+	// Update cluster secret
+	// (PUT /cluster-secrets/{name})
+	UpdateClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params UpdateClusterSecretParams)
+	// Reveal secret values
+	// (GET /cluster-secrets/{name}/reveal)
+	RevealClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params RevealClusterSecretParams)
+	// Get the sealing public key
+	// (GET /sealing-key)
+	GetSealingKey(w http.ResponseWriter, r *http.Request, params GetSealingKeyParams)
 	// List available secrets
 	// (GET /secrets)
 	ListSecrets(w http.ResponseWriter, r *http.Request, params ListSecretsParams)
@@ -367,15 +1184,111 @@ type ServerInterface interface {
 	// Update secret
 	// (PUT /secrets/{name})
 	UpdateSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params UpdateSecretParams)
+	// Reveal secret values
+	// (GET /secrets/{name}/reveal)
+	RevealSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params RevealSecretParams)
 	// Exchange Auth Data for JWT
 	// (POST /user/auth)
 	AuthUser(w http.ResponseWriter, r *http.Request, params AuthUserParams)
+	// Revoke the current tokens
+	// (POST /user/logout)
+	LogoutUser(w http.ResponseWriter, r *http.Request, params LogoutUserParams)
+	// Get the OIDC login settings
+	// (GET /user/oidc)
+	GetOIDCConfig(w http.ResponseWriter, r *http.Request, params GetOIDCConfigParams)
+	// Change the own password
+	// (PUT /user/password)
+	ChangePassword(w http.ResponseWriter, r *http.Request, params ChangePasswordParams)
+	// Exchange a refresh token for new tokens
+	// (POST /user/refresh)
+	RefreshUserToken(w http.ResponseWriter, r *http.Request, params RefreshUserTokenParams)
+	// List users
+	// (GET /users)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// Create a user
+	// (POST /users)
+	CreateUser(w http.ResponseWriter, r *http.Request, params CreateUserParams)
+	// Delete a user
+	// (DELETE /users/{username})
+	DeleteUser(w http.ResponseWriter, r *http.Request, username Username, params DeleteUserParams)
+	// Get a user
+	// (GET /users/{username})
+	GetUser(w http.ResponseWriter, r *http.Request, username Username, params GetUserParams)
+	// Update a user
+	// (PUT /users/{username})
+	UpdateUser(w http.ResponseWriter, r *http.Request, username Username, params UpdateUserParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Get the token signing keys
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetJWKS(w http.ResponseWriter, r *http.Request, params GetJWKSParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List API keys
+// (GET /api-keys)
+func (_ Unimplemented) ListAPIKeys(w http.ResponseWriter, r *http.Request, params ListAPIKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key
+// (POST /api-keys)
+func (_ Unimplemented) CreateAPIKey(w http.ResponseWriter, r *http.Request, params CreateAPIKeyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key
+// (DELETE /api-keys/{id})
+func (_ Unimplemented) DeleteAPIKey(w http.ResponseWriter, r *http.Request, id APIKeyID, params DeleteAPIKeyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List cluster secrets
+// (GET /cluster-secrets)
+func (_ Unimplemented) ListClusterSecrets(w http.ResponseWriter, r *http.Request, params ListClusterSecretsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a cluster secret
+// (POST /cluster-secrets)
+func (_ Unimplemented) CreateClusterSecret(w http.ResponseWriter, r *http.Request, params CreateClusterSecretParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete cluster secret
+// (DELETE /cluster-secrets/{name})
+func (_ Unimplemented) DeleteClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params DeleteClusterSecretParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get cluster secret
+// (GET /cluster-secrets/{name})
+func (_ Unimplemented) GetClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params GetClusterSecretParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update cluster secret
+// (PUT /cluster-secrets/{name})
+func (_ Unimplemented) UpdateClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params UpdateClusterSecretParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reveal secret values
+// (GET /cluster-secrets/{name}/reveal)
+func (_ Unimplemented) RevealClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params RevealClusterSecretParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the sealing public key
+// (GET /sealing-key)
+func (_ Unimplemented) GetSealingKey(w http.ResponseWriter, r *http.Request, params GetSealingKeyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List available secrets
 // (GET /secrets)
 func (_ Unimplemented) ListSecrets(w http.ResponseWriter, r *http.Request, params ListSecretsParams) {
@@ -406,12 +1319,72 @@ func (_ Unimplemented) UpdateSecret(w http.ResponseWriter, r *http.Request, name
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Reveal secret values
+// (GET /secrets/{name}/reveal)
+func (_ Unimplemented) RevealSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params RevealSecretParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Exchange Auth Data for JWT
 // (POST /user/auth)
 func (_ Unimplemented) AuthUser(w http.ResponseWriter, r *http.Request, params AuthUserParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Revoke the current tokens
+// (POST /user/logout)
+func (_ Unimplemented) LogoutUser(w http.ResponseWriter, r *http.Request, params LogoutUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the OIDC login settings
+// (GET /user/oidc)
+func (_ Unimplemented) GetOIDCConfig(w http.ResponseWriter, r *http.Request, params GetOIDCConfigParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the own password
+// (PUT /user/password)
+func (_ Unimplemented) ChangePassword(w http.ResponseWriter, r *http.Request, params ChangePasswordParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Exchange a refresh token for new tokens
+// (POST /user/refresh)
+func (_ Unimplemented) RefreshUserToken(w http.ResponseWriter, r *http.Request, params RefreshUserTokenParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List users
+// (GET /users)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a user
+// (POST /users)
+func (_ Unimplemented) CreateUser(w http.ResponseWriter, r *http.Request, params CreateUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a user
+// (DELETE /users/{username})
+func (_ Unimplemented) DeleteUser(w http.ResponseWriter, r *http.Request, username Username, params DeleteUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a user
+// (GET /users/{username})
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, username Username, params GetUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a user
+// (PUT /users/{username})
+func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, username Username, params UpdateUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -421,34 +1394,13 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// ListSecrets operation middleware
-func (siw *ServerInterfaceWrapper) ListSecrets(w http.ResponseWriter, r *http.Request) {
+// GetJWKS operation middleware
+func (siw *ServerInterfaceWrapper) GetJWKS(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
-
-	r = r.WithContext(ctx)
-
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListSecretsParams
-
-	// ------------- Required query parameter "namespace" -------------
-
-	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
-		return
-	}
+	var params GetJWKSParams
 
 	headers := r.Header
 
@@ -472,7 +1424,7 @@ func (siw *ServerInterfaceWrapper) ListSecrets(w http.ResponseWriter, r *http.Re
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListSecrets(w, r, params)
+		siw.Handler.GetJWKS(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -482,8 +1434,8 @@ func (siw *ServerInterfaceWrapper) ListSecrets(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// CreateSecret operation middleware
-func (siw *ServerInterfaceWrapper) CreateSecret(w http.ResponseWriter, r *http.Request) {
+// ListAPIKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -494,7 +1446,7 @@ func (siw *ServerInterfaceWrapper) CreateSecret(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateSecretParams
+	var params ListAPIKeysParams
 
 	headers := r.Header
 
@@ -518,7 +1470,7 @@ func (siw *ServerInterfaceWrapper) CreateSecret(w http.ResponseWriter, r *http.R
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateSecret(w, r, params)
+		siw.Handler.ListAPIKeys(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -528,20 +1480,11 @@ func (siw *ServerInterfaceWrapper) CreateSecret(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteSecret operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSecret(w http.ResponseWriter, r *http.Request) {
+// CreateAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name ResourceName
-
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
-	}
-
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -549,22 +1492,7 @@ func (siw *ServerInterfaceWrapper) DeleteSecret(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteSecretParams
-
-	// ------------- Required query parameter "namespace" -------------
-
-	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
-		return
-	}
+	var params CreateAPIKeyParams
 
 	headers := r.Header
 
@@ -588,7 +1516,7 @@ func (siw *ServerInterfaceWrapper) DeleteSecret(w http.ResponseWriter, r *http.R
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteSecret(w, r, name, params)
+		siw.Handler.CreateAPIKey(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -598,17 +1526,17 @@ func (siw *ServerInterfaceWrapper) DeleteSecret(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetSecret operation middleware
-func (siw *ServerInterfaceWrapper) GetSecret(w http.ResponseWriter, r *http.Request) {
+// DeleteAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name ResourceName
+	// ------------- Path parameter "id" -------------
+	var id APIKeyID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -619,22 +1547,7 @@ func (siw *ServerInterfaceWrapper) GetSecret(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSecretParams
-
-	// ------------- Required query parameter "namespace" -------------
-
-	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
-		return
-	}
+	var params DeleteAPIKeyParams
 
 	headers := r.Header
 
@@ -658,7 +1571,7 @@ func (siw *ServerInterfaceWrapper) GetSecret(w http.ResponseWriter, r *http.Requ
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSecret(w, r, name, params)
+		siw.Handler.DeleteAPIKey(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -668,20 +1581,11 @@ func (siw *ServerInterfaceWrapper) GetSecret(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateSecret operation middleware
-func (siw *ServerInterfaceWrapper) UpdateSecret(w http.ResponseWriter, r *http.Request) {
+// ListClusterSecrets operation middleware
+func (siw *ServerInterfaceWrapper) ListClusterSecrets(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name ResourceName
-
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
-	}
-
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -689,22 +1593,7 @@ func (siw *ServerInterfaceWrapper) UpdateSecret(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params UpdateSecretParams
-
-	// ------------- Required query parameter "namespace" -------------
-
-	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
-
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
-		return
-	}
+	var params ListClusterSecretsParams
 
 	headers := r.Header
 
@@ -728,7 +1617,7 @@ func (siw *ServerInterfaceWrapper) UpdateSecret(w http.ResponseWriter, r *http.R
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateSecret(w, r, name, params)
+		siw.Handler.ListClusterSecrets(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -738,8 +1627,8 @@ func (siw *ServerInterfaceWrapper) UpdateSecret(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// AuthUser operation middleware
-func (siw *ServerInterfaceWrapper) AuthUser(w http.ResponseWriter, r *http.Request) {
+// CreateClusterSecret operation middleware
+func (siw *ServerInterfaceWrapper) CreateClusterSecret(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -750,7 +1639,7 @@ func (siw *ServerInterfaceWrapper) AuthUser(w http.ResponseWriter, r *http.Reque
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params AuthUserParams
+	var params CreateClusterSecretParams
 
 	headers := r.Header
 
@@ -774,7 +1663,7 @@ func (siw *ServerInterfaceWrapper) AuthUser(w http.ResponseWriter, r *http.Reque
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AuthUser(w, r, params)
+		siw.Handler.CreateClusterSecret(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -784,518 +1673,2866 @@ func (siw *ServerInterfaceWrapper) AuthUser(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// DeleteClusterSecret operation middleware
+func (siw *ServerInterfaceWrapper) DeleteClusterSecret(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	r = r.WithContext(ctx)
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteClusterSecretParams
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	headers := r.Header
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+		params.XRequestID = &XRequestID
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteClusterSecret(w, r, name, params)
+	}))
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+	handler.ServeHTTP(w, r)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+// GetClusterSecret operation middleware
+func (siw *ServerInterfaceWrapper) GetClusterSecret(w http.ResponseWriter, r *http.Request) {
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var err error
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+	ctx := r.Context()
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/secrets", wrapper.ListSecrets)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/secrets", wrapper.CreateSecret)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/secrets/{name}", wrapper.DeleteSecret)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/secrets/{name}", wrapper.GetSecret)
-	})
-	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/secrets/{name}", wrapper.UpdateSecret)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/user/auth", wrapper.AuthUser)
-	})
+	r = r.WithContext(ctx)
 
-	return r
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClusterSecretParams
 
-type BadRequestJSONResponse ErrorBadRequest
+	headers := r.Header
 
-type ConflictJSONResponse ErrorConflict
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
 
-type ForbiddenJSONResponse ErrorForbidden
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
 
-type InternalJSONResponse ErrorInternal
+		params.XRequestID = &XRequestID
 
-type NotFoundJSONResponse ErrorNotFound
+	}
 
-type OkResponseJSONResponse OkResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClusterSecret(w, r, name, params)
+	}))
 
-type UnauthorizedJSONResponse ErrorUnauthorized
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ListSecretsRequestObject struct {
-	Params ListSecretsParams
+	handler.ServeHTTP(w, r)
 }
 
-type ListSecretsResponseObject interface {
-	VisitListSecretsResponse(w http.ResponseWriter) error
-}
+// UpdateClusterSecret operation middleware
+func (siw *ServerInterfaceWrapper) UpdateClusterSecret(w http.ResponseWriter, r *http.Request) {
 
-type ListSecrets200JSONResponse ListSecretsResponse
+	var err error
 
-func (response ListSecrets200JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-type ListSecrets400JSONResponse struct{ BadRequestJSONResponse }
+	ctx := r.Context()
 
-func (response ListSecrets400JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type ListSecrets401JSONResponse struct{ UnauthorizedJSONResponse }
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateClusterSecretParams
 
-func (response ListSecrets401JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	headers := r.Header
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
 
-type ListSecrets403JSONResponse struct{ ForbiddenJSONResponse }
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
 
-func (response ListSecrets403JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+		params.XRequestID = &XRequestID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	}
 
-type ListSecrets404JSONResponse struct{ NotFoundJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateClusterSecret(w, r, name, params)
+	}))
 
-func (response ListSecrets404JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ListSecrets500JSONResponse struct{ InternalJSONResponse }
-
-func (response ListSecrets500JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// RevealClusterSecret operation middleware
+func (siw *ServerInterfaceWrapper) RevealClusterSecret(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type CreateSecretRequestObject struct {
-	Params CreateSecretParams
-	Body   *CreateSecretJSONRequestBody
-}
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
 
-type CreateSecretResponseObject interface {
-	VisitCreateSecretResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
-type CreateSecret201JSONResponse struct{ OkResponseJSONResponse }
+	ctx := r.Context()
 
-func (response CreateSecret201JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type CreateSecret400JSONResponse struct{ BadRequestJSONResponse }
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RevealClusterSecretParams
 
-func (response CreateSecret400JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	headers := r.Header
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
 
-type CreateSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
 
-func (response CreateSecret401JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+		params.XRequestID = &XRequestID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	}
 
-type CreateSecret403JSONResponse struct{ ForbiddenJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevealClusterSecret(w, r, name, params)
+	}))
 
-func (response CreateSecret403JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CreateSecret404JSONResponse struct{ NotFoundJSONResponse }
+// GetSealingKey operation middleware
+func (siw *ServerInterfaceWrapper) GetSealingKey(w http.ResponseWriter, r *http.Request) {
 
-func (response CreateSecret404JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type CreateSecret409JSONResponse struct{ ConflictJSONResponse }
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (response CreateSecret409JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSealingKeyParams
 
-type CreateSecret500JSONResponse struct{ InternalJSONResponse }
+	headers := r.Header
 
-func (response CreateSecret500JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
 
-	return json.NewEncoder(w).Encode(response)
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
 
-type DeleteSecretRequestObject struct {
-	Name   ResourceName `json:"name"`
-	Params DeleteSecretParams
-}
+		params.XRequestID = &XRequestID
 
-type DeleteSecretResponseObject interface {
-	VisitDeleteSecretResponse(w http.ResponseWriter) error
-}
+	}
 
-type DeleteSecret200JSONResponse struct{ OkResponseJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSealingKey(w, r, params)
+	}))
 
-func (response DeleteSecret200JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type DeleteSecret400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response DeleteSecret400JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
+// ListSecrets operation middleware
+func (siw *ServerInterfaceWrapper) ListSecrets(w http.ResponseWriter, r *http.Request) {
 
-type DeleteSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+	var err error
 
-func (response DeleteSecret401JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type DeleteSecret403JSONResponse struct{ ForbiddenJSONResponse }
+	r = r.WithContext(ctx)
 
-func (response DeleteSecret403JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSecretsParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Required query parameter "namespace" -------------
 
-type DeleteSecret404JSONResponse struct{ NotFoundJSONResponse }
+	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
 
-func (response DeleteSecret404JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
+		return
+	}
 
-type DeleteSecret500JSONResponse struct{ InternalJSONResponse }
+	headers := r.Header
 
-func (response DeleteSecret500JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
 
-	return json.NewEncoder(w).Encode(response)
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
 
-type GetSecretRequestObject struct {
-	Name   ResourceName `json:"name"`
-	Params GetSecretParams
-}
+		params.XRequestID = &XRequestID
 
-type GetSecretResponseObject interface {
-	VisitGetSecretResponse(w http.ResponseWriter) error
-}
+	}
 
-type GetSecret200JSONResponse SecretResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSecrets(w, r, params)
+	}))
 
-func (response GetSecret200JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetSecret400JSONResponse struct{ BadRequestJSONResponse }
+// CreateSecret operation middleware
+func (siw *ServerInterfaceWrapper) CreateSecret(w http.ResponseWriter, r *http.Request) {
 
-func (response GetSecret400JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type GetSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (response GetSecret401JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateSecretParams
 
-type GetSecret403JSONResponse struct{ ForbiddenJSONResponse }
+	headers := r.Header
 
-func (response GetSecret403JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSecret(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSecret operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSecret(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSecretParams
+
+	// ------------- Required query parameter "namespace" -------------
+
+	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSecret(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSecret operation middleware
+func (siw *ServerInterfaceWrapper) GetSecret(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSecretParams
+
+	// ------------- Required query parameter "namespace" -------------
+
+	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSecret(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateSecret operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSecret(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateSecretParams
+
+	// ------------- Required query parameter "namespace" -------------
+
+	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateSecret(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevealSecret operation middleware
+func (siw *ServerInterfaceWrapper) RevealSecret(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name ResourceName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RevealSecretParams
+
+	// ------------- Required query parameter "namespace" -------------
+
+	if paramValue := r.URL.Query().Get("namespace"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "namespace"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "namespace", r.URL.Query(), &params.Namespace)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "namespace", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevealSecret(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AuthUser operation middleware
+func (siw *ServerInterfaceWrapper) AuthUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AuthUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LogoutUser operation middleware
+func (siw *ServerInterfaceWrapper) LogoutUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LogoutUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LogoutUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOIDCConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetOIDCConfig(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOIDCConfigParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOIDCConfig(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ChangePassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangePassword(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ChangePasswordParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangePassword(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshUserToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshUserToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RefreshUserTokenParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshUserToken(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username Username
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, username, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username Username
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, username, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username Username
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Request-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Request-ID", Err: err})
+			return
+		}
+
+		params.XRequestID = &XRequestID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateUser(w, r, username, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetJWKS)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api-keys", wrapper.ListAPIKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api-keys", wrapper.CreateAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api-keys/{id}", wrapper.DeleteAPIKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cluster-secrets", wrapper.ListClusterSecrets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cluster-secrets", wrapper.CreateClusterSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/cluster-secrets/{name}", wrapper.DeleteClusterSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cluster-secrets/{name}", wrapper.GetClusterSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/cluster-secrets/{name}", wrapper.UpdateClusterSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cluster-secrets/{name}/reveal", wrapper.RevealClusterSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sealing-key", wrapper.GetSealingKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/secrets", wrapper.ListSecrets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/secrets", wrapper.CreateSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/secrets/{name}", wrapper.DeleteSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/secrets/{name}", wrapper.GetSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/secrets/{name}", wrapper.UpdateSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/secrets/{name}/reveal", wrapper.RevealSecret)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/auth", wrapper.AuthUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/logout", wrapper.LogoutUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/user/oidc", wrapper.GetOIDCConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/user/password", wrapper.ChangePassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/refresh", wrapper.RefreshUserToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users", wrapper.ListUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users", wrapper.CreateUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{username}", wrapper.DeleteUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{username}", wrapper.GetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/users/{username}", wrapper.UpdateUser)
+	})
+
+	return r
+}
+
+type BadRequestJSONResponse ErrorBadRequest
+
+type ConflictJSONResponse ErrorConflict
+
+type ForbiddenJSONResponse ErrorForbidden
+
+type InternalJSONResponse ErrorInternal
+
+type NotFoundJSONResponse ErrorNotFound
+
+type OkResponseJSONResponse OkResponse
+
+type UnauthorizedJSONResponse ErrorUnauthorized
+
+type GetJWKSRequestObject struct {
+	Params GetJWKSParams
+}
+
+type GetJWKSResponseObject interface {
+	VisitGetJWKSResponse(w http.ResponseWriter) error
+}
+
+type GetJWKS200JSONResponse JSONWebKeySet
+
+func (response GetJWKS200JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJWKS404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetJWKS404JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJWKS500JSONResponse struct{ InternalJSONResponse }
+
+func (response GetJWKS500JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeysRequestObject struct {
+	Params ListAPIKeysParams
+}
+
+type ListAPIKeysResponseObject interface {
+	VisitListAPIKeysResponse(w http.ResponseWriter) error
+}
+
+type ListAPIKeys200JSONResponse ListAPIKeysResponse
+
+func (response ListAPIKeys200JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListAPIKeys401JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListAPIKeys403JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAPIKeys500JSONResponse struct{ InternalJSONResponse }
+
+func (response ListAPIKeys500JSONResponse) VisitListAPIKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKeyRequestObject struct {
+	Params CreateAPIKeyParams
+	Body   *CreateAPIKeyJSONRequestBody
+}
+
+type CreateAPIKeyResponseObject interface {
+	VisitCreateAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateAPIKey201JSONResponse CreateAPIKeyResponse
+
+func (response CreateAPIKey201JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateAPIKey400JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateAPIKey401JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateAPIKey403JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAPIKey500JSONResponse struct{ InternalJSONResponse }
+
+func (response CreateAPIKey500JSONResponse) VisitCreateAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAPIKeyRequestObject struct {
+	Id     APIKeyID `json:"id"`
+	Params DeleteAPIKeyParams
+}
+
+type DeleteAPIKeyResponseObject interface {
+	VisitDeleteAPIKeyResponse(w http.ResponseWriter) error
+}
+
+type DeleteAPIKey200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response DeleteAPIKey200JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAPIKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteAPIKey401JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAPIKey403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteAPIKey403JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAPIKey404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteAPIKey404JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAPIKey500JSONResponse struct{ InternalJSONResponse }
+
+func (response DeleteAPIKey500JSONResponse) VisitDeleteAPIKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterSecretsRequestObject struct {
+	Params ListClusterSecretsParams
+}
+
+type ListClusterSecretsResponseObject interface {
+	VisitListClusterSecretsResponse(w http.ResponseWriter) error
+}
+
+type ListClusterSecrets200JSONResponse ListSecretsResponse
+
+func (response ListClusterSecrets200JSONResponse) VisitListClusterSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterSecrets400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListClusterSecrets400JSONResponse) VisitListClusterSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterSecrets401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListClusterSecrets401JSONResponse) VisitListClusterSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterSecrets403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListClusterSecrets403JSONResponse) VisitListClusterSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterSecrets404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ListClusterSecrets404JSONResponse) VisitListClusterSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterSecrets500JSONResponse struct{ InternalJSONResponse }
+
+func (response ListClusterSecrets500JSONResponse) VisitListClusterSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecretRequestObject struct {
+	Params CreateClusterSecretParams
+	Body   *CreateClusterSecretJSONRequestBody
+}
+
+type CreateClusterSecretResponseObject interface {
+	VisitCreateClusterSecretResponse(w http.ResponseWriter) error
+}
+
+type CreateClusterSecret201JSONResponse struct{ OkResponseJSONResponse }
+
+func (response CreateClusterSecret201JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateClusterSecret400JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateClusterSecret401JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateClusterSecret403JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreateClusterSecret404JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecret409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateClusterSecret409JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClusterSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response CreateClusterSecret500JSONResponse) VisitCreateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteClusterSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params DeleteClusterSecretParams
+}
+
+type DeleteClusterSecretResponseObject interface {
+	VisitDeleteClusterSecretResponse(w http.ResponseWriter) error
+}
+
+type DeleteClusterSecret200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response DeleteClusterSecret200JSONResponse) VisitDeleteClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteClusterSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DeleteClusterSecret400JSONResponse) VisitDeleteClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteClusterSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteClusterSecret401JSONResponse) VisitDeleteClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteClusterSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteClusterSecret403JSONResponse) VisitDeleteClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteClusterSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteClusterSecret404JSONResponse) VisitDeleteClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteClusterSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response DeleteClusterSecret500JSONResponse) VisitDeleteClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params GetClusterSecretParams
+}
+
+type GetClusterSecretResponseObject interface {
+	VisitGetClusterSecretResponse(w http.ResponseWriter) error
+}
+
+type GetClusterSecret200JSONResponse SecretResponse
+
+func (response GetClusterSecret200JSONResponse) VisitGetClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetClusterSecret400JSONResponse) VisitGetClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetClusterSecret401JSONResponse) VisitGetClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetClusterSecret403JSONResponse) VisitGetClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetClusterSecret404JSONResponse) VisitGetClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response GetClusterSecret500JSONResponse) VisitGetClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClusterSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params UpdateClusterSecretParams
+	Body   *UpdateClusterSecretJSONRequestBody
+}
+
+type UpdateClusterSecretResponseObject interface {
+	VisitUpdateClusterSecretResponse(w http.ResponseWriter) error
+}
+
+type UpdateClusterSecret200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response UpdateClusterSecret200JSONResponse) VisitUpdateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClusterSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateClusterSecret400JSONResponse) VisitUpdateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClusterSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateClusterSecret401JSONResponse) VisitUpdateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClusterSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateClusterSecret403JSONResponse) VisitUpdateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClusterSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateClusterSecret404JSONResponse) VisitUpdateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClusterSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response UpdateClusterSecret500JSONResponse) VisitUpdateClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealClusterSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params RevealClusterSecretParams
+}
+
+type RevealClusterSecretResponseObject interface {
+	VisitRevealClusterSecretResponse(w http.ResponseWriter) error
+}
+
+type RevealClusterSecret200JSONResponse RevealSecretResponse
+
+func (response RevealClusterSecret200JSONResponse) VisitRevealClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealClusterSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RevealClusterSecret400JSONResponse) VisitRevealClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealClusterSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevealClusterSecret401JSONResponse) VisitRevealClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealClusterSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevealClusterSecret403JSONResponse) VisitRevealClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealClusterSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RevealClusterSecret404JSONResponse) VisitRevealClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealClusterSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response RevealClusterSecret500JSONResponse) VisitRevealClusterSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSealingKeyRequestObject struct {
+	Params GetSealingKeyParams
+}
+
+type GetSealingKeyResponseObject interface {
+	VisitGetSealingKeyResponse(w http.ResponseWriter) error
+}
+
+type GetSealingKey200JSONResponse SealingKeyResponse
+
+func (response GetSealingKey200JSONResponse) VisitGetSealingKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSealingKey401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetSealingKey401JSONResponse) VisitGetSealingKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSealingKey404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSealingKey404JSONResponse) VisitGetSealingKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSealingKey500JSONResponse struct{ InternalJSONResponse }
+
+func (response GetSealingKey500JSONResponse) VisitGetSealingKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSecretsRequestObject struct {
+	Params ListSecretsParams
+}
+
+type ListSecretsResponseObject interface {
+	VisitListSecretsResponse(w http.ResponseWriter) error
+}
+
+type ListSecrets200JSONResponse ListSecretsResponse
+
+func (response ListSecrets200JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSecrets400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListSecrets400JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSecrets401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListSecrets401JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSecrets403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListSecrets403JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSecrets404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ListSecrets404JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSecrets500JSONResponse struct{ InternalJSONResponse }
+
+func (response ListSecrets500JSONResponse) VisitListSecretsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecretRequestObject struct {
+	Params CreateSecretParams
+	Body   *CreateSecretJSONRequestBody
+}
+
+type CreateSecretResponseObject interface {
+	VisitCreateSecretResponse(w http.ResponseWriter) error
+}
+
+type CreateSecret201JSONResponse struct{ OkResponseJSONResponse }
+
+func (response CreateSecret201JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateSecret400JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateSecret401JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateSecret403JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreateSecret404JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecret409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateSecret409JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response CreateSecret500JSONResponse) VisitCreateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params DeleteSecretParams
+}
+
+type DeleteSecretResponseObject interface {
+	VisitDeleteSecretResponse(w http.ResponseWriter) error
+}
+
+type DeleteSecret200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response DeleteSecret200JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DeleteSecret400JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteSecret401JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteSecret403JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteSecret404JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response DeleteSecret500JSONResponse) VisitDeleteSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params GetSecretParams
+}
+
+type GetSecretResponseObject interface {
+	VisitGetSecretResponse(w http.ResponseWriter) error
+}
+
+type GetSecret200JSONResponse SecretResponse
+
+func (response GetSecret200JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetSecret400JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetSecret401JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetSecret403JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetSecret404JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response GetSecret500JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params UpdateSecretParams
+	Body   *UpdateSecretJSONRequestBody
+}
+
+type UpdateSecretResponseObject interface {
+	VisitUpdateSecretResponse(w http.ResponseWriter) error
+}
+
+type UpdateSecret200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response UpdateSecret200JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateSecret400JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateSecret401JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateSecret403JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateSecret404JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response UpdateSecret500JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealSecretRequestObject struct {
+	Name   ResourceName `json:"name"`
+	Params RevealSecretParams
+}
+
+type RevealSecretResponseObject interface {
+	VisitRevealSecretResponse(w http.ResponseWriter) error
+}
+
+type RevealSecret200JSONResponse RevealSecretResponse
+
+func (response RevealSecret200JSONResponse) VisitRevealSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealSecret400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RevealSecret400JSONResponse) VisitRevealSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevealSecret401JSONResponse) VisitRevealSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealSecret403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevealSecret403JSONResponse) VisitRevealSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealSecret404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RevealSecret404JSONResponse) VisitRevealSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevealSecret500JSONResponse struct{ InternalJSONResponse }
+
+func (response RevealSecret500JSONResponse) VisitRevealSecretResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthUserRequestObject struct {
+	Params AuthUserParams
+	Body   *AuthUserJSONRequestBody
+}
+
+type AuthUserResponseObject interface {
+	VisitAuthUserResponse(w http.ResponseWriter) error
+}
+
+type AuthUser200JSONResponse AuthUserResponse
+
+func (response AuthUser200JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response AuthUser400JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response AuthUser401JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthUser500JSONResponse struct{ InternalJSONResponse }
+
+func (response AuthUser500JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogoutUserRequestObject struct {
+	Params LogoutUserParams
+	Body   *LogoutUserJSONRequestBody
+}
+
+type LogoutUserResponseObject interface {
+	VisitLogoutUserResponse(w http.ResponseWriter) error
+}
+
+type LogoutUser200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response LogoutUser200JSONResponse) VisitLogoutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogoutUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response LogoutUser400JSONResponse) VisitLogoutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSecret404JSONResponse struct{ NotFoundJSONResponse }
+type LogoutUser401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response GetSecret404JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+func (response LogoutUser401JSONResponse) VisitLogoutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogoutUser500JSONResponse struct{ InternalJSONResponse }
+
+func (response LogoutUser500JSONResponse) VisitLogoutUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOIDCConfigRequestObject struct {
+	Params GetOIDCConfigParams
+}
+
+type GetOIDCConfigResponseObject interface {
+	VisitGetOIDCConfigResponse(w http.ResponseWriter) error
+}
+
+type GetOIDCConfig200JSONResponse OIDCConfigResponse
+
+func (response GetOIDCConfig200JSONResponse) VisitGetOIDCConfigResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOIDCConfig404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetOIDCConfig404JSONResponse) VisitGetOIDCConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetSecret500JSONResponse struct{ InternalJSONResponse }
+type GetOIDCConfig500JSONResponse struct{ InternalJSONResponse }
 
-func (response GetSecret500JSONResponse) VisitGetSecretResponse(w http.ResponseWriter) error {
+func (response GetOIDCConfig500JSONResponse) VisitGetOIDCConfigResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSecretRequestObject struct {
-	Name   ResourceName `json:"name"`
-	Params UpdateSecretParams
-	Body   *UpdateSecretJSONRequestBody
+type ChangePasswordRequestObject struct {
+	Params ChangePasswordParams
+	Body   *ChangePasswordJSONRequestBody
 }
 
-type UpdateSecretResponseObject interface {
-	VisitUpdateSecretResponse(w http.ResponseWriter) error
+type ChangePasswordResponseObject interface {
+	VisitChangePasswordResponse(w http.ResponseWriter) error
 }
 
-type UpdateSecret200JSONResponse struct{ OkResponseJSONResponse }
+type ChangePassword200JSONResponse struct{ OkResponseJSONResponse }
 
-func (response UpdateSecret200JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+func (response ChangePassword200JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSecret400JSONResponse struct{ BadRequestJSONResponse }
+type ChangePassword400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response UpdateSecret400JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+func (response ChangePassword400JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSecret401JSONResponse struct{ UnauthorizedJSONResponse }
+type ChangePassword401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response UpdateSecret401JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+func (response ChangePassword401JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSecret403JSONResponse struct{ ForbiddenJSONResponse }
+type ChangePassword409JSONResponse struct{ ConflictJSONResponse }
 
-func (response UpdateSecret403JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+func (response ChangePassword409JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ChangePassword500JSONResponse struct{ InternalJSONResponse }
+
+func (response ChangePassword500JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshUserTokenRequestObject struct {
+	Params RefreshUserTokenParams
+	Body   *RefreshUserTokenJSONRequestBody
+}
+
+type RefreshUserTokenResponseObject interface {
+	VisitRefreshUserTokenResponse(w http.ResponseWriter) error
+}
+
+type RefreshUserToken200JSONResponse AuthUserResponse
+
+func (response RefreshUserToken200JSONResponse) VisitRefreshUserTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshUserToken400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RefreshUserToken400JSONResponse) VisitRefreshUserTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshUserToken401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RefreshUserToken401JSONResponse) VisitRefreshUserTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefreshUserToken500JSONResponse struct{ InternalJSONResponse }
+
+func (response RefreshUserToken500JSONResponse) VisitRefreshUserTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersRequestObject struct {
+	Params ListUsersParams
+}
+
+type ListUsersResponseObject interface {
+	VisitListUsersResponse(w http.ResponseWriter) error
+}
+
+type ListUsers200JSONResponse ListUsersResponse
+
+func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListUsers401JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListUsers403JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSecret404JSONResponse struct{ NotFoundJSONResponse }
+type ListUsers500JSONResponse struct{ InternalJSONResponse }
 
-func (response UpdateSecret404JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+func (response ListUsers500JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUserRequestObject struct {
+	Params CreateUserParams
+	Body   *CreateUserJSONRequestBody
+}
+
+type CreateUserResponseObject interface {
+	VisitCreateUserResponse(w http.ResponseWriter) error
+}
+
+type CreateUser201JSONResponse UserResponse
+
+func (response CreateUser201JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateUser400JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateUser401JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateUser403JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateUser409JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateUser500JSONResponse struct{ InternalJSONResponse }
+
+func (response CreateUser500JSONResponse) VisitCreateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUserRequestObject struct {
+	Username Username `json:"username"`
+	Params   DeleteUserParams
+}
+
+type DeleteUserResponseObject interface {
+	VisitDeleteUserResponse(w http.ResponseWriter) error
+}
+
+type DeleteUser200JSONResponse struct{ OkResponseJSONResponse }
+
+func (response DeleteUser200JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteUser401JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteUser403JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUser404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteUser404JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateSecret500JSONResponse struct{ InternalJSONResponse }
+type DeleteUser409JSONResponse struct{ ConflictJSONResponse }
 
-func (response UpdateSecret500JSONResponse) VisitUpdateSecretResponse(w http.ResponseWriter) error {
+func (response DeleteUser409JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUser500JSONResponse struct{ InternalJSONResponse }
+
+func (response DeleteUser500JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRequestObject struct {
+	Username Username `json:"username"`
+	Params   GetUserParams
+}
+
+type GetUserResponseObject interface {
+	VisitGetUserResponse(w http.ResponseWriter) error
+}
+
+type GetUser200JSONResponse UserResponse
+
+func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetUser401JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetUser403JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser500JSONResponse struct{ InternalJSONResponse }
+
+func (response GetUser500JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUserRequestObject struct {
+	Username Username `json:"username"`
+	Params   UpdateUserParams
+	Body     *UpdateUserJSONRequestBody
+}
+
+type UpdateUserResponseObject interface {
+	VisitUpdateUserResponse(w http.ResponseWriter) error
+}
+
+type UpdateUser200JSONResponse UserResponse
+
+func (response UpdateUser200JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateUser400JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthUserRequestObject struct {
-	Params AuthUserParams
-	Body   *AuthUserJSONRequestBody
-}
+type UpdateUser401JSONResponse struct{ UnauthorizedJSONResponse }
 
-type AuthUserResponseObject interface {
-	VisitAuthUserResponse(w http.ResponseWriter) error
+func (response UpdateUser401JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthUser200JSONResponse AuthUserResponse
+type UpdateUser403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response AuthUser200JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+func (response UpdateUser403JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthUser400JSONResponse struct{ BadRequestJSONResponse }
+type UpdateUser404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response AuthUser400JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+func (response UpdateUser404JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthUser401JSONResponse struct{ UnauthorizedJSONResponse }
+type UpdateUser409JSONResponse struct{ ConflictJSONResponse }
 
-func (response AuthUser401JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+func (response UpdateUser409JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthUser500JSONResponse struct{ InternalJSONResponse }
+type UpdateUser500JSONResponse struct{ InternalJSONResponse }
 
-func (response AuthUser500JSONResponse) VisitAuthUserResponse(w http.ResponseWriter) error {
+func (response UpdateUser500JSONResponse) VisitUpdateUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -1304,6 +4541,39 @@ func (response AuthUser500JSONResponse) VisitAuthUserResponse(w http.ResponseWri
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Get the token signing keys
+	// (GET /.well-known/jwks.json)
+	GetJWKS(ctx context.Context, request GetJWKSRequestObject) (GetJWKSResponseObject, error)
+	// List API keys
+	// (GET /api-keys)
+	ListAPIKeys(ctx context.Context, request ListAPIKeysRequestObject) (ListAPIKeysResponseObject, error)
+	// Create an API key
+	// (POST /api-keys)
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error)
+	// Revoke an API key
+	// (DELETE /api-keys/{id})
+	DeleteAPIKey(ctx context.Context, request DeleteAPIKeyRequestObject) (DeleteAPIKeyResponseObject, error)
+	// List cluster secrets
+	// (GET /cluster-secrets)
+	ListClusterSecrets(ctx context.Context, request ListClusterSecretsRequestObject) (ListClusterSecretsResponseObject, error)
+	// Create a cluster secret
+	// (POST /cluster-secrets)
+	CreateClusterSecret(ctx context.Context, request CreateClusterSecretRequestObject) (CreateClusterSecretResponseObject, error)
+	// Delete cluster secret
+	// (DELETE /cluster-secrets/{name})
+	DeleteClusterSecret(ctx context.Context, request DeleteClusterSecretRequestObject) (DeleteClusterSecretResponseObject, error)
+	// Get cluster secret
+	// (GET /cluster-secrets/{name})
+	GetClusterSecret(ctx context.Context, request GetClusterSecretRequestObject) (GetClusterSecretResponseObject, error)
+	// Update cluster secret
+	// (PUT /cluster-secrets/{name})
+	UpdateClusterSecret(ctx context.Context, request UpdateClusterSecretRequestObject) (UpdateClusterSecretResponseObject, error)
+	// Reveal secret values
+	// (GET /cluster-secrets/{name}/reveal)
+	RevealClusterSecret(ctx context.Context, request RevealClusterSecretRequestObject) (RevealClusterSecretResponseObject, error)
+	// Get the sealing public key
+	// (GET /sealing-key)
+	GetSealingKey(ctx context.Context, request GetSealingKeyRequestObject) (GetSealingKeyResponseObject, error)
 	// List available secrets
 	// (GET /secrets)
 	ListSecrets(ctx context.Context, request ListSecretsRequestObject) (ListSecretsResponseObject, error)
@@ -1319,9 +4589,39 @@ type StrictServerInterface interface {
 	// Update secret
 	// (PUT /secrets/{name})
 	UpdateSecret(ctx context.Context, request UpdateSecretRequestObject) (UpdateSecretResponseObject, error)
+	// Reveal secret values
+	// (GET /secrets/{name}/reveal)
+	RevealSecret(ctx context.Context, request RevealSecretRequestObject) (RevealSecretResponseObject, error)
 	// Exchange Auth Data for JWT
 	// (POST /user/auth)
 	AuthUser(ctx context.Context, request AuthUserRequestObject) (AuthUserResponseObject, error)
+	// Revoke the current tokens
+	// (POST /user/logout)
+	LogoutUser(ctx context.Context, request LogoutUserRequestObject) (LogoutUserResponseObject, error)
+	// Get the OIDC login settings
+	// (GET /user/oidc)
+	GetOIDCConfig(ctx context.Context, request GetOIDCConfigRequestObject) (GetOIDCConfigResponseObject, error)
+	// Change the own password
+	// (PUT /user/password)
+	ChangePassword(ctx context.Context, request ChangePasswordRequestObject) (ChangePasswordResponseObject, error)
+	// Exchange a refresh token for new tokens
+	// (POST /user/refresh)
+	RefreshUserToken(ctx context.Context, request RefreshUserTokenRequestObject) (RefreshUserTokenResponseObject, error)
+	// List users
+	// (GET /users)
+	ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error)
+	// Create a user
+	// (POST /users)
+	CreateUser(ctx context.Context, request CreateUserRequestObject) (CreateUserResponseObject, error)
+	// Delete a user
+	// (DELETE /users/{username})
+	DeleteUser(ctx context.Context, request DeleteUserRequestObject) (DeleteUserResponseObject, error)
+	// Get a user
+	// (GET /users/{username})
+	GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error)
+	// Update a user
+	// (PUT /users/{username})
+	UpdateUser(ctx context.Context, request UpdateUserRequestObject) (UpdateUserResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -1332,25 +4632,337 @@ type StrictHTTPServerOptions struct {
 	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetJWKS operation middleware
+func (sh *strictHandler) GetJWKS(w http.ResponseWriter, r *http.Request, params GetJWKSParams) {
+	var request GetJWKSRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJWKS(ctx, request.(GetJWKSRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJWKS")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJWKSResponseObject); ok {
+		if err := validResponse.VisitGetJWKSResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAPIKeys operation middleware
+func (sh *strictHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request, params ListAPIKeysParams) {
+	var request ListAPIKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAPIKeys(ctx, request.(ListAPIKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAPIKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAPIKeysResponseObject); ok {
+		if err := validResponse.VisitListAPIKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAPIKey operation middleware
+func (sh *strictHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request, params CreateAPIKeyParams) {
+	var request CreateAPIKeyRequestObject
+
+	request.Params = params
+
+	var body CreateAPIKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAPIKey(ctx, request.(CreateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAPIKeyResponseObject); ok {
+		if err := validResponse.VisitCreateAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAPIKey operation middleware
+func (sh *strictHandler) DeleteAPIKey(w http.ResponseWriter, r *http.Request, id APIKeyID, params DeleteAPIKeyParams) {
+	var request DeleteAPIKeyRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAPIKey(ctx, request.(DeleteAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAPIKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteAPIKeyResponseObject); ok {
+		if err := validResponse.VisitDeleteAPIKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListClusterSecrets operation middleware
+func (sh *strictHandler) ListClusterSecrets(w http.ResponseWriter, r *http.Request, params ListClusterSecretsParams) {
+	var request ListClusterSecretsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListClusterSecrets(ctx, request.(ListClusterSecretsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListClusterSecrets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListClusterSecretsResponseObject); ok {
+		if err := validResponse.VisitListClusterSecretsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateClusterSecret operation middleware
+func (sh *strictHandler) CreateClusterSecret(w http.ResponseWriter, r *http.Request, params CreateClusterSecretParams) {
+	var request CreateClusterSecretRequestObject
+
+	request.Params = params
+
+	var body CreateClusterSecretJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateClusterSecret(ctx, request.(CreateClusterSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateClusterSecret")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateClusterSecretResponseObject); ok {
+		if err := validResponse.VisitCreateClusterSecretResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteClusterSecret operation middleware
+func (sh *strictHandler) DeleteClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params DeleteClusterSecretParams) {
+	var request DeleteClusterSecretRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteClusterSecret(ctx, request.(DeleteClusterSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteClusterSecret")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteClusterSecretResponseObject); ok {
+		if err := validResponse.VisitDeleteClusterSecretResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetClusterSecret operation middleware
+func (sh *strictHandler) GetClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params GetClusterSecretParams) {
+	var request GetClusterSecretRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClusterSecret(ctx, request.(GetClusterSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClusterSecret")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetClusterSecretResponseObject); ok {
+		if err := validResponse.VisitGetClusterSecretResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateClusterSecret operation middleware
+func (sh *strictHandler) UpdateClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params UpdateClusterSecretParams) {
+	var request UpdateClusterSecretRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	var body UpdateClusterSecretJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateClusterSecret(ctx, request.(UpdateClusterSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateClusterSecret")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateClusterSecretResponseObject); ok {
+		if err := validResponse.VisitUpdateClusterSecretResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevealClusterSecret operation middleware
+func (sh *strictHandler) RevealClusterSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params RevealClusterSecretParams) {
+	var request RevealClusterSecretRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevealClusterSecret(ctx, request.(RevealClusterSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevealClusterSecret")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevealClusterSecretResponseObject); ok {
+		if err := validResponse.VisitRevealClusterSecretResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSealingKey operation middleware
+func (sh *strictHandler) GetSealingKey(w http.ResponseWriter, r *http.Request, params GetSealingKeyParams) {
+	var request GetSealingKeyRequestObject
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	request.Params = params
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSealingKey(ctx, request.(GetSealingKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSealingKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSealingKeyResponseObject); ok {
+		if err := validResponse.VisitGetSealingKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
 // ListSecrets operation middleware
@@ -1500,6 +5112,33 @@ func (sh *strictHandler) UpdateSecret(w http.ResponseWriter, r *http.Request, na
 	}
 }
 
+// RevealSecret operation middleware
+func (sh *strictHandler) RevealSecret(w http.ResponseWriter, r *http.Request, name ResourceName, params RevealSecretParams) {
+	var request RevealSecretRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevealSecret(ctx, request.(RevealSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevealSecret")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevealSecretResponseObject); ok {
+		if err := validResponse.VisitRevealSecretResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // AuthUser operation middleware
 func (sh *strictHandler) AuthUser(w http.ResponseWriter, r *http.Request, params AuthUserParams) {
 	var request AuthUserRequestObject
@@ -1532,3 +5171,275 @@ func (sh *strictHandler) AuthUser(w http.ResponseWriter, r *http.Request, params
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// LogoutUser operation middleware
+func (sh *strictHandler) LogoutUser(w http.ResponseWriter, r *http.Request, params LogoutUserParams) {
+	var request LogoutUserRequestObject
+
+	request.Params = params
+
+	var body LogoutUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.LogoutUser(ctx, request.(LogoutUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "LogoutUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LogoutUserResponseObject); ok {
+		if err := validResponse.VisitLogoutUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOIDCConfig operation middleware
+func (sh *strictHandler) GetOIDCConfig(w http.ResponseWriter, r *http.Request, params GetOIDCConfigParams) {
+	var request GetOIDCConfigRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOIDCConfig(ctx, request.(GetOIDCConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOIDCConfig")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOIDCConfigResponseObject); ok {
+		if err := validResponse.VisitGetOIDCConfigResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ChangePassword operation middleware
+func (sh *strictHandler) ChangePassword(w http.ResponseWriter, r *http.Request, params ChangePasswordParams) {
+	var request ChangePasswordRequestObject
+
+	request.Params = params
+
+	var body ChangePasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ChangePassword(ctx, request.(ChangePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ChangePassword")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ChangePasswordResponseObject); ok {
+		if err := validResponse.VisitChangePasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RefreshUserToken operation middleware
+func (sh *strictHandler) RefreshUserToken(w http.ResponseWriter, r *http.Request, params RefreshUserTokenParams) {
+	var request RefreshUserTokenRequestObject
+
+	request.Params = params
+
+	var body RefreshUserTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshUserToken(ctx, request.(RefreshUserTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshUserToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RefreshUserTokenResponseObject); ok {
+		if err := validResponse.VisitRefreshUserTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUsers operation middleware
+func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	var request ListUsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
+		if err := validResponse.VisitListUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateUser operation middleware
+func (sh *strictHandler) CreateUser(w http.ResponseWriter, r *http.Request, params CreateUserParams) {
+	var request CreateUserRequestObject
+
+	request.Params = params
+
+	var body CreateUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateUser(ctx, request.(CreateUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateUserResponseObject); ok {
+		if err := validResponse.VisitCreateUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUser operation middleware
+func (sh *strictHandler) DeleteUser(w http.ResponseWriter, r *http.Request, username Username, params DeleteUserParams) {
+	var request DeleteUserRequestObject
+
+	request.Username = username
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUser(ctx, request.(DeleteUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteUserResponseObject); ok {
+		if err := validResponse.VisitDeleteUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUser operation middleware
+func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, username Username, params GetUserParams) {
+	var request GetUserRequestObject
+
+	request.Username = username
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserResponseObject); ok {
+		if err := validResponse.VisitGetUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateUser operation middleware
+func (sh *strictHandler) UpdateUser(w http.ResponseWriter, r *http.Request, username Username, params UpdateUserParams) {
+	var request UpdateUserRequestObject
+
+	request.Username = username
+	request.Params = params
+
+	var body UpdateUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateUser(ctx, request.(UpdateUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateUserResponseObject); ok {
+		if err := validResponse.VisitUpdateUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
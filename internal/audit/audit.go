@@ -0,0 +1,88 @@
+// Package audit records access to sensitive data through the API. Events are
+// written as structured log records with the "audit" message and log_type, so
+// they can be routed apart from the request log.
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ActionRevealSecret is recorded when plaintext Secret values are requested.
+const ActionRevealSecret = "secret.reveal"
+
+// ActionCreateAPIKey and ActionDeleteAPIKey are recorded when API keys are
+// issued and revoked.
+const (
+	ActionCreateAPIKey = "apikey.create"
+	ActionDeleteAPIKey = "apikey.delete"
+)
+
+// User actions are recorded when users are managed through the API and when
+// users change their own password.
+const (
+	ActionCreateUser     = "user.create"
+	ActionUpdateUser     = "user.update"
+	ActionDeleteUser     = "user.delete"
+	ActionChangePassword = "user.password"
+)
+
+// Event describes one audited request.
+type Event struct {
+	Action string
+	// Actor is the username of the caller, Role its role.
+	Actor string
+	Role  string
+	// Namespace is empty for cluster-scoped resources.
+	Namespace string
+	Name      string
+	// Keys are the data keys whose values were returned.
+	Keys    []string
+	Allowed bool
+	// Reason explains a denied request.
+	Reason string
+}
+
+// Logger writes audit events.
+type Logger struct {
+	logger *slog.Logger
+}
+
+// New returns a Logger writing to logger, or to the default logger if nil.
+func New(logger *slog.Logger) *Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Logger{logger: logger.With(slog.String("log_type", "audit"))}
+}
+
+// Record writes event with the request ID of ctx. A nil Logger drops events.
+func (l *Logger) Record(ctx context.Context, event Event) {
+	if l == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("action", event.Action),
+		slog.String("actor", event.Actor),
+		slog.String("role", event.Role),
+		slog.String("name", event.Name),
+		slog.Bool("allowed", event.Allowed),
+	}
+	if event.Namespace != "" {
+		attrs = append(attrs, slog.String("namespace", event.Namespace))
+	}
+	if len(event.Keys) > 0 {
+		attrs = append(attrs, slog.Any("keys", event.Keys))
+	}
+	if event.Reason != "" {
+		attrs = append(attrs, slog.String("reason", event.Reason))
+	}
+	if reqID := middleware.GetReqID(ctx); reqID != "" {
+		attrs = append(attrs, slog.String("request_id", reqID))
+	}
+
+	l.logger.LogAttrs(ctx, slog.LevelInfo, "audit", slog.Attr{Key: "audit", Value: slog.GroupValue(attrs...)})
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func TestRecord(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+	logger.Record(ctx, Event{
+		Action:    ActionRevealSecret,
+		Actor:     "alice",
+		Role:      "admin",
+		Namespace: "team-a",
+		Name:      "db",
+		Keys:      []string{"password"},
+		Allowed:   true,
+	})
+
+	var record struct {
+		Msg     string `json:"msg"`
+		LogType string `json:"log_type"`
+		Audit   struct {
+			Action    string   `json:"action"`
+			Actor     string   `json:"actor"`
+			Namespace string   `json:"namespace"`
+			Keys      []string `json:"keys"`
+			Allowed   bool     `json:"allowed"`
+			RequestID string   `json:"request_id"`
+		} `json:"audit"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("audit record is not JSON: %v", err)
+	}
+	if record.Msg != "audit" || record.LogType != "audit" {
+		t.Errorf("msg = %q, log_type = %q, want audit", record.Msg, record.LogType)
+	}
+	if record.Audit.Action != ActionRevealSecret || record.Audit.Actor != "alice" || record.Audit.Namespace != "team-a" ||
+		!record.Audit.Allowed || len(record.Audit.Keys) != 1 || record.Audit.RequestID != "req-1" {
+		t.Errorf("unexpected audit record: %s", buf.String())
+	}
+
+	var nilLogger *Logger
+	nilLogger.Record(ctx, Event{Action: ActionRevealSecret})
+}
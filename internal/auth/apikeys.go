@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, so that they can be told apart from JWTs
+// and found by secret scanners.
+const APIKeyPrefix = "ksm_"
+
+// ErrAPIKeyNotFound is returned by an APIKeyStore for unknown key IDs.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKey is a long-lived credential for automation. Only the SHA-256 hash of
+// the key is stored.
+type APIKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+	Role string `json:"role"`
+	// Namespaces are the allowed namespaces of the key.
+	Namespaces []string `json:"namespaces"`
+	// Verbs restrict the key to these verbs; empty allows every verb the role
+	// grants.
+	Verbs     []string   `json:"verbs,omitempty"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// APIKeyStore keeps API keys by ID.
+type APIKeyStore interface {
+	Create(ctx context.Context, key APIKey) error
+	// Get returns ErrAPIKeyNotFound for unknown IDs.
+	Get(ctx context.Context, id string) (*APIKey, error)
+	List(ctx context.Context) ([]APIKey, error)
+	// Delete returns ErrAPIKeyNotFound for unknown IDs.
+	Delete(ctx context.Context, id string) error
+}
+
+// NewAPIKey generates a key and returns it together with the record to
+// store. The key itself is not kept anywhere.
+func NewAPIKey() (string, APIKey, error) {
+	idBytes := make([]byte, 8)
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", APIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", APIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	id := hex.EncodeToString(idBytes)
+	key := APIKeyPrefix + id + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+	return key, APIKey{ID: id, Hash: hashAPIKey(key)}, nil
+}
+
+// IsAPIKey reports whether token looks like an API key.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// AuthenticateAPIKey looks up key in store and maps it to Claims. Unknown,
+// mismatching and expired keys fail with ErrUnauthorizedToken.
+func AuthenticateAPIKey(ctx context.Context, store APIKeyStore, key string) (*Claims, error) {
+	id, _, ok := strings.Cut(strings.TrimPrefix(key, APIKeyPrefix), "_")
+	if !IsAPIKey(key) || !ok || id == "" {
+		return nil, fmt.Errorf("%w: malformed api key", ErrUnauthorizedToken)
+	}
+	stored, err := store.Get(ctx, id)
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return nil, fmt.Errorf("%w: unknown api key", ErrUnauthorizedToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(stored.Hash)) != 1 {
+		return nil, fmt.Errorf("%w: unknown api key", ErrUnauthorizedToken)
+	}
+	if stored.ExpiresAt != nil && !time.Now().Before(*stored.ExpiresAt) {
+		return nil, fmt.Errorf("%w: api key expired", ErrUnauthorizedToken)
+	}
+
+	return &Claims{
+		Username:          "apikey:" + stored.Name,
+		Role:              stored.Role,
+		AllowedNamespaces: stored.Namespaces,
+		Verbs:             stored.Verbs,
+		APIKey:            stored.Name,
+	}, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ APIKeyStore = &MemoryAPIKeyStore{}
+
+// MemoryAPIKeyStore keeps API keys in the process. They are lost on restart
+// and not shared between replicas.
+type MemoryAPIKeyStore struct {
+	mu   sync.Mutex
+	keys map[string]APIKey
+}
+
+func NewMemoryAPIKeyStore() *MemoryAPIKeyStore {
+	return &MemoryAPIKeyStore{keys: make(map[string]APIKey)}
+}
+
+func (s *MemoryAPIKeyStore) Create(_ context.Context, key APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[key.ID]; ok {
+		return fmt.Errorf("api key %s already exists", key.ID)
+	}
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *MemoryAPIKeyStore) Get(_ context.Context, id string) (*APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+func (s *MemoryAPIKeyStore) List(_ context.Context) ([]APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b APIKey) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return keys, nil
+}
+
+func (s *MemoryAPIKeyStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[id]; !ok {
+		return ErrAPIKeyNotFound
+	}
+	delete(s.keys, id)
+	return nil
+}
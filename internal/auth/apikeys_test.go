@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryAPIKeyStore()
+
+	raw, key, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	if !IsAPIKey(raw) || strings.Contains(key.Hash, raw) {
+		t.Fatalf("unexpected key %q with hash %q", raw, key.Hash)
+	}
+	key.Name, key.Role, key.Namespaces, key.Verbs = "ci", "operator", []string{"staging"}, []string{"get"}
+	if err := store.Create(ctx, key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	claims, err := AuthenticateAPIKey(ctx, store, raw)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIKey: %v", err)
+	}
+	if claims.Username != "apikey:ci" || claims.Role != "operator" || claims.AllowedNamespaces[0] != "staging" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if !claims.AllowsVerb("get") || claims.AllowsVerb("delete") {
+		t.Errorf("expected the key to be limited to get, got %v", claims.Verbs)
+	}
+
+	for name, token := range map[string]string{
+		"wrong secret": raw[:len(raw)-4] + "AAAA",
+		"unknown id":   APIKeyPrefix + "0000000000000000_secret",
+		"malformed":    APIKeyPrefix + "nosecret",
+	} {
+		if _, err := AuthenticateAPIKey(ctx, store, token); !errors.Is(err, ErrUnauthorizedToken) {
+			t.Errorf("%s: expected ErrUnauthorizedToken, got %v", name, err)
+		}
+	}
+
+	expired := time.Now().Add(-time.Minute)
+	key.ExpiresAt = &expired
+	if err := store.Delete(ctx, key.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := AuthenticateAPIKey(ctx, store, raw); !errors.Is(err, ErrUnauthorizedToken) {
+		t.Errorf("expected a deleted key to be rejected, got %v", err)
+	}
+	if err := store.Create(ctx, key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := AuthenticateAPIKey(ctx, store, raw); !errors.Is(err, ErrUnauthorizedToken) {
+		t.Errorf("expected an expired key to be rejected, got %v", err)
+	}
+	if err := store.Delete(ctx, "missing"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package auth
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"slices"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 func IsNamespaceAllowed(requestedNamespace string, allowedNamespaces []string) bool {
 	for _, allowed := range allowedNamespaces {
@@ -18,6 +22,22 @@ func IsNamespaceAllowed(requestedNamespace string, allowedNamespaces []string) b
 	return false
 }
 
+// PermissionRevealSecrets allows reading the plaintext values of Secrets, which
+// are masked in every other response.
+const PermissionRevealSecrets = "secrets:reveal"
+
+// HasPermission reports whether the token grants permission. Permissions are
+// granted per user in the config, independent of the role.
+func (c *Claims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+// AllowsVerb reports whether the verb restriction of an API key, if any,
+// allows verb.
+func (c *Claims) AllowsVerb(verb string) bool {
+	return len(c.Verbs) == 0 || slices.Contains(c.Verbs, verb)
+}
+
 func HashPassword(password string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
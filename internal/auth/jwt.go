@@ -2,19 +2,16 @@ package auth
 
 import (
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
 )
 
 var ErrUnauthorizedToken = errors.New("authentication failed: token is invalid or expired")
 
-func GenerateJWT(user *cfg.User, expiresIn int64, JWTSecret string) (string, error) {
-	if JWTSecret == "" {
-		return "", fmt.Errorf("jwt secret is empty")
-	}
+func GenerateJWT(user *cfg.User, expiresIn int64, keys *KeySet) (string, error) {
 	now := time.Now()
 	expirationTime := time.Now().Add(time.Duration(expiresIn) * time.Second)
 
@@ -22,14 +19,46 @@ func GenerateJWT(user *cfg.User, expiresIn int64, JWTSecret string) (string, err
 		Username:          user.Username,
 		Role:              user.Role,
 		AllowedNamespaces: user.AllowedNamespaces,
+		Permissions:       user.Permissions,
+		KubernetesUser:    user.KubernetesUser,
+		KubernetesGroups:  user.KubernetesGroups,
 
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.ID,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(JWTSecret))
+	return keys.Sign(claims)
+}
+
+// GenerateRefreshToken issues a token that can only be exchanged at
+// /user/refresh. It carries no role or namespaces: those are read from the
+// config again on every refresh.
+func GenerateRefreshToken(user *cfg.User, ttl time.Duration, keys *KeySet) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Username: user.Username,
+		TokenUse: TokenUseRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   user.ID,
+		},
+	}
+
+	return keys.Sign(claims)
+}
+
+// ParseRefreshToken validates a token issued by GenerateRefreshToken.
+func ParseRefreshToken(tokenString string, keys *KeySet) (*Claims, error) {
+	claims := &Claims{}
+	err := keys.Parse(tokenString, claims, jwt.WithExpirationRequired())
+	if err != nil || claims.TokenUse != TokenUseRefresh || claims.ID == "" {
+		return nil, ErrUnauthorizedToken
+	}
+	return claims, nil
 }
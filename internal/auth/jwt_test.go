@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+func TestRefreshToken(t *testing.T) {
+	user := &cfg.User{ID: "1", Username: "alice", Role: "admin"}
+	keys := NewHMACKeySet("secret")
+
+	refresh, err := GenerateRefreshToken(user, time.Hour, keys)
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	claims, err := ParseRefreshToken(refresh, keys)
+	if err != nil {
+		t.Fatalf("ParseRefreshToken: %v", err)
+	}
+	if claims.Username != "alice" || claims.Subject != "1" || claims.ID == "" || claims.Role != "" {
+		t.Errorf("unexpected refresh claims %+v", claims)
+	}
+
+	if _, err := ParseRefreshToken(refresh, NewHMACKeySet("other")); !errors.Is(err, ErrUnauthorizedToken) {
+		t.Errorf("expected a wrong secret to be rejected, got %v", err)
+	}
+	access, err := GenerateJWT(user, 60, keys)
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+	if _, err := ParseRefreshToken(access, keys); !errors.Is(err, ErrUnauthorizedToken) {
+		t.Errorf("expected an access token to be rejected, got %v", err)
+	}
+	expired, err := GenerateRefreshToken(user, -time.Minute, keys)
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	if _, err := ParseRefreshToken(expired, keys); !errors.Is(err, ErrUnauthorizedToken) {
+		t.Errorf("expected an expired token to be rejected, got %v", err)
+	}
+}
+
+func TestMemoryRevocationStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryRevocationStore()
+	s.now = func() time.Time { return now }
+
+	if err := s.Revoke(ctx, "a", now.Add(time.Minute)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if revoked, _ := s.IsRevoked(ctx, "a"); !revoked {
+		t.Error("expected a to be revoked")
+	}
+	if revoked, _ := s.IsRevoked(ctx, "b"); revoked {
+		t.Error("expected b not to be revoked")
+	}
+	if err := s.Revoke(ctx, "a", now.Add(time.Minute)); !errors.Is(err, ErrAlreadyRevoked) {
+		t.Errorf("expected ErrAlreadyRevoked for a revoked token, got %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := s.Revoke(ctx, "b", now.Add(time.Minute)); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if len(s.revoked) != 1 {
+		t.Errorf("expected expired entries to be dropped, got %v", s.revoked)
+	}
+}
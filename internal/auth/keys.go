@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+// signingKey is one configured private key. A zero retiredAt means the key has
+// not been retired.
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	private   crypto.Signer
+	retiredAt time.Time
+}
+
+// KeySet signs and verifies the tokens the API server issues. It either holds
+// asymmetric keys identified by kid, of which one is active, or a single HMAC
+// secret.
+type KeySet struct {
+	secret []byte
+	active *signingKey
+	keys   map[string]*signingKey
+	grace  time.Duration
+	now    func() time.Time
+}
+
+// NewHMACKeySet signs and verifies with HS256. Signing fails when secret is
+// empty.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{secret: []byte(secret), now: time.Now}
+}
+
+// LoadKeySet reads the configured signing keys, or falls back to the HMAC
+// secret when there are none.
+func LoadKeySet(config cfg.JWTConfig) (*KeySet, error) {
+	if len(config.Keys) == 0 {
+		if config.Secret == "" {
+			return nil, fmt.Errorf("jwt secret is empty and no signing keys are configured")
+		}
+		return NewHMACKeySet(config.Secret), nil
+	}
+
+	grace := config.RetiredKeyGrace
+	if grace <= 0 {
+		grace = config.RefreshTTL()
+	}
+	ks := &KeySet{keys: make(map[string]*signingKey, len(config.Keys)), grace: grace, now: time.Now}
+	for _, kc := range config.Keys {
+		if kc.ID == "" {
+			return nil, fmt.Errorf("signing key %s has no id", kc.File)
+		}
+		if _, ok := ks.keys[kc.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id %q", kc.ID)
+		}
+		data, err := os.ReadFile(kc.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key %q: %w", kc.ID, err)
+		}
+		key, err := parseSigningKey(kc.ID, data)
+		if err != nil {
+			return nil, err
+		}
+		if kc.RetiredAt != nil {
+			key.retiredAt = *kc.RetiredAt
+		}
+		ks.keys[kc.ID] = key
+	}
+
+	active, ok := ks.keys[config.ActiveKey]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q is not configured", config.ActiveKey)
+	}
+	if !active.retiredAt.IsZero() {
+		return nil, fmt.Errorf("active signing key %q is retired", config.ActiveKey)
+	}
+	ks.active = active
+	return ks, nil
+}
+
+func parseSigningKey(id string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %q is not PEM encoded", id)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %q: %w", id, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("signing key %q: RSA keys must have at least 2048 bits", id)
+		}
+		return &signingKey{id: id, method: jwt.SigningMethodRS256, private: k}, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("signing key %q: only P-256 EC keys are supported", id)
+		}
+		return &signingKey{id: id, method: jwt.SigningMethodES256, private: k}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{id: id, method: jwt.SigningMethodEdDSA, private: k}, nil
+	default:
+		return nil, fmt.Errorf("signing key %q has unsupported type %T", id, parsed)
+	}
+}
+
+// Sign signs claims with the active key, or the HMAC secret.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.active == nil {
+		if len(ks.secret) == 0 {
+			return "", fmt.Errorf("jwt secret is empty")
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+	token := jwt.NewWithClaims(ks.active.method, claims)
+	token.Header["kid"] = ks.active.id
+	return token.SignedString(ks.active.private)
+}
+
+// Parse verifies tokenString into claims. Tokens signed by a retired key are
+// accepted until its grace period ends.
+func (ks *KeySet) Parse(tokenString string, claims jwt.Claims, opts ...jwt.ParserOption) error {
+	methods := []string{jwt.SigningMethodHS256.Alg()}
+	if ks.active != nil {
+		methods = []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+	}
+	opts = append(opts, jwt.WithValidMethods(methods))
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, ks.verificationKey, opts...)
+	return err
+}
+
+func (ks *KeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	if ks.active == nil {
+		if len(ks.secret) == 0 {
+			return nil, fmt.Errorf("jwt secret is empty")
+		}
+		return ks.secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok || !ks.usable(key) {
+		return nil, fmt.Errorf("unknown or expired signing key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("signing key %q does not sign %s", kid, token.Method.Alg())
+	}
+	return key.private.Public(), nil
+}
+
+func (ks *KeySet) usable(key *signingKey) bool {
+	return key.retiredAt.IsZero() || ks.now().Before(key.retiredAt.Add(ks.grace))
+}
+
+// JWKS returns the public keys that verify tokens, or nil for an HMAC key set
+// whose secret cannot be published.
+func (ks *KeySet) JWKS() (*JWKS, error) {
+	if ks.active == nil {
+		return nil, nil
+	}
+	set := &JWKS{Keys: []JWK{}}
+	for _, key := range ks.keys {
+		if !ks.usable(key) {
+			continue
+		}
+		jwk, err := NewJWK(key.id, key.method.Alg(), key.private.Public())
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	slices.SortFunc(set.Keys, func(a, b JWK) int { return strings.Compare(a.Kid, b.Kid) })
+	return set, nil
+}
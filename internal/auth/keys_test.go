@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+func writeKeyFile(t *testing.T, dir, name string, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	path := filepath.Join(dir, name+".pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return path
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	oldFile := writeKeyFile(t, dir, "old", newRSAKey(t))
+	newFile := writeKeyFile(t, dir, "new", edKey)
+
+	user := &cfg.User{ID: "1", Username: "alice", Role: "admin"}
+	oldKeys, err := LoadKeySet(cfg.JWTConfig{
+		Keys:      []cfg.SigningKeyConfig{{ID: "old", File: oldFile}},
+		ActiveKey: "old",
+	})
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	oldToken, err := GenerateJWT(user, 3600, oldKeys)
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+
+	// The new key becomes active; the old one keeps verifying for a day.
+	retiredAt := time.Now()
+	keys, err := LoadKeySet(cfg.JWTConfig{
+		Keys: []cfg.SigningKeyConfig{
+			{ID: "new", File: newFile},
+			{ID: "old", File: oldFile, RetiredAt: &retiredAt},
+		},
+		ActiveKey:       "new",
+		RetiredKeyGrace: 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+	newToken, err := GenerateJWT(user, 3600, keys)
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+	header, _, _ := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	if header.Header["kid"] != "new" || header.Method.Alg() != "EdDSA" {
+		t.Errorf("expected an EdDSA token with kid new, got %v", header.Header)
+	}
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if err := keys.Parse(token, &Claims{}); err != nil {
+			t.Errorf("expected the %s token to verify: %v", name, err)
+		}
+	}
+
+	set, err := keys.JWKS()
+	if err != nil {
+		t.Fatalf("JWKS: %v", err)
+	}
+	if len(set.Keys) != 2 || set.Keys[0].Kid != "new" || set.Keys[0].Kty != "OKP" || set.Keys[1].Kty != "RSA" {
+		t.Errorf("unexpected JWKS %+v", set.Keys)
+	}
+	// The published keys verify the tokens, as another service would.
+	data, _ := json.Marshal(set)
+	published, err := ParseJWKS(data)
+	if err != nil {
+		t.Fatalf("ParseJWKS: %v", err)
+	}
+	if _, err := jwt.Parse(newToken, func(token *jwt.Token) (interface{}, error) {
+		return published[token.Header["kid"].(string)], nil
+	}); err != nil {
+		t.Errorf("expected the published key to verify: %v", err)
+	}
+
+	// After the grace period the old key is gone.
+	keys.now = func() time.Time { return retiredAt.Add(25 * time.Hour) }
+	if err := keys.Parse(oldToken, &Claims{}, jwt.WithoutClaimsValidation()); err == nil {
+		t.Error("expected the retired key to be rejected after the grace period")
+	}
+	if set, _ := keys.JWKS(); len(set.Keys) != 1 {
+		t.Errorf("expected the retired key to be unpublished, got %+v", set.Keys)
+	}
+
+	// HMAC tokens are not accepted once keys are configured.
+	hmacToken, err := GenerateJWT(user, 3600, NewHMACKeySet("secret"))
+	if err != nil {
+		t.Fatalf("GenerateJWT: %v", err)
+	}
+	if err := keys.Parse(hmacToken, &Claims{}); err == nil {
+		t.Error("expected an HS256 token to be rejected")
+	}
+}
+
+func TestLoadKeySet_Errors(t *testing.T) {
+	dir := t.TempDir()
+	file := writeKeyFile(t, dir, "k", newRSAKey(t))
+	retired := time.Now()
+
+	for name, config := range map[string]cfg.JWTConfig{
+		"nothing":        {},
+		"unknown active": {Keys: []cfg.SigningKeyConfig{{ID: "k", File: file}}, ActiveKey: "other"},
+		"retired active": {Keys: []cfg.SigningKeyConfig{{ID: "k", File: file, RetiredAt: &retired}}, ActiveKey: "k"},
+		"duplicate id":   {Keys: []cfg.SigningKeyConfig{{ID: "k", File: file}, {ID: "k", File: file}}, ActiveKey: "k"},
+		"missing file":   {Keys: []cfg.SigningKeyConfig{{ID: "k", File: filepath.Join(dir, "missing.pem")}}, ActiveKey: "k"},
+	} {
+		if _, err := LoadKeySet(config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
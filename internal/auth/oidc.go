@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+// jwksRefreshInterval limits how often an unknown key ID refetches the JWKS.
+const jwksRefreshInterval = time.Minute
+
+// OIDCDiscovery is the part of an OpenID Provider configuration the API server
+// and the CLI use.
+type OIDCDiscovery struct {
+	Issuer                      string `json:"issuer"`
+	JWKSURI                     string `json:"jwks_uri"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// DiscoverOIDC fetches the discovery document of issuer.
+func DiscoverOIDC(ctx context.Context, client *http.Client, issuer string) (*OIDCDiscovery, error) {
+	body, err := httpGet(ctx, client, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	var discovery OIDCDiscovery
+	if err := json.Unmarshal(body, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to parse OIDC discovery document: %w", err)
+	}
+	if discovery.Issuer != issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q, not %q", discovery.Issuer, issuer)
+	}
+	return &discovery, nil
+}
+
+var _ TokenVerifier = &OIDCVerifier{}
+
+// OIDCVerifier validates ID tokens of the configured issuer and maps their
+// claims to API Claims.
+type OIDCVerifier struct {
+	config  cfg.OIDCConfig
+	jwksURL string
+	client  *http.Client
+	now     func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	lastFetch time.Time
+}
+
+// NewOIDCVerifier loads the signing keys of the issuer, from the JWKS file if
+// one is configured.
+func NewOIDCVerifier(ctx context.Context, config cfg.OIDCConfig, client *http.Client) (*OIDCVerifier, error) {
+	if config.Issuer == "" || config.ClientID == "" {
+		return nil, fmt.Errorf("oidc issuer and client_id are required")
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "email"
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	v := &OIDCVerifier{config: config, client: client, now: time.Now}
+
+	if config.JWKSFile != "" {
+		data, err := os.ReadFile(config.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		keys, err := ParseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		v.keys = keys
+		return v, nil
+	}
+
+	v.jwksURL = config.JWKSURL
+	if v.jwksURL == "" {
+		discovery, err := DiscoverOIDC(ctx, client, config.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		v.jwksURL = discovery.JWKSURI
+	}
+	if err := v.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Issuer returns the configured issuer.
+func (v *OIDCVerifier) Issuer() string {
+	return v.config.Issuer
+}
+
+// Handles reports whether tokenString claims to be issued by the configured
+// issuer. The signature is not checked.
+func (v *OIDCVerifier) Handles(tokenString string) bool {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, &claims); err != nil {
+		return false
+	}
+	return claims.Issuer == v.config.Issuer
+}
+
+// Verify validates an ID token and maps it to Claims. Tokens without a
+// username or a role are rejected.
+func (v *OIDCVerifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	idClaims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, idClaims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return v.key(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithIssuer(v.config.Issuer),
+		jwt.WithAudience(v.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(v.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorizedToken, err)
+	}
+
+	username := firstClaimValue(idClaims, v.config.UsernameClaim)
+	if username == "" {
+		return nil, fmt.Errorf("%w: claim %s is missing", ErrUnauthorizedToken, v.config.UsernameClaim)
+	}
+	role := v.role(idClaims)
+	if role == "" {
+		return nil, fmt.Errorf("%w: no role mapped for %s", ErrUnauthorizedToken, username)
+	}
+	groups := claimValues(idClaims, v.config.GroupsClaim)
+
+	var namespaces []string
+	for _, group := range groups {
+		namespaces = append(namespaces, v.config.GroupNamespaces[group]...)
+	}
+	slices.Sort(namespaces)
+
+	subject, _ := idClaims.GetSubject()
+	expiresAt, _ := idClaims.GetExpirationTime()
+	return &Claims{
+		Username:          username,
+		Role:              role,
+		AllowedNamespaces: slices.Compact(namespaces),
+		KubernetesUser:    username,
+		KubernetesGroups:  groups,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    v.config.Issuer,
+			Subject:   subject,
+			ExpiresAt: expiresAt,
+		},
+	}, nil
+}
+
+func (v *OIDCVerifier) role(idClaims jwt.MapClaims) string {
+	for _, mapping := range v.config.RoleMappings {
+		if slices.Contains(claimValues(idClaims, mapping.Claim), mapping.Value) {
+			return mapping.Role
+		}
+	}
+	return v.config.DefaultRole
+}
+
+// key returns the key for kid, refetching the JWKS once per interval when the
+// key is unknown so that rotated keys are picked up.
+func (v *OIDCVerifier) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := v.now().Sub(v.lastFetch) >= jwksRefreshInterval
+	v.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if v.jwksURL == "" || !stale {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if err := v.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+func (v *OIDCVerifier) refreshKeys(ctx context.Context) error {
+	body, err := httpGet(ctx, v.client, v.jwksURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	keys, err := ParseJWKS(body)
+	if err != nil {
+		return err
+	}
+	v.mu.Lock()
+	v.keys = keys
+	v.lastFetch = v.now()
+	v.mu.Unlock()
+	return nil
+}
+
+// claimValues returns a string claim or the strings of a list claim.
+func claimValues(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func firstClaimValue(claims jwt.MapClaims, name string) string {
+	if values := claimValues(claims, name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func httpGet(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+func rsaJWK(t *testing.T, kid string, key *rsa.PrivateKey) JWK {
+	t.Helper()
+	return JWK{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		Alg: "RS256",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func signIDToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func idTokenClaims(issuer string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    issuer,
+		"aud":    "ksec",
+		"sub":    "0001",
+		"email":  "alice@example.com",
+		"groups": []string{"team-a", "ops"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func oidcTestConfig(issuer string) cfg.OIDCConfig {
+	return cfg.OIDCConfig{
+		Issuer:   issuer,
+		ClientID: "ksec",
+		RoleMappings: []cfg.OIDCRoleMapping{
+			{Claim: "groups", Value: "ops", Role: "operator"},
+		},
+		DefaultRole: "developer",
+		GroupNamespaces: map[string][]string{
+			"team-a": {"team-a", "shared"},
+			"ops":    {"shared", "monitoring"},
+		},
+	}
+}
+
+func TestOIDCVerifier_JWKSFile(t *testing.T) {
+	key := newRSAKey(t)
+	data, err := json.Marshal(JWKS{Keys: []JWK{rsaJWK(t, "k1", key)}})
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksFile, data, 0600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	config := oidcTestConfig("https://idp.example.com")
+	config.JWKSFile = jwksFile
+	v, err := NewOIDCVerifier(context.Background(), config, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewOIDCVerifier: %v", err)
+	}
+
+	token := signIDToken(t, "k1", key, idTokenClaims("https://idp.example.com"))
+	if !v.Handles(token) {
+		t.Fatal("expected the verifier to handle a token of its issuer")
+	}
+	claims, err := v.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.Username != "alice@example.com" || claims.Role != "operator" {
+		t.Errorf("unexpected identity %s/%s", claims.Username, claims.Role)
+	}
+	if got := claims.AllowedNamespaces; len(got) != 3 || got[0] != "monitoring" || got[1] != "shared" || got[2] != "team-a" {
+		t.Errorf("unexpected namespaces %v", got)
+	}
+	if claims.KubernetesUser != "alice@example.com" || len(claims.KubernetesGroups) != 2 {
+		t.Errorf("unexpected kubernetes identity %s %v", claims.KubernetesUser, claims.KubernetesGroups)
+	}
+
+	t.Run("default role", func(t *testing.T) {
+		c := idTokenClaims("https://idp.example.com")
+		c["groups"] = []string{"team-a"}
+		claims, err := v.Verify(context.Background(), signIDToken(t, "k1", key, c))
+		if err != nil {
+			t.Fatalf("Verify: %v", err)
+		}
+		if claims.Role != "developer" {
+			t.Errorf("expected the default role, got %s", claims.Role)
+		}
+	})
+
+	for name, mutate := range map[string]func(jwt.MapClaims){
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "other" },
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"no username":    func(c jwt.MapClaims) { delete(c, "email") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := idTokenClaims("https://idp.example.com")
+			mutate(c)
+			_, err := v.Verify(context.Background(), signIDToken(t, "k1", key, c))
+			if !errors.Is(err, ErrUnauthorizedToken) {
+				t.Errorf("expected ErrUnauthorizedToken, got %v", err)
+			}
+		})
+	}
+
+	t.Run("no role", func(t *testing.T) {
+		config := config
+		config.DefaultRole = ""
+		v, err := NewOIDCVerifier(context.Background(), config, http.DefaultClient)
+		if err != nil {
+			t.Fatalf("NewOIDCVerifier: %v", err)
+		}
+		c := idTokenClaims("https://idp.example.com")
+		c["groups"] = []string{"team-a"}
+		if _, err := v.Verify(context.Background(), signIDToken(t, "k1", key, c)); !errors.Is(err, ErrUnauthorizedToken) {
+			t.Errorf("expected a token without a role to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("other signer", func(t *testing.T) {
+		token := signIDToken(t, "k1", newRSAKey(t), idTokenClaims("https://idp.example.com"))
+		if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrUnauthorizedToken) {
+			t.Errorf("expected a bad signature to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestOIDCVerifier_DiscoveryAndRotation(t *testing.T) {
+	oldKey, newKey := newRSAKey(t), newRSAKey(t)
+	var jwks atomic.Value
+	jwks.Store(JWKS{Keys: []JWK{rsaJWK(t, "old", oldKey)}})
+	var fetches atomic.Int32
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OIDCDiscovery{Issuer: server.URL, JWKSURI: server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(jwks.Load())
+	})
+
+	v, err := NewOIDCVerifier(context.Background(), oidcTestConfig(server.URL), server.Client())
+	if err != nil {
+		t.Fatalf("NewOIDCVerifier: %v", err)
+	}
+	if _, err := v.Verify(context.Background(), signIDToken(t, "old", oldKey, idTokenClaims(server.URL))); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	// The issuer rotates; the new key is picked up on first use once the
+	// refresh interval has passed.
+	jwks.Store(JWKS{Keys: []JWK{rsaJWK(t, "new", newKey)}})
+	token := signIDToken(t, "new", newKey, idTokenClaims(server.URL))
+	if _, err := v.Verify(context.Background(), token); err == nil {
+		t.Fatal("expected the unknown key to be rejected within the refresh interval")
+	}
+	if fetches.Load() != 1 {
+		t.Fatalf("expected no refetch within the interval, got %d fetches", fetches.Load())
+	}
+
+	now := time.Now().Add(2 * jwksRefreshInterval)
+	v.now = func() time.Time { return now }
+	if _, err := v.Verify(context.Background(), token); err != nil {
+		t.Fatalf("expected the rotated key to be fetched: %v", err)
+	}
+	if fetches.Load() != 2 {
+		t.Errorf("expected one refetch, got %d fetches", fetches.Load())
+	}
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrAlreadyRevoked is returned by Revoke for a token that was revoked before.
+var ErrAlreadyRevoked = errors.New("token is already revoked")
+
+// RevocationStore remembers revoked token IDs (the jti claim) until the tokens
+// expire on their own.
+type RevocationStore interface {
+	// Revoke records id, or returns ErrAlreadyRevoked if it is recorded
+	// already. The check and the write are one step, so of concurrent calls
+	// for the same id only one succeeds.
+	Revoke(ctx context.Context, id string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, id string) (bool, error)
+}
+
+// MemoryRevocationStore keeps revoked token IDs in the process. Revocations are
+// lost on restart and not shared between replicas.
+type MemoryRevocationStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	revoked map[string]time.Time
+}
+
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{now: time.Now, revoked: make(map[string]time.Time)}
+}
+
+// Revoke records id and drops the entries of tokens that have expired.
+func (s *MemoryRevocationStore) Revoke(ctx context.Context, id string, expiresAt time.Time) error {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, exp := range s.revoked {
+		if !now.Before(exp) {
+			delete(s.revoked, k)
+		}
+	}
+	if _, ok := s.revoked[id]; ok {
+		return ErrAlreadyRevoked
+	}
+	s.revoked[id] = expiresAt
+	return nil
+}
+
+func (s *MemoryRevocationStore) IsRevoked(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.revoked[id]
+	return ok, nil
+}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"context"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -9,4 +11,29 @@ type Claims struct {
 	Username          string   `json:"username"`
 	Role              string   `json:"role"`
 	AllowedNamespaces []string `json:"allowed_namespaces"`
+	Permissions       []string `json:"permissions,omitempty"`
+	KubernetesUser    string   `json:"k8s_user,omitempty"`
+	KubernetesGroups  []string `json:"k8s_groups,omitempty"`
+	// Verbs restrict API keys to these verbs on top of their role. Empty
+	// allows every verb.
+	Verbs []string `json:"verbs,omitempty"`
+	// APIKey is the name of the API key the caller authenticated with. It is
+	// never read from tokens.
+	APIKey string `json:"-"`
+	// TokenUse is TokenUseRefresh for refresh tokens, which are not accepted
+	// as bearer tokens, and empty for access tokens.
+	TokenUse string `json:"token_use,omitempty"`
+}
+
+const TokenUseRefresh = "refresh"
+
+// TokenVerifier authenticates bearer tokens that are not issued by the API
+// server, such as OIDC ID tokens and Kubernetes ServiceAccount tokens.
+type TokenVerifier interface {
+	// Handles reports whether the verifier is responsible for token. It must
+	// not verify the token.
+	Handles(token string) bool
+	// Verify validates token and maps it to Claims. Invalid tokens fail with
+	// ErrUnauthorizedToken.
+	Verify(ctx context.Context, token string) (*Claims, error)
 }
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+var (
+	// ErrUserNotFound is returned by a UserStore for unknown usernames.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserExists is returned by a UserStore when creating a taken username.
+	ErrUserExists = errors.New("user already exists")
+	// ErrUserReadOnly is returned for changes to users of the config file,
+	// which can only be changed there.
+	ErrUserReadOnly = errors.New("user is defined in the config file")
+)
+
+// UserStore keeps users by username.
+type UserStore interface {
+	// Get returns ErrUserNotFound for unknown usernames.
+	Get(ctx context.Context, username string) (*cfg.User, error)
+	List(ctx context.Context) ([]cfg.User, error)
+	// Create returns ErrUserExists if the username is taken.
+	Create(ctx context.Context, user cfg.User) error
+	// Update replaces the user with the same username. It returns
+	// ErrUserNotFound for unknown usernames.
+	Update(ctx context.Context, user cfg.User) error
+	// Delete returns ErrUserNotFound for unknown usernames.
+	Delete(ctx context.Context, username string) error
+}
+
+var _ UserStore = &ConfigUserStore{}
+
+// ConfigUserStore serves the users of the config file in front of a store for
+// the users managed through the API. Users of the config file are read-only,
+// and their usernames cannot be taken by managed users.
+type ConfigUserStore struct {
+	static map[string]cfg.User
+	store  UserStore
+}
+
+func NewConfigUserStore(users []cfg.User, store UserStore) *ConfigUserStore {
+	static := make(map[string]cfg.User, len(users))
+	for _, u := range users {
+		static[u.Username] = u
+	}
+	return &ConfigUserStore{static: static, store: store}
+}
+
+// WithUsers returns a ConfigUserStore for another config file in front of the
+// same store of managed users.
+func (s *ConfigUserStore) WithUsers(users []cfg.User) *ConfigUserStore {
+	return NewConfigUserStore(users, s.store)
+}
+
+// FromConfig reports whether username is a user of the config file.
+func (s *ConfigUserStore) FromConfig(username string) bool {
+	_, ok := s.static[username]
+	return ok
+}
+
+func (s *ConfigUserStore) Get(ctx context.Context, username string) (*cfg.User, error) {
+	if u, ok := s.static[username]; ok {
+		return &u, nil
+	}
+	return s.store.Get(ctx, username)
+}
+
+// List returns the users of the config file followed by the managed users,
+// each sorted by username.
+func (s *ConfigUserStore) List(ctx context.Context) ([]cfg.User, error) {
+	managed, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]cfg.User, 0, len(s.static)+len(managed))
+	for _, u := range s.static {
+		users = append(users, u)
+	}
+	sortUsers(users)
+	for _, u := range managed {
+		if !s.FromConfig(u.Username) {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+func (s *ConfigUserStore) Create(ctx context.Context, user cfg.User) error {
+	if s.FromConfig(user.Username) {
+		return ErrUserExists
+	}
+	return s.store.Create(ctx, user)
+}
+
+func (s *ConfigUserStore) Update(ctx context.Context, user cfg.User) error {
+	if s.FromConfig(user.Username) {
+		return ErrUserReadOnly
+	}
+	return s.store.Update(ctx, user)
+}
+
+func (s *ConfigUserStore) Delete(ctx context.Context, username string) error {
+	if s.FromConfig(username) {
+		return ErrUserReadOnly
+	}
+	return s.store.Delete(ctx, username)
+}
+
+var _ UserStore = &MemoryUserStore{}
+
+// MemoryUserStore keeps users in the process. They are lost on restart and not
+// shared between replicas.
+type MemoryUserStore struct {
+	mu    sync.Mutex
+	users map[string]cfg.User
+}
+
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{users: make(map[string]cfg.User)}
+}
+
+func (s *MemoryUserStore) Get(_ context.Context, username string) (*cfg.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[username]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &u, nil
+}
+
+func (s *MemoryUserStore) List(_ context.Context) ([]cfg.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]cfg.User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, u)
+	}
+	sortUsers(users)
+	return users, nil
+}
+
+func (s *MemoryUserStore) Create(_ context.Context, user cfg.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[user.Username]; ok {
+		return ErrUserExists
+	}
+	s.users[user.Username] = user
+	return nil
+}
+
+func (s *MemoryUserStore) Update(_ context.Context, user cfg.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[user.Username]; !ok {
+		return ErrUserNotFound
+	}
+	s.users[user.Username] = user
+	return nil
+}
+
+func (s *MemoryUserStore) Delete(_ context.Context, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[username]; !ok {
+		return ErrUserNotFound
+	}
+	delete(s.users, username)
+	return nil
+}
+
+// MinPasswordLength is the shortest password accepted for users managed
+// through the API.
+const MinPasswordLength = 8
+
+// ValidatePassword checks a new password of a managed user.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", MinPasswordLength)
+	}
+	// bcrypt cannot hash longer passwords.
+	if len(password) > 72 {
+		return fmt.Errorf("password must be at most 72 bytes long")
+	}
+	return nil
+}
+
+func sortUsers(users []cfg.User) {
+	slices.SortFunc(users, func(a, b cfg.User) int { return strings.Compare(a.Username, b.Username) })
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+func TestConfigUserStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewConfigUserStore([]cfg.User{{ID: "1", Username: "root", Role: "admin"}}, NewMemoryUserStore())
+
+	if err := store.Create(ctx, cfg.User{ID: "2", Username: "root"}); !errors.Is(err, ErrUserExists) {
+		t.Errorf("expected usernames of the config file to be taken, got %v", err)
+	}
+	if err := store.Update(ctx, cfg.User{ID: "1", Username: "root", Role: "developer"}); !errors.Is(err, ErrUserReadOnly) {
+		t.Errorf("expected users of the config file to be read-only, got %v", err)
+	}
+	if err := store.Delete(ctx, "root"); !errors.Is(err, ErrUserReadOnly) {
+		t.Errorf("expected users of the config file to be read-only, got %v", err)
+	}
+
+	for _, name := range []string{"zoe", "anna"} {
+		if err := store.Create(ctx, cfg.User{ID: name, Username: name, Role: "developer"}); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+	if err := store.Create(ctx, cfg.User{Username: "anna"}); !errors.Is(err, ErrUserExists) {
+		t.Errorf("expected ErrUserExists, got %v", err)
+	}
+	if err := store.Update(ctx, cfg.User{ID: "anna", Username: "anna", Role: "operator"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := store.Update(ctx, cfg.User{Username: "missing"}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	u, err := store.Get(ctx, "anna")
+	if err != nil || u.Role != "operator" {
+		t.Fatalf("Get: %+v, %v", u, err)
+	}
+	if u, err := store.Get(ctx, "root"); err != nil || u.Role != "admin" || !store.FromConfig("root") || store.FromConfig("anna") {
+		t.Errorf("expected root from the config file, got %+v, %v", u, err)
+	}
+
+	users, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	if len(names) != 3 || names[0] != "root" || names[1] != "anna" || names[2] != "zoe" {
+		t.Errorf("expected config users first, then managed users by name, got %v", names)
+	}
+
+	if err := store.Delete(ctx, "anna"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "anna"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+	if err := store.Delete(ctx, "anna"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
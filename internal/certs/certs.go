@@ -0,0 +1,267 @@
+// Package certs issues the TLS certificates of Certificate claims.
+//
+// A certificate is either self-signed or signed by a CA key pair taken from the
+// Secret of another claim. Keys and certificates are PEM encoded so they can be
+// written to kubernetes.io/tls Secrets as is.
+package certs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	AlgorithmRSA   = "RSA"
+	AlgorithmECDSA = "ECDSA"
+
+	DefaultRSAKeySize   = 2048
+	DefaultECDSAKeySize = 256
+
+	// DefaultDuration is used when a Request has no Duration.
+	DefaultDuration = 90 * 24 * time.Hour
+)
+
+// Request describes the certificate to issue.
+type Request struct {
+	CommonName   string
+	DNSNames     []string
+	IPAddresses  []string
+	KeyAlgorithm string
+	KeySize      int
+	Duration     time.Duration
+	IsCA         bool
+}
+
+// KeyPair is a parsed certificate with its private key.
+type KeyPair struct {
+	Certificate *x509.Certificate
+	Key         crypto.Signer
+	CertPEM     []byte
+}
+
+// ValidateRequest checks the parts of req that Issue cannot recover from.
+func ValidateRequest(req Request) error {
+	if req.CommonName == "" && len(req.DNSNames) == 0 {
+		return errors.New("certificate requires a commonName or at least one dnsName")
+	}
+	for _, ip := range req.IPAddresses {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid ip address %q", ip)
+		}
+	}
+	if req.Duration < 0 {
+		return errors.New("certificate duration must be positive")
+	}
+	_, _, err := normalizeKey(req.KeyAlgorithm, req.KeySize)
+	return err
+}
+
+func normalizeKey(algorithm string, size int) (string, int, error) {
+	switch strings.ToUpper(algorithm) {
+	case "", AlgorithmECDSA:
+		if size == 0 {
+			size = DefaultECDSAKeySize
+		}
+		if size != 256 && size != 384 {
+			return "", 0, fmt.Errorf("unsupported ECDSA key size %d, use 256 or 384", size)
+		}
+		return AlgorithmECDSA, size, nil
+	case AlgorithmRSA:
+		if size == 0 {
+			size = DefaultRSAKeySize
+		}
+		if size != 2048 && size != 3072 && size != 4096 {
+			return "", 0, fmt.Errorf("unsupported RSA key size %d, use 2048, 3072 or 4096", size)
+		}
+		return AlgorithmRSA, size, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported key algorithm %q, use RSA or ECDSA", algorithm)
+	}
+}
+
+func generateKey(algorithm string, size int) (crypto.Signer, error) {
+	algorithm, size, err := normalizeKey(algorithm, size)
+	if err != nil {
+		return nil, err
+	}
+	if algorithm == AlgorithmRSA {
+		return rsa.GenerateKey(rand.Reader, size)
+	}
+	curve := elliptic.P256()
+	if size == 384 {
+		curve = elliptic.P384()
+	}
+	return ecdsa.GenerateKey(curve, rand.Reader)
+}
+
+// Issue generates a key pair and a certificate for req, valid from now. The
+// certificate is signed by issuer, or self-signed when issuer is nil.
+// It returns the PEM encoded certificate and private key.
+func Issue(req Request, issuer *KeyPair, now time.Time) (certPEM, keyPEM []byte, err error) {
+	if err := ValidateRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	if req.Duration == 0 {
+		req.Duration = DefaultDuration
+	}
+
+	key, err := generateKey(req.KeyAlgorithm, req.KeySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate %s key: %w", req.KeyAlgorithm, err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: req.CommonName},
+		DNSNames:              req.DNSNames,
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(req.Duration),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  req.IsCA,
+	}
+	for _, ip := range req.IPAddresses {
+		template.IPAddresses = append(template.IPAddresses, net.ParseIP(ip))
+	}
+	if req.IsCA {
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	parent, signer := template, key
+	if issuer != nil {
+		if !issuer.Certificate.IsCA {
+			return nil, nil, errors.New("issuer certificate is not a CA")
+		}
+		parent, signer = issuer.Certificate, issuer.Key
+		if template.NotAfter.After(issuer.Certificate.NotAfter) {
+			template.NotAfter = issuer.Certificate.NotAfter
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// ParseCertificate decodes the first PEM certificate of certPEM.
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// ParseKeyPair decodes a PEM certificate and its PKCS#8, PKCS#1 or SEC 1 private key.
+func ParseKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM private key found")
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return &KeyPair{Certificate: cert, Key: signer, CertPEM: certPEM}, nil
+}
+
+// Matches reports whether cert was issued for req, ignoring the validity period.
+func Matches(cert *x509.Certificate, req Request) bool {
+	algorithm, size, err := normalizeKey(req.KeyAlgorithm, req.KeySize)
+	if err != nil {
+		return false
+	}
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if algorithm != AlgorithmRSA || pub.N.BitLen() != size {
+			return false
+		}
+	case *ecdsa.PublicKey:
+		if algorithm != AlgorithmECDSA || pub.Curve.Params().BitSize != size {
+			return false
+		}
+	default:
+		return false
+	}
+
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	wantIPs := make([]string, 0, len(req.IPAddresses))
+	for _, ip := range req.IPAddresses {
+		wantIPs = append(wantIPs, net.ParseIP(ip).String())
+	}
+
+	return cert.Subject.CommonName == req.CommonName &&
+		cert.IsCA == req.IsCA &&
+		sameStrings(cert.DNSNames, req.DNSNames) &&
+		sameStrings(ips, wantIPs)
+}
+
+// SignedBy reports whether cert was signed by the CA certificate in caPEM.
+func SignedBy(cert *x509.Certificate, caPEM []byte) bool {
+	ca, err := ParseCertificate(caPEM)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(cert.RawIssuer, ca.RawSubject) && cert.CheckSignatureFrom(ca) == nil
+}
+
+func sameStrings(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,113 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIssue_SelfSigned(t *testing.T) {
+	now := time.Now()
+	req := Request{
+		CommonName:  "api.default.svc",
+		DNSNames:    []string{"api", "api.default.svc"},
+		IPAddresses: []string{"10.0.0.1"},
+		Duration:    24 * time.Hour,
+	}
+
+	certPEM, keyPEM, err := Issue(req, nil, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pair, err := ParseKeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed to parse issued pair: %v", err)
+	}
+	if _, ok := pair.Key.(*ecdsa.PrivateKey); !ok {
+		t.Errorf("expected an ECDSA key by default, got %T", pair.Key)
+	}
+	if !Matches(pair.Certificate, req) {
+		t.Errorf("issued certificate does not match the request")
+	}
+	if got := pair.Certificate.NotAfter.Sub(now); got < 23*time.Hour || got > 25*time.Hour {
+		t.Errorf("unexpected validity: %v", got)
+	}
+
+	req.DNSNames = append(req.DNSNames, "extra")
+	if Matches(pair.Certificate, req) {
+		t.Errorf("expected a mismatch after adding a dns name")
+	}
+}
+
+func TestIssue_SignedByCA(t *testing.T) {
+	now := time.Now()
+	caCertPEM, caKeyPEM, err := Issue(Request{CommonName: "internal-ca", IsCA: true, KeyAlgorithm: "RSA", Duration: 48 * time.Hour}, nil, now)
+	if err != nil {
+		t.Fatalf("failed to issue CA: %v", err)
+	}
+	ca, err := ParseKeyPair(caCertPEM, caKeyPEM)
+	if err != nil {
+		t.Fatalf("failed to parse CA: %v", err)
+	}
+	if _, ok := ca.Key.(*rsa.PrivateKey); !ok {
+		t.Fatalf("expected an RSA CA key, got %T", ca.Key)
+	}
+
+	certPEM, _, err := Issue(Request{CommonName: "client", Duration: 72 * time.Hour}, ca, now)
+	if err != nil {
+		t.Fatalf("failed to issue leaf: %v", err)
+	}
+	leaf, err := ParseCertificate(certPEM)
+	if err != nil {
+		t.Fatalf("failed to parse leaf: %v", err)
+	}
+
+	if !SignedBy(leaf, caCertPEM) {
+		t.Errorf("leaf is not signed by the CA")
+	}
+	if leaf.NotAfter.After(ca.Certificate.NotAfter) {
+		t.Errorf("leaf outlives its CA: %v > %v", leaf.NotAfter, ca.Certificate.NotAfter)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+		t.Errorf("leaf does not verify against the CA: %v", err)
+	}
+
+	if _, _, err := Issue(Request{CommonName: "other"}, &KeyPair{Certificate: leaf, Key: ca.Key}, now); err == nil {
+		t.Errorf("expected an error when the issuer is not a CA")
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	cases := []struct {
+		name    string
+		req     Request
+		wantErr string
+	}{
+		{name: "no names", req: Request{}, wantErr: "commonName"},
+		{name: "bad ip", req: Request{CommonName: "a", IPAddresses: []string{"nope"}}, wantErr: "invalid ip"},
+		{name: "bad algorithm", req: Request{CommonName: "a", KeyAlgorithm: "DSA"}, wantErr: "unsupported key algorithm"},
+		{name: "bad rsa size", req: Request{CommonName: "a", KeyAlgorithm: "RSA", KeySize: 1024}, wantErr: "RSA key size"},
+		{name: "ok", req: Request{CommonName: "a", KeyAlgorithm: "ecdsa", KeySize: 384}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRequest(tc.req)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package cfg
 import (
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,6 +15,22 @@ type Config struct {
 	JWT JWTConfig `yaml:"jwt"`
 
 	Service ServiceConfig `yaml:"service"`
+
+	Sealing SealingConfig `yaml:"sealing"`
+
+	Secrets SecretsConfig `yaml:"secrets"`
+
+	Roles []Role `yaml:"roles"`
+
+	Authorization AuthorizationConfig `yaml:"authorization"`
+
+	OIDC OIDCConfig `yaml:"oidc"`
+
+	APIKeys APIKeysConfig `yaml:"api_keys"`
+
+	ServiceAccounts ServiceAccountsConfig `yaml:"service_accounts"`
+
+	UserStore UserStoreConfig `yaml:"user_store"`
 }
 
 type ServiceConfig struct {
@@ -21,42 +39,309 @@ type ServiceConfig struct {
 	Port    string `yaml:"port"`
 }
 
+// User is a user of the config file or, encoded as JSON, one managed through
+// the API.
 type User struct {
-	ID                string   `yaml:"id"`
-	Username          string   `yaml:"username"`
-	PasswordHash      string   `yaml:"pwd"`
-	Role              string   `yaml:"role"`
-	AllowedNamespaces []string `yaml:"allowed_namespaces"`
+	ID                string   `yaml:"id" json:"id"`
+	Username          string   `yaml:"username" json:"username"`
+	PasswordHash      string   `yaml:"pwd" json:"pwd"`
+	Role              string   `yaml:"role" json:"role"`
+	AllowedNamespaces []string `yaml:"allowed_namespaces" json:"allowedNamespaces"`
+	Permissions       []string `yaml:"permissions" json:"permissions,omitempty"`
+	// KubernetesUser and KubernetesGroups are the identity SubjectAccessReviews
+	// are made for; the user defaults to the username.
+	KubernetesUser   string   `yaml:"kubernetes_user" json:"kubernetesUser,omitempty"`
+	KubernetesGroups []string `yaml:"kubernetes_groups" json:"kubernetesGroups,omitempty"`
+	// PasswordChangedAt is set when the password of a managed user changes;
+	// refresh tokens issued before are rejected.
+	PasswordChangedAt *time.Time `yaml:"-" json:"passwordChangedAt,omitempty"`
+}
+
+// SealingConfig points to the namespace the controller publishes the sealing
+// public key in.
+type SealingConfig struct {
+	Namespace string `yaml:"namespace"`
+}
+
+// SecretsConfig controls how SecretClaims are written.
+type SecretsConfig struct {
+	// HashOpaqueData keeps only hashes of Opaque data in SecretClaims and writes
+	// the values straight to the created Secret.
+	HashOpaqueData bool `yaml:"hash_opaque_data"`
+}
+
+// Role grants the users that have it the verbs of its rules. Roles named
+// admin, operator and developer replace the built-in roles of the same name.
+type Role struct {
+	Name  string       `yaml:"name"`
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule allows verbs on resources. Empty namespaces match every namespace
+// the user is allowed; an empty label selector matches every claim.
+type PolicyRule struct {
+	Resources     []string `yaml:"resources"`
+	Verbs         []string `yaml:"verbs"`
+	Namespaces    []string `yaml:"namespaces"`
+	LabelSelector string   `yaml:"label_selector"`
+}
+
+// Authorization modes.
+const (
+	// AuthorizationModeRoles checks calls against the roles only.
+	AuthorizationModeRoles = "roles"
+	// AuthorizationModeKubernetes asks the cluster with a SubjectAccessReview.
+	AuthorizationModeKubernetes = "kubernetes"
+	// AuthorizationModeBoth needs both the roles and the cluster to allow a call.
+	AuthorizationModeBoth = "both"
+)
+
+// AuthorizationConfig selects who decides on API calls.
+type AuthorizationConfig struct {
+	// Mode is roles (the default), kubernetes or both.
+	Mode string `yaml:"mode"`
+	// CacheTTL is how long SubjectAccessReview decisions are reused.
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+// OIDCConfig enables logins with ID tokens of an external identity provider.
+// It is off while Issuer is empty.
+type OIDCConfig struct {
+	Issuer   string `yaml:"issuer"`
+	ClientID string `yaml:"client_id"`
+	// JWKSFile is read instead of fetching the keys from JWKSURL or, when both
+	// are empty, from the jwks_uri of the issuer's discovery document.
+	JWKSFile string `yaml:"jwks_file"`
+	JWKSURL  string `yaml:"jwks_url"`
+	// Scopes are requested by the CLI device-code login.
+	Scopes []string `yaml:"scopes"`
+	// UsernameClaim defaults to email, GroupsClaim to groups.
+	UsernameClaim string `yaml:"username_claim"`
+	GroupsClaim   string `yaml:"groups_claim"`
+	// RoleMappings are tried in order; DefaultRole applies when none matches.
+	// Without a role the token is rejected.
+	RoleMappings []OIDCRoleMapping `yaml:"role_mappings"`
+	DefaultRole  string            `yaml:"default_role"`
+	// GroupNamespaces lists the allowed namespaces of the members of a group.
+	GroupNamespaces map[string][]string `yaml:"group_namespaces"`
+}
+
+// OIDCRoleMapping gives Role to tokens whose Claim equals Value or, for list
+// claims, contains it.
+type OIDCRoleMapping struct {
+	Claim string `yaml:"claim"`
+	Value string `yaml:"value"`
+	Role  string `yaml:"role"`
 }
 
 type JWTConfig struct {
+	// Secret signs tokens with HS256 while no Keys are configured.
 	Secret string `yaml:"secret"`
+	// Keys sign tokens with RS256, ES256 or EdDSA. ActiveKey names the key new
+	// tokens are signed with; the others only verify tokens.
+	Keys      []SigningKeyConfig `yaml:"keys"`
+	ActiveKey string             `yaml:"active_key"`
+	// RetiredKeyGrace is how long a key keeps verifying tokens after its
+	// retired_at. It defaults to the refresh token TTL, so that no token signed
+	// before the retirement is cut short.
+	RetiredKeyGrace time.Duration `yaml:"retired_key_grace"`
+	// AccessTokenTTL defaults to 15m, RefreshTokenTTL to 7 days.
+	AccessTokenTTL  time.Duration    `yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration    `yaml:"refresh_token_ttl"`
+	Revocation      RevocationConfig `yaml:"revocation"`
+}
+
+// SigningKeyConfig is a PEM encoded RSA, P-256 or Ed25519 private key. ID is
+// sent as the kid of the tokens it signs.
+type SigningKeyConfig struct {
+	ID   string `yaml:"id"`
+	File string `yaml:"file"`
+	// RetiredAt starts the grace period of a key that is no longer active.
+	RetiredAt *time.Time `yaml:"retired_at"`
 }
 
+// AccessTTL returns the configured access token lifetime or its default.
+func (c JWTConfig) AccessTTL() time.Duration {
+	if c.AccessTokenTTL > 0 {
+		return c.AccessTokenTTL
+	}
+	return 15 * time.Minute
+}
+
+// RefreshTTL returns the configured refresh token lifetime or its default.
+func (c JWTConfig) RefreshTTL() time.Duration {
+	if c.RefreshTokenTTL > 0 {
+		return c.RefreshTokenTTL
+	}
+	return 7 * 24 * time.Hour
+}
+
+// Revocation stores.
+const (
+	// RevocationStoreMemory keeps revoked token IDs in the API server process.
+	RevocationStoreMemory = "memory"
+	// RevocationStoreKubernetes keeps them in a ConfigMap shared by all replicas.
+	RevocationStoreKubernetes = "kubernetes"
+)
+
+// RevocationConfig selects where revoked token IDs are kept until the tokens
+// expire.
+type RevocationConfig struct {
+	// Store is memory (the default) or kubernetes.
+	Store     string `yaml:"store"`
+	Namespace string `yaml:"namespace"`
+	// ConfigMap defaults to secret-manager-revoked-tokens.
+	ConfigMap string `yaml:"config_map"`
+}
+
+// API key stores.
+const (
+	// APIKeyStoreMemory keeps API keys in the API server process.
+	APIKeyStoreMemory = "memory"
+	// APIKeyStoreKubernetes keeps them in a Secret shared by all replicas.
+	APIKeyStoreKubernetes = "kubernetes"
+)
+
+// APIKeysConfig selects where the hashes of API keys are kept.
+type APIKeysConfig struct {
+	// Store is memory (the default) or kubernetes.
+	Store     string `yaml:"store"`
+	Namespace string `yaml:"namespace"`
+	// Secret defaults to secret-manager-api-keys.
+	Secret string `yaml:"secret"`
+}
+
+// User stores.
+const (
+	// UserStoreMemory keeps users created through the API in the API server
+	// process.
+	UserStoreMemory = "memory"
+	// UserStoreKubernetes keeps them in a Secret shared by all replicas.
+	UserStoreKubernetes = "kubernetes"
+)
+
+// UserStoreConfig selects where the users created through the API are kept.
+// The users of the config file are always available and read-only.
+type UserStoreConfig struct {
+	// Store is memory (the default) or kubernetes.
+	Store     string `yaml:"store"`
+	Namespace string `yaml:"namespace"`
+	// Secret defaults to secret-manager-users.
+	Secret string `yaml:"secret"`
+}
+
+// ServiceAccountsConfig lets in-cluster workloads call the API with their
+// ServiceAccount tokens, which are verified with TokenReviews. It is off
+// unless Enabled.
+type ServiceAccountsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Audiences the tokens must be issued for. Empty accepts the audiences of
+	// the Kubernetes API server.
+	Audiences []string `yaml:"audiences"`
+	// CacheTTL is how long TokenReview results are reused, 1m by default.
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// Bindings are tried in order; ServiceAccounts without one are rejected.
+	Bindings []ServiceAccountBinding `yaml:"bindings"`
+}
+
+// ServiceAccountBinding gives Role to the ServiceAccounts whose namespace and
+// name match the glob patterns Namespace and Name. An empty Name matches every
+// ServiceAccount of the namespace.
+type ServiceAccountBinding struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	Role      string `yaml:"role"`
+	// AllowedNamespaces default to the namespace of the ServiceAccount.
+	AllowedNamespaces []string `yaml:"allowed_namespaces"`
+	// Verbs restrict the ServiceAccounts to these verbs on top of the role.
+	Verbs []string `yaml:"verbs"`
+}
+
+// AppConfig is the config the API server started with. Reloads do not change
+// it; the settings read from it need a restart anyway.
 var AppConfig Config
 
 func LoadConfig() (*Config, error) {
-	configPath := os.Getenv("CONFIG_PATH")
-	envSecret := os.Getenv("JWT_SECRET")
+	config, err := Load(os.Getenv("CONFIG_PATH"))
+	if err != nil {
+		return nil, err
+	}
+	AppConfig = *config
+	return &AppConfig, nil
+}
 
-	data, err := os.ReadFile(configPath)
+// Load reads and validates the config file at path. The JWT_SECRET environment
+// variable overrides jwt.secret.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
-	if err := yaml.Unmarshal(data, &AppConfig); err != nil {
+	return Parse(data)
+}
+
+// Parse decodes and validates a config file.
+func Parse(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
-	if envSecret != "" {
-		AppConfig.JWT.Secret = envSecret
+	if envSecret := os.Getenv("JWT_SECRET"); envSecret != "" {
+		config.JWT.Secret = envSecret
 	}
-	return &AppConfig, nil
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return config, nil
 }
 
-func (c *Config) FindUser(username string) *User {
-	for _, u := range c.Users {
-		if u.Username == username {
-			return &u
+// Validate checks what can be checked without building the authorizer and
+// signing keys, which report their own errors.
+func (c *Config) Validate() error {
+	usernames := make(map[string]bool, len(c.Users))
+	for i, u := range c.Users {
+		if u.Username == "" {
+			return fmt.Errorf("users[%d]: username is required", i)
+		}
+		if usernames[u.Username] {
+			return fmt.Errorf("users[%d]: duplicate username %q", i, u.Username)
+		}
+		usernames[u.Username] = true
+		if u.PasswordHash == "" {
+			return fmt.Errorf("user %s: pwd is required", u.Username)
+		}
+		if u.Role == "" {
+			return fmt.Errorf("user %s: role is required", u.Username)
+		}
+	}
+	// Every store is memory or kubernetes.
+	for name, store := range map[string]string{
+		"jwt.revocation.store": c.JWT.Revocation.Store,
+		"api_keys.store":       c.APIKeys.Store,
+		"user_store.store":     c.UserStore.Store,
+	} {
+		if store != "" && store != "memory" && store != "kubernetes" {
+			return fmt.Errorf("%s: unknown store %q", name, store)
 		}
 	}
 	return nil
 }
+
+// RestartRequired lists the sections of next that differ from c and are only
+// read at startup. Reloads apply everything else.
+func (c *Config) RestartRequired(next *Config) []string {
+	var sections []string
+	for name, changed := range map[string]bool{
+		"service":        c.Service != next.Service,
+		"secrets":        c.Secrets != next.Secrets,
+		"jwt.revocation": c.JWT.Revocation != next.JWT.Revocation,
+		"api_keys":       c.APIKeys != next.APIKeys,
+		"user_store":     c.UserStore != next.UserStore,
+	} {
+		if changed {
+			sections = append(sections, name)
+		}
+	}
+	slices.Sort(sections)
+	return sections
+}
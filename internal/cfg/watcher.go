@@ -0,0 +1,148 @@
+package cfg
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Config reload results, the values of the result label of ReloadsTotal.
+const (
+	ReloadSuccess = "success"
+	ReloadFailure = "failure"
+)
+
+var (
+	// ReloadsTotal counts config reloads by result.
+	ReloadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "secret_manager_config_reloads_total",
+		Help: "Config file reloads of the API server by result.",
+	}, []string{"result"})
+	// LastReloadSuccessful is 1 while the config file on disk is the one in
+	// use and 0 after it was rejected.
+	LastReloadSuccessful = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "secret_manager_config_last_reload_successful",
+		Help: "Whether the last config file reload of the API server was applied.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(ReloadsTotal, LastReloadSuccessful)
+}
+
+// reloadDelay collects the events of a single update, such as the several
+// renames of a ConfigMap volume update, into one reload.
+const reloadDelay = 200 * time.Millisecond
+
+// Watcher reloads the config file when it changes and hands valid configs to
+// apply. A config that fails to parse, validate or apply is logged, counted
+// and otherwise ignored; the previous one stays in use.
+//
+// The directory of the file is watched rather than the file, since ConfigMap
+// volumes update files by swapping a symlink to a new directory.
+type Watcher struct {
+	path   string
+	apply  func(*Config) error
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	current *Config
+}
+
+// NewWatcher returns a Watcher for the config file at path. current is the
+// config in use, so that files that decode to it are not applied again.
+func NewWatcher(path string, current *Config, apply func(*Config) error, logger *slog.Logger) *Watcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	LastReloadSuccessful.Set(1)
+	return &Watcher{path: path, apply: apply, logger: logger, current: current}
+}
+
+// Run watches the config file until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch config file: %w", err)
+	}
+	defer fsWatcher.Close()
+
+	dir := filepath.Dir(w.path)
+	if err := fsWatcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+	// Pick up changes made before the watch started.
+	w.Reload()
+
+	timer := time.NewTimer(0)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if w.affects(event) {
+				timer.Reset(reloadDelay)
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Warn("Config file watch error", slog.Any("error", err))
+		case <-timer.C:
+			w.Reload()
+		}
+	}
+}
+
+// affects reports whether event may have changed the config file: a change of
+// the file itself or of the ..data symlink of a ConfigMap volume.
+func (w *Watcher) affects(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Base(event.Name)
+	return filepath.Clean(event.Name) == filepath.Clean(w.path) || name == "..data"
+}
+
+// Reload reads the config file and applies it if it changed. It reports
+// whether a new config was applied.
+func (w *Watcher) Reload() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	config, err := Load(w.path)
+	if err == nil && reflect.DeepEqual(config, w.current) {
+		// Only formatting changed, or a rejected edit was reverted.
+		LastReloadSuccessful.Set(1)
+		return false
+	}
+	if err == nil {
+		err = w.apply(config)
+	}
+	if err != nil {
+		ReloadsTotal.WithLabelValues(ReloadFailure).Inc()
+		LastReloadSuccessful.Set(0)
+		w.logger.Error("❌ Rejected config file, keeping the previous config", slog.String("path", w.path), slog.Any("error", err))
+		return false
+	}
+
+	w.current = config
+	ReloadsTotal.WithLabelValues(ReloadSuccess).Inc()
+	LastReloadSuccessful.Set(1)
+	w.logger.Info("✅ Config file reloaded", slog.String("path", w.path))
+	return true
+}
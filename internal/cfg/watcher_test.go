@@ -0,0 +1,163 @@
+package cfg
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const watcherTestConfig = `
+jwt:
+  secret: test-secret
+users:
+  - id: "1"
+    username: alice
+    pwd: hash
+    role: admin
+`
+
+// startWatcher watches path and returns the configs it applies.
+func startWatcher(t *testing.T, path string) <-chan *Config {
+	t.Helper()
+	current, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load initial config: %v", err)
+	}
+	applied := make(chan *Config, 10)
+	w := NewWatcher(path, current, func(c *Config) error {
+		applied <- c
+		return nil
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := w.Run(ctx); err != nil {
+			t.Errorf("Run failed: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	// Give the watch time to start.
+	time.Sleep(50 * time.Millisecond)
+	return applied
+}
+
+func waitForConfig(t *testing.T, applied <-chan *Config) *Config {
+	t.Helper()
+	select {
+	case c := <-applied:
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the config to be reloaded")
+		return nil
+	}
+}
+
+func expectNoConfig(t *testing.T, applied <-chan *Config) {
+	t.Helper()
+	select {
+	case c := <-applied:
+		t.Fatalf("expected no config to be applied, got %+v", c)
+	case <-time.After(2 * reloadDelay):
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, watcherTestConfig)
+	applied := startWatcher(t, path)
+
+	writeFile(t, path, watcherTestConfig+`
+  - id: "2"
+    username: bob
+    pwd: hash
+    role: developer
+`)
+	if c := waitForConfig(t, applied); len(c.Users) != 2 || c.Users[1].Username != "bob" {
+		t.Fatalf("expected the added user to be applied, got %+v", c.Users)
+	}
+
+	failures := testutil.ToFloat64(ReloadsTotal.WithLabelValues(ReloadFailure))
+	writeFile(t, path, watcherTestConfig+`
+  - id: "2"
+    username: alice
+    pwd: hash
+    role: developer
+`)
+	expectNoConfig(t, applied)
+	if got := testutil.ToFloat64(ReloadsTotal.WithLabelValues(ReloadFailure)); got != failures+1 {
+		t.Errorf("expected the rejected config to be counted, got %v failures after %v", got, failures)
+	}
+	if got := testutil.ToFloat64(LastReloadSuccessful); got != 0 {
+		t.Errorf("expected the last reload to be reported as failed, got %v", got)
+	}
+
+	writeFile(t, path, watcherTestConfig)
+	if c := waitForConfig(t, applied); len(c.Users) != 1 {
+		t.Fatalf("expected the fixed config to be applied, got %+v", c.Users)
+	}
+	if got := testutil.ToFloat64(LastReloadSuccessful); got != 1 {
+		t.Errorf("expected the last reload to be reported as successful, got %v", got)
+	}
+
+	// Only formatting changes.
+	writeFile(t, path, "# comment\n"+watcherTestConfig)
+	expectNoConfig(t, applied)
+}
+
+// TestWatcher_ConfigMap updates the file the way the kubelet updates a
+// ConfigMap volume: by swapping the ..data symlink to a new directory.
+func TestWatcher_ConfigMap(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	dir := t.TempDir()
+	writeVersion := func(version, data string) {
+		t.Helper()
+		if err := os.Mkdir(filepath.Join(dir, version), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(dir, version, "config.yaml"), data)
+		if err := os.Symlink(version, filepath.Join(dir, "..data_tmp")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeVersion("..v1", watcherTestConfig)
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(filepath.Join("..data", "config.yaml"), path); err != nil {
+		t.Fatal(err)
+	}
+	applied := startWatcher(t, path)
+
+	writeVersion("..v2", `
+jwt:
+  secret: rotated-secret
+users:
+  - id: "1"
+    username: alice
+    pwd: hash
+    role: admin
+`)
+	if c := waitForConfig(t, applied); c.JWT.Secret != "rotated-secret" {
+		t.Fatalf("expected the rotated secret to be applied, got %q", c.JWT.Secret)
+	}
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/certs"
+)
+
+// caCertKey holds the issuing CA certificate, or the certificate itself when self-signed.
+const caCertKey = "ca.crt"
+
+// certificateKeys are the Secret keys written for Certificate claims.
+var certificateKeys = []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, caCertKey}
+
+// certificateRequest converts the claim's CertificateConfig for the certs package.
+func certificateRequest(cfg *secretsv1alpha1.CertificateConfig) certs.Request {
+	req := certs.Request{
+		CommonName:   cfg.CommonName,
+		DNSNames:     cfg.DNSNames,
+		IPAddresses:  cfg.IPAddresses,
+		KeyAlgorithm: cfg.KeyAlgorithm,
+		KeySize:      cfg.KeySize,
+		Duration:     certs.DefaultDuration,
+		IsCA:         cfg.IsCA,
+	}
+	if cfg.Duration != nil {
+		req.Duration = cfg.Duration.Duration
+	}
+	return req
+}
+
+// renewalTime returns when a certificate valid from notBefore to notAfter is renewed:
+// RenewBefore ahead of notAfter, or after two thirds of its lifetime when RenewBefore
+// is unset or does not fit, e.g. because the issuing CA shortened the lifetime.
+func renewalTime(cfg *secretsv1alpha1.CertificateConfig, notBefore, notAfter time.Time) time.Time {
+	lifetime := notAfter.Sub(notBefore)
+	if cfg.RenewBefore != nil && cfg.RenewBefore.Duration > 0 && cfg.RenewBefore.Duration < lifetime {
+		return notAfter.Add(-cfg.RenewBefore.Duration)
+	}
+	return notBefore.Add(lifetime * 2 / 3)
+}
+
+// markCertificateIssued records the validity of the certificate in certPEM in the
+// claim status and schedules its renewal.
+func markCertificateIssued(claim *secretsv1alpha1.SecretClaim, certPEM []byte) error {
+	cert, err := certs.ParseCertificate(certPEM)
+	if err != nil {
+		return err
+	}
+
+	issued := metav1.NewTime(cert.NotBefore)
+	notAfter := metav1.NewTime(cert.NotAfter)
+	next := metav1.NewTime(renewalTime(claim.Spec.Certificate, cert.NotBefore, cert.NotAfter))
+	claim.Status.LastRotationTime = &issued
+	claim.Status.NotAfter = &notAfter
+	claim.Status.NextRotationTime = &next
+	return nil
+}
+
+// loadIssuer returns the CA key pair referenced by the claim's IssuerRef, or nil
+// for self-signed certificates. The CA Secret must be controlled by the issuer claim.
+func (r *SecretClaimReconciler) loadIssuer(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (*certs.KeyPair, error) {
+	issuerName := claim.Spec.Certificate.IssuerRef
+	if issuerName == "" {
+		return nil, nil
+	}
+
+	var issuer secretsv1alpha1.SecretClaim
+	if err := r.Get(ctx, client.ObjectKey{Name: issuerName, Namespace: claim.Namespace}, &issuer); err != nil {
+		return nil, fmt.Errorf("failed to get issuer SecretClaim %s: %w", issuerName, err)
+	}
+
+	var caSecret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Name: issuer.Name, Namespace: issuer.Namespace}, &caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get CA Secret of issuer %s: %w", issuerName, err)
+	}
+	if !metav1.IsControlledBy(&caSecret, &issuer) {
+		return nil, fmt.Errorf("CA Secret %s is not managed by issuer SecretClaim %s", caSecret.Name, issuerName)
+	}
+
+	pair, err := certs.ParseKeyPair(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA key pair in issuer %s: %w", issuerName, err)
+	}
+	if !pair.Certificate.IsCA {
+		return nil, fmt.Errorf("issuer %s does not hold a CA certificate", issuerName)
+	}
+	return pair, nil
+}
+
+// issueCertificate issues a new certificate for the claim and returns the Secret
+// data holding it. The claim status records its validity.
+func (r *SecretClaimReconciler) issueCertificate(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (map[string][]byte, error) {
+	issuer, err := r.loadIssuer(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, keyPEM, err := certs.Issue(certificateRequest(claim.Spec.Certificate), issuer, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate: %w", err)
+	}
+
+	caPEM := certPEM
+	if issuer != nil {
+		caPEM = issuer.CertPEM
+	}
+
+	if err := markCertificateIssued(claim, certPEM); err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		caCertKey:               caPEM,
+	}, nil
+}
+
+// certificateRenewalReason explains why the certificate stored in secret has to be
+// re-issued, or returns "" while it is still good.
+func (r *SecretClaimReconciler) certificateRenewalReason(ctx context.Context, claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret, now time.Time) (string, error) {
+	cert, err := certs.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return "stored certificate is missing or invalid", nil
+	}
+
+	if !certs.Matches(cert, certificateRequest(claim.Spec.Certificate)) {
+		return "certificate spec changed", nil
+	}
+
+	issuer, err := r.loadIssuer(ctx, claim)
+	if err != nil {
+		return "", err
+	}
+	if issuer != nil && !bytes.Equal(secret.Data[caCertKey], issuer.CertPEM) {
+		return "issuer CA changed", nil
+	}
+
+	if !now.Before(renewalTime(claim.Spec.Certificate, cert.NotBefore, cert.NotAfter)) {
+		return "certificate is due for renewal", nil
+	}
+	return "", nil
+}
+
+// claimsIssuedBy maps an issuer claim to the Certificate claims it signs, so they
+// are re-issued when the CA changes.
+func (r *SecretClaimReconciler) claimsIssuedBy(ctx context.Context, obj client.Object) []reconcile.Request {
+	var claims secretsv1alpha1.SecretClaimList
+	if err := r.List(ctx, &claims, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, claim := range claims.Items {
+		if claim.Spec.Certificate != nil && claim.Spec.Certificate.IssuerRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&claim)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+)
+
+// ClusterSecretClaimReconciler reconciles a ClusterSecretClaim object. It keeps a
+// SecretClaim of the same name in Namespace and mirrors its status; generating,
+// rotating and replicating the Secret is left to the SecretClaimReconciler.
+type ClusterSecretClaimReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Tracer trace.Tracer
+	Log    *slog.Logger
+
+	// Namespace holds the SecretClaims and source Secrets of all ClusterSecretClaims.
+	Namespace string
+}
+
+// +kubebuilder:rbac:groups=secrets.myapp.io,resources=clustersecretclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secrets.myapp.io,resources=clustersecretclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secrets.myapp.io,resources=clustersecretclaims/finalizers,verbs=update
+
+// Reconcile creates or updates the SecretClaim backing the ClusterSecretClaim. The
+// SecretClaim is owned by the ClusterSecretClaim, so deleting the ClusterSecretClaim
+// garbage collects it and its finalizer applies the deletion policy to the replicas.
+func (r *ClusterSecretClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With(slog.String("name", req.Name))
+
+	if r.Tracer == nil {
+		r.Tracer = otel.Tracer("k8s-secret-manager")
+	}
+
+	ctx, span := r.Tracer.Start(ctx, "ClusterSecretClaimReconciler.Reconcile",
+		trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(attribute.String("claim.source_namespace", r.Namespace))
+
+	ctx = context.WithValue(ctx, observability.LoggerContextKey, logger)
+	logger.Info("Starting reconciliation cycle.")
+
+	var cluster secretsv1alpha1.ClusterSecretClaim
+	if err := r.Get(ctx, req.NamespacedName, &cluster); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error("Failed to fetch ClusterSecretClaim", slog.Any("error", err))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Get ClusterSecretClaim Failed")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		logger.Info("ClusterSecretClaim is being deleted, SecretClaim is garbage collected.")
+		span.SetStatus(codes.Ok, "Deleting")
+		return ctrl.Result{}, nil
+	}
+
+	if err := validateClusterTargets(cluster.Spec.Targets); err != nil {
+		logger.Error("Invalid ClusterSecretClaim spec", slog.Any("error", err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid Spec")
+		r.updateFailedStatus(ctx, &cluster, "InvalidSpec", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	var claim secretsv1alpha1.SecretClaim
+	err := r.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: r.Namespace}, &claim)
+	switch {
+	case errors.IsNotFound(err):
+		logger.Info("SecretClaim not found, creating it.", slog.String("namespace", r.Namespace))
+		claim = secretsv1alpha1.SecretClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: r.Namespace},
+		}
+		desiredClaim(&cluster, &claim)
+		if err := ctrl.SetControllerReference(&cluster, &claim, r.Scheme); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Controller Reference Failed")
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, &claim); err != nil {
+			return r.claimWriteFailed(ctx, &cluster, span, "CreateFailed", err)
+		}
+	case err != nil:
+		logger.Error("Failed to get SecretClaim", slog.Any("error", err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Get SecretClaim Failed")
+		return ctrl.Result{}, err
+	case !metav1.IsControlledBy(&claim, &cluster):
+		msg := fmt.Sprintf("secretclaim %s/%s already exists and is not managed by this ClusterSecretClaim", r.Namespace, cluster.Name)
+		logger.Warn("SecretClaim exists but is not controlled by ClusterSecretClaim. Skipping.", slog.String("namespace", r.Namespace))
+		span.SetStatus(codes.Error, "SecretClaim Not Owned")
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               secretsv1alpha1.ConditionOwnershipConflict,
+			Status:             metav1.ConditionTrue,
+			Reason:             "SecretClaimNotOwned",
+			Message:            msg,
+			ObservedGeneration: cluster.Generation,
+		})
+		r.updateFailedStatus(ctx, &cluster, "SecretClaimNotOwned", msg)
+		return ctrl.Result{}, nil
+	default:
+		before := claim.DeepCopy()
+		desiredClaim(&cluster, &claim)
+		if !equality.Semantic.DeepEqual(before, &claim) {
+			logger.Info("ClusterSecretClaim changed, updating SecretClaim.", slog.String("namespace", r.Namespace))
+			if err := r.Update(ctx, &claim); err != nil {
+				return r.claimWriteFailed(ctx, &cluster, span, "UpdateFailed", err)
+			}
+		}
+	}
+
+	status := mirroredStatus(&cluster, &claim, r.Namespace)
+	if !equality.Semantic.DeepEqual(cluster.Status, status) {
+		cluster.Status = status
+		if err := r.Status().Update(ctx, &cluster); err != nil {
+			logger.Error("Failed to update status", slog.Any("error", err))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Status Update Failed")
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.Info("Reconciliation complete")
+	span.SetStatus(codes.Ok, "Reconciliation successful")
+	return ctrl.Result{}, nil
+}
+
+// claimWriteFailed reports a failed create or update of the SecretClaim. Specs the
+// SecretClaim webhook rejects are not retried until the ClusterSecretClaim changes.
+func (r *ClusterSecretClaimReconciler) claimWriteFailed(ctx context.Context, cluster *secretsv1alpha1.ClusterSecretClaim, span trace.Span, reason string, err error) (ctrl.Result, error) {
+	observability.LoggerFromContext(ctx).Error("Failed to write SecretClaim", slog.String("reason", reason), slog.Any("error", err))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "K8s Write SecretClaim Failed")
+	if errors.IsInvalid(err) || errors.IsForbidden(err) {
+		r.updateFailedStatus(ctx, cluster, "InvalidSpec", err.Error())
+		return ctrl.Result{}, nil
+	}
+	r.updateFailedStatus(ctx, cluster, reason, err.Error())
+	return ctrl.Result{}, err
+}
+
+// updateFailedStatus marks the ClusterSecretClaim as not ready without touching the
+// status mirrored from the SecretClaim.
+func (r *ClusterSecretClaimReconciler) updateFailedStatus(ctx context.Context, cluster *secretsv1alpha1.ClusterSecretClaim, reason, msg string) {
+	logger := observability.LoggerFromContext(ctx)
+
+	cluster.Status.Synced = false
+	cluster.Status.ErrorMessage = msg
+	cluster.Status.ObservedGeneration = cluster.Generation
+	cluster.Status.SourceNamespace = r.Namespace
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               secretsv1alpha1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: cluster.Generation,
+	})
+
+	if err := r.Status().Update(ctx, cluster); err != nil {
+		logger.Error("Failed to update status", slog.Any("error", err))
+	}
+}
+
+// validateClusterTargets checks that a ClusterSecretClaim fans out to at least one
+// namespace; without targets its Secret would only exist in the controller namespace.
+func validateClusterTargets(cfg *secretsv1alpha1.TargetsConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("targets are required for ClusterSecretClaim")
+	}
+	return validateTargets(cfg)
+}
+
+// desiredClaim copies the spec and metadata of the ClusterSecretClaim to its SecretClaim.
+func desiredClaim(cluster *secretsv1alpha1.ClusterSecretClaim, claim *secretsv1alpha1.SecretClaim) {
+	claim.Labels = cluster.Labels
+	claim.Annotations = cluster.Annotations
+	claim.Spec = *cluster.Spec.DeepCopy()
+}
+
+// mirroredStatus is the status of the SecretClaim as seen on the ClusterSecretClaim.
+// Generations are translated so ObservedGeneration only catches up with the
+// ClusterSecretClaim once the SecretClaim has reconciled the copied spec.
+func mirroredStatus(cluster *secretsv1alpha1.ClusterSecretClaim, claim *secretsv1alpha1.SecretClaim, namespace string) secretsv1alpha1.ClusterSecretClaimStatus {
+	status := secretsv1alpha1.ClusterSecretClaimStatus{
+		SecretClaimStatus: *claim.Status.DeepCopy(),
+		SourceNamespace:   namespace,
+	}
+
+	observed := cluster.Status.ObservedGeneration
+	if claim.Status.ObservedGeneration == claim.Generation && equality.Semantic.DeepEqual(claim.Spec, cluster.Spec) {
+		observed = cluster.Generation
+	}
+	status.ObservedGeneration = observed
+	for i := range status.Conditions {
+		status.Conditions[i].ObservedGeneration = observed
+	}
+	return status
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterSecretClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Namespace == "" {
+		return fmt.Errorf("namespace for ClusterSecretClaim sources is not set")
+	}
+	if r.Log == nil {
+		r.Log = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	}
+	if r.Tracer == nil {
+		r.Tracer = otel.Tracer("k8s-secret-manager")
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretsv1alpha1.ClusterSecretClaim{}).
+		Named("clustersecretclaim").
+		Owns(&secretsv1alpha1.SecretClaim{}).
+		Complete(r)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+)
+
+var _ = Describe("ClusterSecretClaim Controller", func() {
+	const (
+		resourceName    = "shared-token"
+		sourceNamespace = "default"
+	)
+
+	var (
+		ctx        context.Context
+		reconciler *ClusterSecretClaimReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		reconciler = &ClusterSecretClaimReconciler{
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Tracer:    otel.Tracer("test"),
+			Log:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+			Namespace: sourceNamespace,
+		}
+	})
+
+	It("should keep a SecretClaim in the controller namespace in sync with the ClusterSecretClaim", func() {
+		cluster := &secretsv1alpha1.ClusterSecretClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+			Spec: secretsv1alpha1.SecretClaimSpec{
+				Type: "Opaque",
+				Data: map[string]string{"token": "v1"},
+				Targets: &secretsv1alpha1.TargetsConfig{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, cluster)
+			_ = k8sClient.Delete(ctx, &secretsv1alpha1.SecretClaim{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: sourceNamespace}})
+		})
+
+		key := types.NamespacedName{Name: resourceName}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		var claim secretsv1alpha1.SecretClaim
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: sourceNamespace}, &claim)).To(Succeed())
+		Expect(metav1.IsControlledBy(&claim, cluster)).To(BeTrue())
+		Expect(claim.Spec.Data).To(HaveKeyWithValue("token", "v1"))
+		Expect(claim.Spec.Targets).To(Equal(cluster.Spec.Targets))
+
+		Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+		cluster.Spec.Data = map[string]string{"token": "v2"}
+		Expect(k8sClient.Update(ctx, cluster)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: sourceNamespace}, &claim)).To(Succeed())
+		Expect(claim.Spec.Data).To(HaveKeyWithValue("token", "v2"))
+
+		Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+		Expect(cluster.Status.SourceNamespace).To(Equal(sourceNamespace))
+	})
+
+	It("should report a ClusterSecretClaim without targets", func() {
+		cluster := &secretsv1alpha1.ClusterSecretClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName + "-invalid"},
+			Spec:       secretsv1alpha1.SecretClaimSpec{Type: "Opaque", Data: map[string]string{"token": "v1"}},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, cluster) })
+
+		key := types.NamespacedName{Name: cluster.Name}
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, key, cluster)).To(Succeed())
+		Expect(cluster.Status.Synced).To(BeFalse())
+		ready := meta.FindStatusCondition(cluster.Status.Conditions, secretsv1alpha1.ConditionReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("InvalidSpec"))
+	})
+})
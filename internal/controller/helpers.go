@@ -1,63 +1,485 @@
 package controller
 
 import (
-	"crypto/rand"
-	"math/big"
-	"strings"
-)
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
 
-const (
-	EncodingDigits       = "digits"
-	EncodingAlphanumeric = "alphanumeric"
-	EncodingSymbols      = "symbols"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
 
-	charsetDigits  = "0123456789"
-	charsetLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	charsetSymbols = "!@#$%^&*()-_=+[]{}|;:,.<>?/"
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/certs"
+	"github.com/mogilyoy/k8s-secret-manager/internal/datahash"
+	"github.com/mogilyoy/k8s-secret-manager/internal/keygen"
+	"github.com/mogilyoy/k8s-secret-manager/internal/secrettypes"
+	"github.com/mogilyoy/k8s-secret-manager/internal/templates"
 )
 
-func generatePassword(length int, encoding string) (string, error) {
+func needsUpdate(claim map[string]string, secret map[string][]byte) bool {
+	if len(claim) != len(secret) {
+		return true
+	}
 
-	var charset string
+	for k, v := range claim {
+		secretValue, exists := secret[k]
+		if !exists || string(secretValue) != v {
+			return true
+		}
+	}
 
-	switch strings.ToLower(encoding) {
-	case EncodingDigits:
-		charset = charsetDigits
-	case EncodingAlphanumeric:
-		charset = charsetDigits + charsetLetters
-	case EncodingSymbols:
-		charset = charsetDigits + charsetLetters + charsetSymbols
-	default:
-		charset = charsetDigits + charsetLetters
+	return false
+}
+
+// errHashedDataMissing is returned when the Secret lacks a value the claim only
+// keeps the hash of. The API server writes these values to the Secret after
+// creating the claim.
+var errHashedDataMissing = errors.New("hashed data is not in the Secret yet")
+
+// errDataHashMismatch is returned when a Secret value does not match its hash in
+// the claim. The controller cannot restore such a value.
+var errDataHashMismatch = errors.New("secret value does not match its hash")
+
+// hashedData returns the Secret values of the hashed keys after checking them
+// against their hashes, so the plaintext never has to be in the claim.
+func hashedData(hashes map[string]string, secret map[string][]byte) (map[string]string, error) {
+	data := make(map[string]string, len(hashes))
+	for _, key := range slices.Sorted(maps.Keys(hashes)) {
+		value, ok := secret[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s: %w", key, errHashedDataMissing)
+		}
+		if !datahash.Verify(hashes[key], value) {
+			return nil, fmt.Errorf("key %s: %w", key, errDataHashMismatch)
+		}
+		data[key] = string(value)
 	}
+	return data, nil
+}
+
+// isHashedDataError reports whether err means the Secret does not hold the
+// hashed values of the claim.
+func isHashedDataError(err error) bool {
+	return errors.Is(err, errHashedDataMissing) || errors.Is(err, errDataHashMismatch)
+}
+
+// withoutKeys returns a copy of data without the keys present in exclude.
+func withoutKeys(data map[string][]byte, exclude map[string]string) map[string][]byte {
+	if len(exclude) == 0 {
+		return data
+	}
+	result := make(map[string][]byte, len(data))
+	for k, v := range data {
+		if _, ok := exclude[k]; !ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// sameKeys reports whether data holds exactly the given keys.
+func sameKeys(keys []string, data map[string][]byte) bool {
+	unique := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		if _, ok := data[k]; !ok {
+			return false
+		}
+		unique[k] = struct{}{}
+	}
+	return len(unique) == len(data)
+}
 
-	result := make([]byte, length)
-	charsetLength := big.NewInt(int64(len(charset)))
+// changedKeyTriggers returns the data keys whose per-key trigger differs from
+// the one applied on the last successful sync.
+func changedKeyTriggers(claim *secretsv1alpha1.SecretClaim) []string {
+	if claim.Spec.Generation == nil {
+		return nil
+	}
 
-	for i := 0; i < length; i++ {
-		num, err := rand.Int(rand.Reader, charsetLength)
+	var keys []string
+	for _, key := range claim.Spec.Generation.DataKeys {
+		trigger := claim.Spec.Generation.KeyTriggers[key]
+		if trigger != "" && trigger != claim.Status.LastKeyTriggers[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// keyFormat returns the generation format of a data key; "" means password.
+func keyFormat(gen *secretsv1alpha1.GenerationConfig, key string) string {
+	if format := gen.Keys[key].Format; format != keygen.FormatPassword {
+		return format
+	}
+	return ""
+}
+
+// keyFormats returns the non-password formats of the generated data keys.
+func keyFormats(gen *secretsv1alpha1.GenerationConfig) map[string]string {
+	var formats map[string]string
+	for _, key := range gen.DataKeys {
+		if format := keyFormat(gen, key); format != "" {
+			if formats == nil {
+				formats = make(map[string]string)
+			}
+			formats[key] = format
+		}
+	}
+	return formats
+}
+
+// generatedKeys returns the data keys of an AutoGenerated claim together with the
+// public keys written next to its key pairs.
+func generatedKeys(gen *secretsv1alpha1.GenerationConfig) []string {
+	keys := slices.Clone(gen.DataKeys)
+	for _, key := range gen.DataKeys {
+		if keygen.IsKeyPair(keyFormat(gen, key)) {
+			keys = append(keys, keygen.PublicKey(key))
+		}
+	}
+	return keys
+}
+
+// changedKeyFormats returns the data keys whose format differs from the one they
+// were last generated with.
+func changedKeyFormats(claim *secretsv1alpha1.SecretClaim) []string {
+	if claim.Spec.Generation == nil {
+		return nil
+	}
+
+	var keys []string
+	for _, key := range claim.Spec.Generation.DataKeys {
+		if keyFormat(claim.Spec.Generation, key) != claim.Status.LastKeyFormats[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// passwordPolicy returns the password settings of a data key. Length also sets
+// the size of random-bytes keys.
+func passwordPolicy(gen *secretsv1alpha1.GenerationConfig, key string) keygen.PasswordPolicy {
+	policy := keyPolicy(gen.Keys[key])
+	if policy.Length == 0 {
+		policy.Length = gen.Length
+	}
+	if policy.Charset == "" {
+		policy.Charset = keygen.Charset(gen.Encoding)
+	}
+	return policy
+}
+
+// keyPolicy returns the password policy fields set on a single key.
+func keyPolicy(cfg secretsv1alpha1.KeyGeneration) keygen.PasswordPolicy {
+	return keygen.PasswordPolicy{
+		Length:           cfg.Length,
+		Charset:          cfg.Charset,
+		MinDigits:        cfg.MinDigits,
+		MinSymbols:       cfg.MinSymbols,
+		MinUppercase:     cfg.MinUppercase,
+		ExcludeAmbiguous: cfg.ExcludeAmbiguous,
+		Prefix:           cfg.Prefix,
+	}
+}
+
+// generatePassword builds the password of a data key from its policy.
+func generatePassword(gen *secretsv1alpha1.GenerationConfig, key string) (string, error) {
+	return keygen.Password(passwordPolicy(gen, key))
+}
+
+// generateKey generates the value of a data key and, for key pairs, its public key.
+func generateKey(gen *secretsv1alpha1.GenerationConfig, key string) (map[string][]byte, error) {
+	format := keyFormat(gen, key)
+	if format == "" {
+		password, err := generatePassword(gen, key)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+		return map[string][]byte{key: []byte(password)}, nil
+	}
+
+	private, public, err := keygen.Generate(format, gen.Keys[key].Bits, passwordPolicy(gen, key).Length)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string][]byte{key: private}
+	if public != nil {
+		values[keygen.PublicKey(key)] = public
+	}
+	return values, nil
+}
 
-		result[i] = charset[num.Int64()]
+// nextRotationTime returns the first rotation moment strictly after from.
+func nextRotationTime(rotation *secretsv1alpha1.RotationConfig, from time.Time) (time.Time, error) {
+	if rotation.Schedule != "" {
+		schedule, err := cron.ParseStandard(rotation.Schedule)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid rotation schedule %q: %w", rotation.Schedule, err)
+		}
+		return schedule.Next(from), nil
 	}
 
-	return string(result), nil
+	if rotation.Interval != nil && rotation.Interval.Duration > 0 {
+		return from.Add(rotation.Interval.Duration), nil
+	}
 
+	return time.Time{}, fmt.Errorf("rotation requires either a positive interval or a cron schedule")
 }
 
-func needsUpdate(claim map[string]string, secret map[string][]byte) bool {
-	if len(claim) != len(secret) {
-		return true
+// markRotated records that the claim's secret values were regenerated at now
+// and, if the claim has a rotation policy, when they are due next.
+func markRotated(claim *secretsv1alpha1.SecretClaim, now time.Time) {
+	last := metav1.NewTime(now)
+	claim.Status.LastRotationTime = &last
+	claim.Status.NextRotationTime = nil
+
+	if claim.Spec.Rotation == nil {
+		return
+	}
+	if next, err := nextRotationTime(claim.Spec.Rotation, now); err == nil {
+		nextTime := metav1.NewTime(next)
+		claim.Status.NextRotationTime = &nextTime
 	}
+}
 
-	for k, v := range claim {
-		secretValue, exists := secret[k]
-		if !exists || string(secretValue) != v {
-			return true
+// requeueForRotation asks for the next reconcile at the claim's next rotation time.
+func requeueForRotation(claim *secretsv1alpha1.SecretClaim) ctrl.Result {
+	if claim.Status.NextRotationTime == nil {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: max(time.Until(claim.Status.NextRotationTime.Time), time.Second)}
+}
+
+// validateClaimSpec checks the parts of the spec the controller cannot act on.
+func validateClaimSpec(claim *secretsv1alpha1.SecretClaim) error {
+	var dataKeys []string
+	switch claim.Spec.Type {
+	case "Opaque":
+		for k := range claim.Spec.Data {
+			dataKeys = append(dataKeys, k)
+		}
+		for k := range claim.Spec.EncryptedData {
+			if _, ok := claim.Spec.Data[k]; ok {
+				return fmt.Errorf("key %s is set in both data and encryptedData", k)
+			}
+			dataKeys = append(dataKeys, k)
+		}
+		for k := range claim.Spec.DataHashes {
+			_, inData := claim.Spec.Data[k]
+			_, inEncryptedData := claim.Spec.EncryptedData[k]
+			if inData || inEncryptedData {
+				return fmt.Errorf("key %s is hashed and set in data or encryptedData", k)
+			}
+			dataKeys = append(dataKeys, k)
+		}
+	case "AutoGenerated":
+		if claim.Spec.Generation == nil {
+			return fmt.Errorf("generationConfig spec is nil for AutoGenerated claim")
+		}
+		if claim.Spec.Generation.Length < 8 {
+			return fmt.Errorf("secrets should be at least 8 symbols")
+		}
+		for key, cfg := range claim.Spec.Generation.Keys {
+			if !slices.Contains(claim.Spec.Generation.DataKeys, key) {
+				return fmt.Errorf("generation format for key %s must reference one of dataKeys", key)
+			}
+			if err := keygen.Validate(cfg.Format, cfg.Bits); err != nil {
+				return fmt.Errorf("key %s: %w", key, err)
+			}
+			if keygen.IsKeyPair(cfg.Format) && slices.Contains(claim.Spec.Generation.DataKeys, keygen.PublicKey(key)) {
+				return fmt.Errorf("data key %s is reserved for the public key of %s", keygen.PublicKey(key), key)
+			}
+			if cfg.Length != 0 && cfg.Length < 8 {
+				return fmt.Errorf("key %s: secrets should be at least 8 symbols", key)
+			}
+			if keyFormat(claim.Spec.Generation, key) != "" {
+				if keyPolicy(cfg).HasRules() {
+					return fmt.Errorf("key %s: password policy is only supported for the password format", key)
+				}
+				continue
+			}
+			if err := passwordPolicy(claim.Spec.Generation, key).Validate(); err != nil {
+				return fmt.Errorf("key %s: %w", key, err)
+			}
+		}
+		dataKeys = generatedKeys(claim.Spec.Generation)
+	case "Certificate":
+		if claim.Spec.Certificate == nil {
+			return fmt.Errorf("certificate spec is nil for Certificate claim")
+		}
+		if err := certs.ValidateRequest(certificateRequest(claim.Spec.Certificate)); err != nil {
+			return err
+		}
+		if claim.Spec.Certificate.IssuerRef == claim.Name {
+			return fmt.Errorf("certificate cannot be issued by its own claim")
 		}
+		if claim.Spec.SecretType != "" && claim.Spec.SecretType != string(corev1.SecretTypeTLS) {
+			return fmt.Errorf("certificate claims always create %s secrets", corev1.SecretTypeTLS)
+		}
+		dataKeys = certificateKeys
+	case "External":
+		if err := validateSource(claim.Spec.Source); err != nil {
+			return err
+		}
+		if len(claim.Spec.Source.Keys) == 0 && secrettypes.SecretType(claim.Spec.SecretType) != corev1.SecretTypeOpaque {
+			return fmt.Errorf("source keys must list the Secret keys of %s secrets", claim.Spec.SecretType)
+		}
+		dataKeys = sourceKeys(claim.Spec.Source)
+	default:
+		return fmt.Errorf("unknown claim type: %s", claim.Spec.Type)
+	}
+	if len(claim.Spec.EncryptedData) > 0 && claim.Spec.Type != "Opaque" {
+		return fmt.Errorf("encryptedData is only supported for Opaque claims")
+	}
+	if len(claim.Spec.DataHashes) > 0 && claim.Spec.Type != "Opaque" {
+		return fmt.Errorf("dataHashes is only supported for Opaque claims")
 	}
 
-	return false
+	keys := slices.Concat(dataKeys, slices.Collect(maps.Keys(claim.Spec.Templates)))
+	if claim.Spec.Type == "AutoGenerated" && !secrettypes.Generatable(claim.Spec.SecretType, keyFormats(claim.Spec.Generation)) {
+		return fmt.Errorf("secret type %s cannot be generated with the configured key formats, use an Opaque claim", claim.Spec.SecretType)
+	}
+	if err := secrettypes.Validate(string(desiredSecretType(claim)), keys, dockerConfigOf(claim)); err != nil {
+		return err
+	}
+
+	if claim.Spec.Rotation != nil {
+		if claim.Spec.Type != "AutoGenerated" {
+			return fmt.Errorf("rotation is only supported for AutoGenerated claims")
+		}
+		if _, err := nextRotationTime(claim.Spec.Rotation, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	switch claim.Spec.DeletionPolicy {
+	case "", secretsv1alpha1.DeletionPolicyDelete, secretsv1alpha1.DeletionPolicyRetain, secretsv1alpha1.DeletionPolicyOrphan:
+	default:
+		return fmt.Errorf("unknown deletion policy: %s", claim.Spec.DeletionPolicy)
+	}
+
+	if err := validateTargets(claim.Spec.Targets); err != nil {
+		return err
+	}
+
+	if err := validatePush(claim); err != nil {
+		return err
+	}
+
+	return templates.Validate(claim.Spec.Templates, dataKeys)
+}
+
+// desiredSecretType is the type of the Secret created for the claim.
+func desiredSecretType(claim *secretsv1alpha1.SecretClaim) corev1.SecretType {
+	if claim.Spec.Type == "Certificate" {
+		return corev1.SecretTypeTLS
+	}
+	return secrettypes.SecretType(claim.Spec.SecretType)
+}
+
+// stagedSecretPrefix is the GenerateName of the copy kept while the Secret name
+// is replaced for a type change.
+func stagedSecretPrefix(name string) string {
+	const maxPrefix = validation.DNS1123SubdomainMaxLength - 6
+	if len(name) > maxPrefix {
+		name = name[:maxPrefix]
+	}
+	return name + "-"
+}
+
+// dockerConfigOf converts the claim's DockerConfig for the secrettypes package.
+func dockerConfigOf(claim *secretsv1alpha1.SecretClaim) *secrettypes.DockerConfig {
+	if claim.Spec.DockerConfig == nil {
+		return nil
+	}
+	return &secrettypes.DockerConfig{
+		Registry: claim.Spec.DockerConfig.Registry,
+		Username: claim.Spec.DockerConfig.Username,
+	}
+}
+
+// derivedKeys returns the Secret keys computed from other values: templated keys
+// and .dockerconfigjson. They are left out when comparing data with the spec.
+func derivedKeys(claim *secretsv1alpha1.SecretClaim) map[string]string {
+	if claim.Spec.DockerConfig == nil {
+		return claim.Spec.Templates
+	}
+	keys := maps.Clone(claim.Spec.Templates)
+	if keys == nil {
+		keys = make(map[string]string, 1)
+	}
+	keys[corev1.DockerConfigJsonKey] = ""
+	return keys
+}
+
+// renderTypedKeys adds the keys required by the claim's secret type that are
+// rendered by the controller rather than set or generated.
+func renderTypedKeys(claim *secretsv1alpha1.SecretClaim, data map[string][]byte) error {
+	docker := dockerConfigOf(claim)
+	if docker == nil {
+		return nil
+	}
+	config, err := secrettypes.DockerConfigJSON(*docker, data)
+	if err != nil {
+		return err
+	}
+	data[corev1.DockerConfigJsonKey] = config
+	return nil
+}
+
+// typedSecretOutdated reports whether the Secret has the wrong type or a stale
+// .dockerconfigjson for the claim.
+func typedSecretOutdated(claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret) bool {
+	if secret.Type != desiredSecretType(claim) {
+		return true
+	}
+	docker := dockerConfigOf(claim)
+	if docker == nil {
+		return false
+	}
+	config, err := secrettypes.DockerConfigJSON(*docker, secret.Data)
+	return err != nil || !bytes.Equal(config, secret.Data[corev1.DockerConfigJsonKey])
+}
+
+// releaseSecret drops the claim's owner reference from the Secret so the garbage
+// collector keeps it after the claim is gone.
+func releaseSecret(secret *corev1.Secret, claim *secretsv1alpha1.SecretClaim) {
+	secret.OwnerReferences = slices.DeleteFunc(secret.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.UID == claim.UID
+	})
+	if len(secret.OwnerReferences) == 0 {
+		secret.OwnerReferences = nil
+	}
+}
+
+// setCondition sets a status condition stamped with the claim's current generation
+// and reports whether it changed.
+func setCondition(claim *secretsv1alpha1.SecretClaim, conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&claim.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: claim.Generation,
+	})
+}
+
+// readyFailureReason picks the reason for Ready=False from the first failing condition.
+func readyFailureReason(claim *secretsv1alpha1.SecretClaim) string {
+	if c := meta.FindStatusCondition(claim.Status.Conditions, secretsv1alpha1.ConditionOwnershipConflict); c != nil && c.Status == metav1.ConditionTrue {
+		return c.Reason
+	}
+	for _, conditionType := range []string{secretsv1alpha1.ConditionGenerationValid, secretsv1alpha1.ConditionSecretCreated} {
+		if c := meta.FindStatusCondition(claim.Status.Conditions, conditionType); c != nil && c.Status == metav1.ConditionFalse {
+			return c.Reason
+		}
+	}
+	return "ReconcileFailed"
 }
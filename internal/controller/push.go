@@ -0,0 +1,256 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/datahash"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sources"
+)
+
+// errPushConflict is reported when the store holds values the claim did not push.
+var errPushConflict = errors.New("values in the push store were changed outside of the claim")
+
+// validatePush checks the push settings of the claim.
+func validatePush(claim *secretsv1alpha1.SecretClaim) error {
+	cfg := claim.Spec.Push
+	if cfg == nil {
+		return nil
+	}
+	if claim.Spec.Type == "External" {
+		return fmt.Errorf("push is not supported for External claims")
+	}
+	if !slices.Contains(sources.PushSupported, cfg.Provider) {
+		return fmt.Errorf("unsupported push provider: %s", cfg.Provider)
+	}
+	if cfg.Path == "" {
+		return fmt.Errorf("push path must not be empty")
+	}
+	if cfg.Provider == sources.ProviderVault && cfg.Address == "" {
+		return fmt.Errorf("vault push requires an address")
+	}
+	switch cfg.ConflictPolicy {
+	case "", secretsv1alpha1.PushConflictFail, secretsv1alpha1.PushConflictOverwrite:
+	default:
+		return fmt.Errorf("unknown push conflict policy: %s", cfg.ConflictPolicy)
+	}
+	for key, storeKey := range cfg.Keys {
+		if key == "" || storeKey == "" {
+			return fmt.Errorf("push keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// pushData returns the data written to the store: every key of the Secret, or
+// the keys mapped in Push.Keys under their names in the store.
+func pushData(cfg *secretsv1alpha1.PushConfig, secret *corev1.Secret) (map[string]string, error) {
+	if len(cfg.Keys) == 0 {
+		data := make(map[string]string, len(secret.Data))
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
+		return data, nil
+	}
+
+	data := make(map[string]string, len(cfg.Keys))
+	for key, storeKey := range cfg.Keys {
+		value, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("secret has no key %s to push", key)
+		}
+		data[storeKey] = string(value)
+	}
+	return data, nil
+}
+
+// pushChecksum identifies data together with the location it is pushed to, so
+// moving the claim to another store or path pushes again.
+func pushChecksum(cfg *secretsv1alpha1.PushConfig, data map[string]string) string {
+	return sources.Checksum(map[string]string{
+		"provider": cfg.Provider,
+		"address":  cfg.Address,
+		"mount":    cfg.Mount,
+		"path":     cfg.Path,
+		"data":     sources.Checksum(data),
+	})
+}
+
+// pushedLast reports whether checksum, the salted hash kept in the status,
+// records data pushed to the location of cfg. The status only holds salted
+// hashes so that readers of the claim cannot look the pushed values up;
+// checksums recorded before are plain pushChecksum values.
+func pushedLast(checksum string, cfg *secretsv1alpha1.PushConfig, data map[string]string) bool {
+	sum := pushChecksum(cfg, data)
+	if datahash.Check(checksum) != nil {
+		return checksum == sum
+	}
+	return datahash.Verify(checksum, []byte(sum))
+}
+
+// pushProvider builds the writable provider of the claim's push store.
+func (r *SecretClaimReconciler) pushProvider(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (sources.SecretPushProvider, error) {
+	cfg := claim.Spec.Push
+	token, err := r.credentialsToken(ctx, claim.Namespace, cfg.CredentialsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sources.NewPusher(sources.Config{
+		Provider:              cfg.Provider,
+		Address:               cfg.Address,
+		Mount:                 cfg.Mount,
+		Token:                 token,
+		Client:                r.SourceClient,
+		HTTPAllowedURLs:       r.HTTPSourceURLs,
+		VaultAllowedAddresses: r.VaultAddresses,
+	})
+}
+
+// syncPush writes the Secret data to the claim's push store when it changed since
+// the last successful push and records the result in the claim status. Keys in
+// the store that differ from the ones pushed last were changed by someone else:
+// with the Fail policy they are left alone and the conflict is reported, with
+// Overwrite they are replaced. It reports whether the status changed; the error
+// is returned for failed writes so the push is retried.
+func (r *SecretClaimReconciler) syncPush(ctx context.Context, claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret) (bool, error) {
+	cfg := claim.Spec.Push
+	if cfg == nil {
+		changed := claim.Status.Push != nil
+		claim.Status.Push = nil
+		return meta.RemoveStatusCondition(&claim.Status.Conditions, secretsv1alpha1.ConditionPushSynced) || changed, nil
+	}
+
+	logger := observability.LoggerFromContext(ctx)
+
+	ctx, span := r.Tracer.Start(ctx, "SecretClaimReconciler.syncPush")
+	defer span.End()
+	span.SetAttributes(attribute.String("claim.push.provider", cfg.Provider))
+
+	previous := claim.Status.Push.DeepCopy()
+	status := secretsv1alpha1.PushStatus{}
+	if previous != nil {
+		status.Version = previous.Version
+		status.Checksum = previous.Checksum
+		status.LastPushTime = previous.LastPushTime
+	}
+
+	data, err := pushData(cfg, secret)
+	if err == nil {
+		if previous != nil && previous.Result == secretsv1alpha1.PushResultPushed && pushedLast(previous.Checksum, cfg, data) {
+			span.SetStatus(codes.Ok, "Push Up To Date")
+			return false, nil
+		}
+		status, err = r.push(ctx, claim, data, status)
+	}
+
+	var pushErr error
+	switch {
+	case errors.Is(err, errPushConflict):
+		logger.Warn("Push store holds values changed outside of the claim. Skipping push.", slog.String("path", cfg.Path))
+		status.Result = secretsv1alpha1.PushResultConflict
+		status.Message = err.Error()
+	case err != nil:
+		logger.Error("Failed to push Secret data", slog.String("path", cfg.Path), slog.Any("error", err))
+		status.Result = secretsv1alpha1.PushResultFailed
+		status.Message = err.Error()
+		pushErr = err
+	default:
+		status.Result = secretsv1alpha1.PushResultPushed
+		status.Message = ""
+	}
+
+	changed := !equality.Semantic.DeepEqual(claim.Status.Push, &status)
+	claim.Status.Push = &status
+
+	switch status.Result {
+	case secretsv1alpha1.PushResultPushed:
+		msg := fmt.Sprintf("Secret data is pushed to %s %s", cfg.Provider, cfg.Path)
+		changed = setCondition(claim, secretsv1alpha1.ConditionPushSynced, metav1.ConditionTrue, "Pushed", msg) || changed
+	case secretsv1alpha1.PushResultConflict:
+		changed = setCondition(claim, secretsv1alpha1.ConditionPushSynced, metav1.ConditionFalse, "PushConflict", status.Message) || changed
+	default:
+		changed = setCondition(claim, secretsv1alpha1.ConditionPushSynced, metav1.ConditionFalse, "PushFailed", status.Message) || changed
+	}
+
+	if pushErr != nil {
+		span.RecordError(pushErr)
+		span.SetStatus(codes.Error, "Push Failed")
+		return changed, pushErr
+	}
+	span.SetStatus(codes.Ok, "Push Synced")
+	return changed, nil
+}
+
+// push reads the current secret from the store, checks it for conflicts and
+// writes data over it, keeping the keys the claim does not push. status carries
+// the previous push and is returned updated on success.
+func (r *SecretClaimReconciler) push(ctx context.Context, claim *secretsv1alpha1.SecretClaim, data map[string]string, status secretsv1alpha1.PushStatus) (secretsv1alpha1.PushStatus, error) {
+	logger := observability.LoggerFromContext(ctx)
+	cfg := claim.Spec.Push
+	checksum, err := datahash.Sum([]byte(pushChecksum(cfg, data)))
+	if err != nil {
+		return status, err
+	}
+
+	provider, err := r.pushProvider(ctx, claim)
+	if err != nil {
+		return status, err
+	}
+
+	merged := map[string]string{}
+	var version string
+	current, err := provider.Fetch(ctx, cfg.Path)
+	switch {
+	case errors.Is(err, sources.ErrNotFound):
+	case err != nil:
+		return status, fmt.Errorf("failed to read %s push store %s: %w", cfg.Provider, cfg.Path, err)
+	default:
+		version = current.Version
+		merged = current.Data
+
+		remote := map[string]string{}
+		for k := range data {
+			if v, ok := current.Data[k]; ok {
+				remote[k] = v
+			}
+		}
+		if pushChecksum(cfg, remote) == pushChecksum(cfg, data) {
+			logger.Info("Push store already holds the Secret data.", slog.String("path", cfg.Path))
+			status.Version = version
+			status.Checksum = checksum
+			return status, nil
+		}
+		if len(remote) > 0 && !pushedLast(status.Checksum, cfg, remote) && cfg.ConflictPolicy != secretsv1alpha1.PushConflictOverwrite {
+			return status, errPushConflict
+		}
+	}
+
+	for k, v := range data {
+		merged[k] = v
+	}
+	written, err := provider.Push(ctx, cfg.Path, merged, version)
+	if err != nil {
+		return status, fmt.Errorf("failed to write %s push store %s: %w", cfg.Provider, cfg.Path, err)
+	}
+	logger.Info("Pushed Secret data to the store.", slog.String("path", cfg.Path), slog.String("version", written))
+
+	now := metav1.NewTime(time.Now())
+	status.Version = written
+	status.Checksum = checksum
+	status.LastPushTime = &now
+	return status, nil
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+)
+
+// Data keys of the retention records.
+const (
+	retainedNamespaceKey = "namespace"
+	retainedNameKey      = "name"
+	retainedSecretUIDKey = "secretUID"
+)
+
+// retentionRecordKey is the key of the record of the Secret retained by the
+// deleted claim with uid.
+func (r *SecretClaimReconciler) retentionRecordKey(uid types.UID) client.ObjectKey {
+	return client.ObjectKey{Namespace: r.RetentionNamespace, Name: "retained-" + string(uid)}
+}
+
+// recordRetention records in RetentionNamespace that the controller released
+// secret from claim. The annotations on the Secret alone can be set by anyone who
+// can write Secrets in the namespace, the record only by the controller.
+func (r *SecretClaimReconciler) recordRetention(ctx context.Context, claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret) error {
+	if r.RetentionNamespace == "" {
+		return nil
+	}
+	key := r.retentionRecordKey(claim.UID)
+	record := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data: map[string][]byte{
+			retainedNamespaceKey: []byte(claim.Namespace),
+			retainedNameKey:      []byte(claim.Name),
+			retainedSecretUIDKey: []byte(secret.UID),
+		},
+	}
+	if err := r.Create(ctx, record); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// isRetainedFor reports whether the Secret was left behind by a deleted claim with
+// the Retain policy and the same name, and can be adopted by claim. Besides the
+// annotations, the controller's record of the retention must name this Secret.
+func (r *SecretClaimReconciler) isRetainedFor(ctx context.Context, secret *corev1.Secret, claim *secretsv1alpha1.SecretClaim) (bool, error) {
+	uid := secret.Annotations[secretsv1alpha1.OrphanedFromUIDAnnotation]
+	if r.RetentionNamespace == "" || uid == "" || metav1.GetControllerOf(secret) != nil ||
+		secret.Annotations[secretsv1alpha1.OrphanedFromAnnotation] != claim.Name {
+		return false, nil
+	}
+
+	var record corev1.Secret
+	if err := r.Get(ctx, r.retentionRecordKey(types.UID(uid)), &record); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return string(record.Data[retainedNamespaceKey]) == claim.Namespace &&
+		string(record.Data[retainedNameKey]) == claim.Name &&
+		string(record.Data[retainedSecretUIDKey]) == string(secret.UID), nil
+}
+
+// forgetRetention deletes the record of the Secret retained by the deleted claim
+// with uid once it is adopted.
+func (r *SecretClaimReconciler) forgetRetention(ctx context.Context, uid string) error {
+	record := &corev1.Secret{}
+	key := r.retentionRecordKey(types.UID(uid))
+	record.Name, record.Namespace = key.Name, key.Namespace
+	return client.IgnoreNotFound(r.Delete(ctx, record))
+}
@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sealing"
+)
+
+// DefaultSealingKeyRotation is how often a new sealing key pair is generated.
+const DefaultSealingKeyRotation = 30 * 24 * time.Hour
+
+// sealingKeyCheckInterval bounds the time between two checks of the key pairs, so
+// a deleted public key ConfigMap is published again.
+const sealingKeyCheckInterval = time.Hour
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// SealingKeyManager keeps the key pairs EncryptedData values are sealed to. It
+// generates the first key pair, adds a new one every RotationInterval and
+// publishes the public key of the newest one. Older key pairs are kept so values
+// sealed to them still open; new values are sealed to the published key.
+type SealingKeyManager struct {
+	Client client.Client
+	// Reader reads the key pairs without starting a cache for ConfigMaps.
+	Reader client.Reader
+	Log    *slog.Logger
+
+	// Namespace holds the key pair Secrets and the public key ConfigMap.
+	Namespace string
+	// RotationInterval is the age of the active key pair at which a new one is
+	// generated. Keys are never rotated when zero.
+	RotationInterval time.Duration
+}
+
+// NeedLeaderElection makes only the leader generate and publish keys.
+func (m *SealingKeyManager) NeedLeaderElection() bool {
+	return true
+}
+
+// Start checks the key pairs until ctx is done.
+func (m *SealingKeyManager) Start(ctx context.Context) error {
+	logger := m.logger()
+
+	for {
+		wait := sealingKeyCheckInterval
+		next, err := m.sync(ctx, time.Now())
+		if err != nil {
+			logger.Error("Failed to sync sealing keys", slog.Any("error", err))
+			wait = time.Minute
+		} else if until := time.Until(next); m.RotationInterval > 0 && until < wait {
+			wait = max(until, time.Second)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// sync generates a key pair when none exists or the active one is due for
+// rotation, publishes the active public key and returns the next rotation time.
+func (m *SealingKeyManager) sync(ctx context.Context, now time.Time) (time.Time, error) {
+	keys, err := listSealingKeys(ctx, m.Reader, m.Namespace)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var active *corev1.Secret
+	if len(keys) > 0 {
+		active = &keys[len(keys)-1]
+	}
+	if active == nil || (m.RotationInterval > 0 && !now.Before(sealingKeyCreated(active).Add(m.RotationInterval))) {
+		if active, err = m.generate(ctx, now); err != nil {
+			return time.Time{}, err
+		}
+		m.logger().Info("Generated sealing key pair", slog.String("key_id", string(active.Data[secretsv1alpha1.SealingKeyIDKey])))
+	}
+
+	if err := m.publish(ctx, active); err != nil {
+		return time.Time{}, err
+	}
+	return sealingKeyCreated(active).Add(m.RotationInterval), nil
+}
+
+func (m *SealingKeyManager) logger() *slog.Logger {
+	logger := m.Log
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return logger.With(slog.String("namespace", m.Namespace))
+}
+
+// generate stores a new key pair created at now.
+func (m *SealingKeyManager) generate(ctx context.Context, now time.Time) (*corev1.Secret, error) {
+	priv, err := sealing.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sealing key: %w", err)
+	}
+	privPEM, err := sealing.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	pubPEM, err := sealing.MarshalPublicKey(priv.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := sealing.KeyID(priv.PublicKey())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sealing-key-" + keyID,
+			Namespace:   m.Namespace,
+			Labels:      map[string]string{secretsv1alpha1.SealingKeyLabel: "true"},
+			Annotations: map[string]string{secretsv1alpha1.SealingKeyCreatedAnnotation: now.UTC().Format(time.RFC3339)},
+		},
+		Data: map[string][]byte{
+			secretsv1alpha1.SealingPrivateKeyKey: privPEM,
+			secretsv1alpha1.SealingPublicKeyKey:  pubPEM,
+			secretsv1alpha1.SealingKeyIDKey:      []byte(keyID),
+		},
+	}
+	if err := m.Client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to store sealing key %s: %w", keyID, err)
+	}
+	return secret, nil
+}
+
+// publish writes the public key of active to the public key ConfigMap.
+func (m *SealingKeyManager) publish(ctx context.Context, active *corev1.Secret) error {
+	data := map[string]string{
+		secretsv1alpha1.SealingPublicKeyKey: string(active.Data[secretsv1alpha1.SealingPublicKeyKey]),
+		secretsv1alpha1.SealingKeyIDKey:     string(active.Data[secretsv1alpha1.SealingKeyIDKey]),
+	}
+
+	var cm corev1.ConfigMap
+	err := m.Reader.Get(ctx, client.ObjectKey{Name: secretsv1alpha1.SealingPublicKeyConfigMap, Namespace: m.Namespace}, &cm)
+	switch {
+	case errors.IsNotFound(err):
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: secretsv1alpha1.SealingPublicKeyConfigMap, Namespace: m.Namespace},
+			Data:       data,
+		}
+		if err := m.Client.Create(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to publish sealing public key: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get sealing public key ConfigMap: %w", err)
+	case !equality.Semantic.DeepEqual(cm.Data, data):
+		cm.Data = data
+		if err := m.Client.Update(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to publish sealing public key: %w", err)
+		}
+	}
+	return nil
+}
+
+// listSealingKeys returns the key pair Secrets in namespace, oldest first.
+func listSealingKeys(ctx context.Context, c client.Reader, namespace string) ([]corev1.Secret, error) {
+	var list corev1.SecretList
+	if err := c.List(ctx, &list, client.InNamespace(namespace), client.HasLabels{secretsv1alpha1.SealingKeyLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list sealing keys: %w", err)
+	}
+	slices.SortFunc(list.Items, func(a, b corev1.Secret) int {
+		return sealingKeyCreated(&a).Compare(sealingKeyCreated(&b))
+	})
+	return list.Items, nil
+}
+
+// sealingKeyCreated is the creation time of a key pair. Key pairs without a valid
+// annotation sort first and are due for rotation.
+func sealingKeyCreated(secret *corev1.Secret) time.Time {
+	created, _ := time.Parse(time.RFC3339, secret.Annotations[secretsv1alpha1.SealingKeyCreatedAnnotation])
+	return created
+}
+
+// sealingKeyring loads every key pair EncryptedData values can be sealed to.
+func (r *SecretClaimReconciler) sealingKeyring(ctx context.Context) (sealing.Keyring, error) {
+	if r.SealingKeysNamespace == "" {
+		return nil, fmt.Errorf("encryptedData is not supported: no sealing keys namespace is configured")
+	}
+	keys, err := listSealingKeys(ctx, r, r.SealingKeysNamespace)
+	if err != nil {
+		return nil, err
+	}
+	keyring := sealing.Keyring{}
+	for _, secret := range keys {
+		priv, err := sealing.ParsePrivateKey(secret.Data[secretsv1alpha1.SealingPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid sealing key %s: %w", secret.Name, err)
+		}
+		keyring.Add(priv)
+	}
+	return keyring, nil
+}
+
+// sealingScope is the claim EncryptedData values are sealed for. SecretClaims
+// backing a ClusterSecretClaim open values sealed for the ClusterSecretClaim;
+// only claims in the cluster claims namespace qualify, as anyone able to create
+// claims could point an owner reference at a ClusterSecretClaim.
+func (r *SecretClaimReconciler) sealingScope(claim *secretsv1alpha1.SecretClaim) string {
+	owner := metav1.GetControllerOf(claim)
+	if owner != nil && r.ClusterClaimsNamespace != "" && claim.Namespace == r.ClusterClaimsNamespace &&
+		owner.APIVersion == secretsv1alpha1.GroupVersion.String() && owner.Kind == "ClusterSecretClaim" {
+		return sealing.Scope("", owner.Name)
+	}
+	return sealing.Scope(claim.Namespace, claim.Name)
+}
+
+// opaqueData returns the Secret data of an Opaque claim: Data, the opened
+// EncryptedData values and the values of secret matching DataHashes.
+func (r *SecretClaimReconciler) opaqueData(ctx context.Context, claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret) (map[string]string, error) {
+	data := maps.Clone(claim.Spec.Data)
+	if data == nil {
+		data = make(map[string]string, len(claim.Spec.EncryptedData)+len(claim.Spec.DataHashes))
+	}
+	if len(claim.Spec.DataHashes) > 0 {
+		var existing map[string][]byte
+		if secret != nil {
+			existing = secret.Data
+		}
+		hashed, err := hashedData(claim.Spec.DataHashes, existing)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(data, hashed)
+	}
+	if len(claim.Spec.EncryptedData) == 0 {
+		return data, nil
+	}
+
+	keyring, err := r.sealingKeyring(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scope := r.sealingScope(claim)
+	for key, value := range claim.Spec.EncryptedData {
+		opened, err := keyring.Open(scope, key, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open encryptedData key %s: %w", key, err)
+		}
+		data[key] = string(opened)
+	}
+	return data, nil
+}
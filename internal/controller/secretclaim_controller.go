@@ -20,8 +20,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/http"
 	"os"
 	"reflect"
+	"slices"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -31,15 +34,21 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/keygen"
 	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/templates"
 )
 
 // SecretClaimReconciler reconciles a SecretClaim object
@@ -48,12 +57,36 @@ type SecretClaimReconciler struct {
 	Scheme *runtime.Scheme
 	Tracer trace.Tracer
 	Log    *slog.Logger
+
+	// SourceRoot holds a directory per namespace that the file sources of
+	// External claims in the namespace are resolved against; file sources are
+	// rejected when empty.
+	SourceRoot string
+	// HTTPSourceURLs are the base URLs http sources and pushes may use; they
+	// are rejected when empty.
+	HTTPSourceURLs []string
+	// VaultAddresses are the Vault server addresses vault sources and pushes
+	// may use; they are rejected when empty.
+	VaultAddresses []string
+	// SourceClient performs the requests of http and vault sources,
+	// http.DefaultClient when nil.
+	SourceClient *http.Client
+
+	// SealingKeysNamespace holds the key pairs EncryptedData values are opened
+	// with; EncryptedData is rejected when empty.
+	SealingKeysNamespace string
+	// ClusterClaimsNamespace holds the SecretClaims backing ClusterSecretClaims.
+	ClusterClaimsNamespace string
+	// RetentionNamespace holds the records of Secrets retained by deleted claims;
+	// retained Secrets are not adopted again when empty.
+	RetentionNamespace string
 }
 
 // +kubebuilder:rbac:groups=secrets.myapp.io,resources=secretclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=secrets.myapp.io,resources=secretclaims/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=secrets.myapp.io,resources=secretclaims/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -122,6 +155,33 @@ func (r *SecretClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	ctx = context.WithValue(ctx, observability.LoggerContextKey, logger)
 	logger.Info("Starting reconciliation cycle.")
 
+	if !claim.DeletionTimestamp.IsZero() {
+		if reconcileError = r.finalizeClaim(ctx, &claim); reconcileError != nil {
+			logger.Error("Failed to finalize SecretClaim", slog.Any("error", reconcileError))
+			return ctrl.Result{}, reconcileError
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(&claim, secretsv1alpha1.SecretClaimFinalizer) {
+		if reconcileError = r.Update(ctx, &claim); reconcileError != nil {
+			logger.Error("Failed to add finalizer", slog.Any("error", reconcileError))
+			return ctrl.Result{}, reconcileError
+		}
+	}
+
+	if reconcileError = validateClaimSpec(&claim); reconcileError != nil {
+		logger.Error("Invalid SecretClaim spec", slog.Any("error", reconcileError))
+		setCondition(&claim, secretsv1alpha1.ConditionGenerationValid, metav1.ConditionFalse, "InvalidSpec", reconcileError.Error())
+		r.updateStatus(ctx, &claim, false, reconcileError.Error())
+		return ctrl.Result{}, reconcileError
+	}
+	if claim.Spec.Type == "AutoGenerated" {
+		setCondition(&claim, secretsv1alpha1.ConditionGenerationValid, metav1.ConditionTrue, "SpecValid", "")
+	} else {
+		meta.RemoveStatusCondition(&claim.Status.Conditions, secretsv1alpha1.ConditionGenerationValid)
+	}
+
 	targetSecretName := claim.Name
 	var secret corev1.Secret
 
@@ -134,14 +194,41 @@ func (r *SecretClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Секрета нет -> нужно создать
 		logger.Info("K8s Secret not found, creating new Secret.", slog.String("secret_name", targetSecretName))
 
+		setCondition(&claim, secretsv1alpha1.ConditionOwnershipConflict, metav1.ConditionFalse, "SecretOwned", "")
+		restored, err := r.restoreReplacedSecret(ctx, &claim)
+		if err != nil {
+			reconcileError = err
+			logger.Error("Failed to recreate Secret", slog.Any("error", reconcileError))
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "CreateFailed", reconcileError.Error())
+			r.updateStatus(ctx, &claim, false, reconcileError.Error())
+			return ctrl.Result{}, reconcileError
+		}
+		if restored {
+			logger.Info("Recreated Secret replaced for a type change.")
+			r.updateStatus(ctx, &claim, true, "")
+			return requeueForRotation(&claim), nil
+		}
+		if claim.Spec.Type == "Opaque" && len(claim.Spec.DataHashes) > 0 {
+			// Only the API server has the hashed values; its write to the Secret
+			// triggers another reconcile through the Owns watch.
+			logger.Info("Waiting for the hashed data to be written to the Secret.")
+			msg := fmt.Sprintf("waiting for the values of dataHashes to be written to secret %s", targetSecretName)
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "WaitingForData", msg)
+			r.updateStatus(ctx, &claim, false, msg)
+			return ctrl.Result{}, nil
+		}
 		if reconcileError = r.createSecret(ctx, &claim); reconcileError != nil {
 			logger.Error("Failed to create Secret", slog.Any("error", reconcileError))
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "CreateFailed", reconcileError.Error())
 			r.updateStatus(ctx, &claim, false, reconcileError.Error())
 			return ctrl.Result{}, reconcileError
 		}
 		logger.Info("K8s Secret created successfully.")
+		if claim.Spec.Type == "AutoGenerated" {
+			markRotated(&claim, time.Now())
+		}
 		r.updateStatus(ctx, &claim, true, "")
-		return ctrl.Result{}, nil
+		return requeueForRotation(&claim), nil
 
 	} else if err != nil {
 		logger.Error("Failed to get Secret", slog.Any("error", err))
@@ -149,35 +236,143 @@ func (r *SecretClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{RequeueAfter: time.Minute}, reconcileError
 	}
 
+	retained, err := r.isRetainedFor(ctx, &secret, &claim)
+	if err != nil {
+		logger.Error("Failed to check retained Secret", slog.Any("error", err))
+		reconcileError = err
+		return ctrl.Result{}, reconcileError
+	}
+	if retained {
+		logger.Info("Adopting Secret retained by a previously deleted SecretClaim.", slog.String("secret_name", targetSecretName))
+		if reconcileError = r.adoptSecret(ctx, &claim, &secret); reconcileError != nil {
+			logger.Error("Failed to adopt retained Secret", slog.Any("error", reconcileError))
+			return ctrl.Result{}, reconcileError
+		}
+	}
+
 	if !metav1.IsControlledBy(&secret.ObjectMeta, &claim) {
 		logger.Warn("Secret exists but is not controlled by SecretClaim. Skipping.",
 			slog.Any("secret_owner_refs", secret.ObjectMeta.OwnerReferences),
 			slog.String("secret_name", targetSecretName))
+		msg := fmt.Sprintf("secret %s already exists and is not managed by this SecretClaim", targetSecretName)
+		setCondition(&claim, secretsv1alpha1.ConditionOwnershipConflict, metav1.ConditionTrue, "SecretNotOwned", msg)
+		r.updateStatus(ctx, &claim, false, msg)
 		return ctrl.Result{}, nil
 	}
+	setCondition(&claim, secretsv1alpha1.ConditionOwnershipConflict, metav1.ConditionFalse, "SecretOwned", "")
 
 	needsSecretUpdate := false
+	regenerate := false
+	statusChanged := false
 	if claim.Spec.Type == "AutoGenerated" {
-		if claim.Spec.Generation == nil {
-			reconcileError = fmt.Errorf("generationConfig spec is nil for AutoGenerated claim")
-			logger.Error("Invalid generation spec", slog.Any("error", reconcileError))
-			r.updateStatus(ctx, &claim, false, reconcileError.Error())
-			return ctrl.Result{}, reconcileError
-		}
-
 		currentTrigger := claim.Spec.Generation.ReconcileTrigger
 
 		if currentTrigger != "" && currentTrigger != claim.Status.LastReconcileTrigger {
 			logger.Info("ReconcileTrigger changed. Starting regeneration.", slog.String("old_trigger", claim.Status.LastReconcileTrigger), slog.String("new_trigger", currentTrigger))
 			needsSecretUpdate = true
+			regenerate = true
+		}
+
+		if rotateKeys := changedKeyTriggers(&claim); len(rotateKeys) > 0 {
+			logger.Info("Per-key regeneration requested.", slog.Any("keys", rotateKeys))
+			needsSecretUpdate = true
+		}
+
+		if formatKeys := changedKeyFormats(&claim); len(formatKeys) > 0 {
+			logger.Info("Key formats changed. Regenerating affected keys.", slog.Any("keys", formatKeys))
+			needsSecretUpdate = true
+		}
+
+		if !sameKeys(generatedKeys(claim.Spec.Generation), withoutKeys(secret.Data, derivedKeys(&claim))) {
+			logger.Info("DataKeys changed. Generating new keys and dropping removed ones.")
+			needsSecretUpdate = true
+		}
+
+		if claim.Spec.Rotation != nil {
+			lastRotation := secret.CreationTimestamp.Time
+			if claim.Status.LastRotationTime != nil {
+				lastRotation = claim.Status.LastRotationTime.Time
+			}
+			nextRotation, _ := nextRotationTime(claim.Spec.Rotation, lastRotation)
+
+			if !time.Now().Before(nextRotation) {
+				logger.Info("Scheduled rotation is due. Starting regeneration.", slog.Time("due_at", nextRotation))
+				needsSecretUpdate = true
+				regenerate = true
+			} else if claim.Status.NextRotationTime == nil || !claim.Status.NextRotationTime.Equal(&metav1.Time{Time: nextRotation}) {
+				next := metav1.NewTime(nextRotation)
+				claim.Status.NextRotationTime = &next
+				statusChanged = true
+			}
+		} else if claim.Status.NextRotationTime != nil {
+			claim.Status.NextRotationTime = nil
+			statusChanged = true
+		}
+	}
+
+	if claim.Spec.Type == "Certificate" {
+		reason, err := r.certificateRenewalReason(ctx, &claim, &secret, time.Now())
+		if err != nil {
+			reconcileError = err
+			logger.Error("Failed to check certificate", slog.Any("error", reconcileError))
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "IssuerNotReady", reconcileError.Error())
+			r.updateStatus(ctx, &claim, false, reconcileError.Error())
+			return ctrl.Result{RequeueAfter: time.Minute}, reconcileError
+		}
+		if reason != "" {
+			logger.Info("Re-issuing certificate.", slog.String("reason", reason))
+			needsSecretUpdate = true
+			regenerate = true
+		} else if claim.Status.NotAfter == nil {
+			if err := markCertificateIssued(&claim, secret.Data[corev1.TLSCertKey]); err == nil {
+				statusChanged = true
+			}
+		}
+	}
+
+	if claim.Spec.Type == "External" {
+		if sourceRefreshDue(&claim, time.Now()) {
+			logger.Info("Source refresh is due. Fetching external data.")
+			needsSecretUpdate = true
+			regenerate = true
 		}
 	}
 
 	if claim.Spec.Type == "Opaque" {
-		if needsUpdate(claim.Spec.Data, secret.Data) {
+		data, err := r.opaqueData(ctx, &claim, &secret)
+		if isHashedDataError(err) {
+			logger.Warn("Secret does not hold the hashed data", slog.Any("error", err))
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "DataHashMismatch", err.Error())
+			r.updateStatus(ctx, &claim, false, err.Error())
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
+			reconcileError = err
+			logger.Error("Failed to open encrypted data", slog.Any("error", reconcileError))
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "DecryptFailed", reconcileError.Error())
+			r.updateStatus(ctx, &claim, false, reconcileError.Error())
+			return ctrl.Result{}, reconcileError
+		}
+		if needsUpdate(data, withoutKeys(secret.Data, derivedKeys(&claim))) {
 			logger.Info("Opaque data changed. Starting secret update.")
 			needsSecretUpdate = true
 		}
+		if len(claim.Spec.DataHashes) > 0 && (!claim.Status.Synced || claim.Status.ObservedGeneration != claim.Generation) {
+			// The API server writes hashed values to the Secret itself, so keys
+			// rendered from them are refreshed whenever the hashes change.
+			logger.Info("Hashed data changed. Re-rendering derived keys.")
+			needsSecretUpdate = true
+		}
+	}
+
+	if templates.Checksum(claim.Spec.Templates) != claim.Status.TemplatesChecksum {
+		logger.Info("Templates changed. Re-rendering templated keys.")
+		needsSecretUpdate = true
+	}
+
+	if typedSecretOutdated(&claim, &secret) {
+		logger.Info("Secret type or rendered registry credentials changed. Updating Secret.", slog.String("secret_type", string(secret.Type)))
+		needsSecretUpdate = true
 	}
 
 	if !reflect.DeepEqual(claim.Labels, secret.Labels) || !reflect.DeepEqual(claim.Annotations, secret.Annotations) {
@@ -186,25 +381,47 @@ func (r *SecretClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	if needsSecretUpdate {
-		if reconcileError = r.updateSecret(ctx, &claim, &secret); reconcileError != nil {
+		if reconcileError = r.updateSecret(ctx, &claim, &secret, regenerate); reconcileError != nil {
 			logger.Error("Failed to update Secret", slog.Any("error", reconcileError))
+			setCondition(&claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionFalse, "UpdateFailed", reconcileError.Error())
 			r.updateStatus(ctx, &claim, false, reconcileError.Error())
 			return ctrl.Result{}, reconcileError
 		}
 
 		logger.Info("K8s Secret updated successfully.")
+		if regenerate && claim.Spec.Type == "AutoGenerated" {
+			markRotated(&claim, time.Now())
+		}
 		r.updateStatus(ctx, &claim, true, "")
-		return ctrl.Result{}, nil
+		return requeueForRotation(&claim), nil
 	}
 
-	if !claim.Status.Synced {
-		logger.Info("SecretClaim not synced yet, updating status")
+	// Replicas and the push store are written once the Secret is current; writing
+	// the Secret above triggers another reconcile through the Owns watch.
+	targetsChanged, targetsErr := r.syncTargets(ctx, &claim, &secret)
+	if targetsErr != nil {
+		reconcileError = targetsErr
+		logger.Error("Failed to replicate Secret to target namespaces", slog.Any("error", reconcileError))
 		r.updateStatus(ctx, &claim, true, "")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, reconcileError
+	}
+
+	pushChanged, pushErr := r.syncPush(ctx, &claim, &secret)
+	if pushErr != nil {
+		reconcileError = pushErr
+		logger.Error("Failed to push Secret data to the external store", slog.Any("error", reconcileError))
+		r.updateStatus(ctx, &claim, true, "")
+		return ctrl.Result{}, reconcileError
+	}
+
+	if !claim.Status.Synced || statusChanged || targetsChanged || pushChanged || claim.Status.ObservedGeneration != claim.Generation {
+		logger.Info("SecretClaim status is outdated, updating status")
+		r.updateStatus(ctx, &claim, true, "")
+		return requeueForRotation(&claim), nil
 	}
 
 	logger.Info("Reconciliation complete")
-	return ctrl.Result{}, nil
+	return requeueForRotation(&claim), nil
 }
 
 func (r *SecretClaimReconciler) createSecret(ctx context.Context, claim *secretsv1alpha1.SecretClaim) error {
@@ -218,10 +435,16 @@ func (r *SecretClaimReconciler) createSecret(ctx context.Context, claim *secrets
 	var err error
 	switch claim.Spec.Type {
 	case "Opaque":
-		for k, v := range claim.Spec.Data {
+		data, err := r.opaqueData(ctx, claim, nil)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Encrypted Data Opening Failed")
+			return err
+		}
+		for k, v := range data {
 			secretData[k] = []byte(v)
 		}
-		span.AddEvent("Opaque data copied")
+		span.AddEvent("Opaque data copied", trace.WithAttributes(attribute.Int("encrypted_keys", len(claim.Spec.EncryptedData))))
 
 	case "AutoGenerated":
 
@@ -241,17 +464,33 @@ func (r *SecretClaimReconciler) createSecret(ctx context.Context, claim *secrets
 				return err
 			}
 
-			password, err := generatePassword(claim.Spec.Generation.Length, claim.Spec.Generation.Encoding)
+			values, err := generateKey(claim.Spec.Generation, key)
 			if err != nil {
-				err = fmt.Errorf("failed to generate password for key %s: %w", key, err)
+				err = fmt.Errorf("failed to generate value for key %s: %w", key, err)
 				span.RecordError(err)
-				span.SetStatus(codes.Error, "Password Generation Failed")
+				span.SetStatus(codes.Error, "Key Generation Failed")
 				return err
 			}
-			secretData[key] = []byte(password)
+			maps.Copy(secretData, values)
 		}
 		span.AddEvent("Password generation complete", trace.WithAttributes(attribute.Int("data_keys", len(claim.Spec.Generation.DataKeys))))
 
+	case "Certificate":
+		if secretData, err = r.issueCertificate(ctx, claim); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Certificate Issuing Failed")
+			return err
+		}
+		span.AddEvent("Certificate issued", trace.WithAttributes(attribute.String("issuer", claim.Spec.Certificate.IssuerRef)))
+
+	case "External":
+		if secretData, err = r.fetchSource(ctx, claim); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Source Fetch Failed")
+			return err
+		}
+		span.AddEvent("Source data fetched", trace.WithAttributes(attribute.String("source.version", claim.Status.SourceVersion)))
+
 	default:
 		err = fmt.Errorf("unknown claim type: %s", claim.Spec.Type)
 		span.RecordError(err)
@@ -259,6 +498,21 @@ func (r *SecretClaimReconciler) createSecret(ctx context.Context, claim *secrets
 		return err
 	}
 
+	rendered, err := templates.Render(claim.Spec.Templates, secretData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Template Rendering Failed")
+		return err
+	}
+	for k, v := range rendered {
+		secretData[k] = v
+	}
+	if err := renderTypedKeys(claim, secretData); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Typed Keys Rendering Failed")
+		return err
+	}
+
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        claim.Name,
@@ -266,7 +520,7 @@ func (r *SecretClaimReconciler) createSecret(ctx context.Context, claim *secrets
 			Labels:      claim.Labels,
 			Annotations: claim.Annotations,
 		},
-		Type: corev1.SecretTypeOpaque,
+		Type: desiredSecretType(claim),
 		Data: secretData,
 	}
 
@@ -286,7 +540,12 @@ func (r *SecretClaimReconciler) createSecret(ctx context.Context, claim *secrets
 	return nil
 }
 
-func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secretsv1alpha1.SecretClaim, existingSecret *corev1.Secret) error {
+// updateSecret writes the claim's desired data into existingSecret. For AutoGenerated
+// claims existing values are kept unless regenerate is set or the key's trigger or
+// format changed; only new keys are generated and keys removed from DataKeys are
+// dropped. Certificate claims keep their certificate and External claims their
+// fetched data unless regenerate is set.
+func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secretsv1alpha1.SecretClaim, existingSecret *corev1.Secret, regenerate bool) error {
 	logger := observability.LoggerFromContext(ctx)
 
 	ctx, span := r.Tracer.Start(ctx, "SecretClaimReconciler.updateSecret")
@@ -299,10 +558,16 @@ func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secrets
 
 	switch claim.Spec.Type {
 	case "Opaque":
-		for k, v := range claim.Spec.Data {
+		data, err := r.opaqueData(ctx, claim, existingSecret)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Encrypted Data Opening Failed")
+			return err
+		}
+		for k, v := range data {
 			secretData[k] = []byte(v)
 		}
-		span.AddEvent("Opaque data copied for update")
+		span.AddEvent("Opaque data copied for update", trace.WithAttributes(attribute.Int("encrypted_keys", len(claim.Spec.EncryptedData))))
 
 	case "AutoGenerated":
 		if claim.Spec.Generation == nil {
@@ -312,7 +577,9 @@ func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secrets
 			return err
 		}
 
-		span.AddEvent("Starting password regeneration")
+		span.AddEvent("Starting password regeneration", trace.WithAttributes(attribute.Bool("regenerate_all", regenerate)))
+		rotateKeys := append(changedKeyTriggers(claim), changedKeyFormats(claim)...)
+		generated := 0
 		for _, key := range claim.Spec.Generation.DataKeys {
 
 			if claim.Spec.Generation.Length < 8 {
@@ -321,16 +588,56 @@ func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secrets
 				span.SetStatus(codes.Error, "Secret lenght <8")
 				return err
 			}
-			password, genErr := generatePassword(claim.Spec.Generation.Length, claim.Spec.Generation.Encoding)
+
+			if existing, ok := existingSecret.Data[key]; ok && !regenerate && !slices.Contains(rotateKeys, key) {
+				if !keygen.IsKeyPair(keyFormat(claim.Spec.Generation, key)) {
+					secretData[key] = existing
+					continue
+				}
+				if public, ok := existingSecret.Data[keygen.PublicKey(key)]; ok {
+					secretData[key] = existing
+					secretData[keygen.PublicKey(key)] = public
+					continue
+				}
+			}
+
+			values, genErr := generateKey(claim.Spec.Generation, key)
 			if genErr != nil {
-				err = fmt.Errorf("failed to generate password for key %s: %w", key, genErr)
+				err = fmt.Errorf("failed to generate value for key %s: %w", key, genErr)
 				span.RecordError(err)
-				span.SetStatus(codes.Error, "Password Regeneration Failed")
+				span.SetStatus(codes.Error, "Key Regeneration Failed")
 				return err
 			}
-			secretData[key] = []byte(password)
+			maps.Copy(secretData, values)
+			generated++
+		}
+		span.AddEvent("Password regeneration complete", trace.WithAttributes(attribute.Int("generated_keys", generated)))
+
+	case "Certificate":
+		if regenerate {
+			if secretData, err = r.issueCertificate(ctx, claim); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "Certificate Issuing Failed")
+				return err
+			}
+			span.AddEvent("Certificate re-issued", trace.WithAttributes(attribute.String("issuer", claim.Spec.Certificate.IssuerRef)))
+			break
+		}
+		for _, key := range certificateKeys {
+			secretData[key] = existingSecret.Data[key]
 		}
-		span.AddEvent("Password regeneration complete")
+
+	case "External":
+		if regenerate {
+			if secretData, err = r.fetchSource(ctx, claim); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "Source Fetch Failed")
+				return err
+			}
+			span.AddEvent("Source data refreshed", trace.WithAttributes(attribute.String("source.version", claim.Status.SourceVersion)))
+			break
+		}
+		maps.Copy(secretData, withoutKeys(existingSecret.Data, derivedKeys(claim)))
 
 	default:
 		err = fmt.Errorf("unknown claim type: %s", claim.Spec.Type)
@@ -339,6 +646,21 @@ func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secrets
 		return err
 	}
 
+	rendered, err := templates.Render(claim.Spec.Templates, secretData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Template Rendering Failed")
+		return err
+	}
+	for k, v := range rendered {
+		secretData[k] = v
+	}
+	if err := renderTypedKeys(claim, secretData); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Typed Keys Rendering Failed")
+		return err
+	}
+
 	if claim.Annotations != nil {
 		existingSecret.Annotations = claim.Annotations
 	}
@@ -347,9 +669,50 @@ func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secrets
 		existingSecret.Labels = claim.Labels
 	}
 
-	existingSecret.Type = corev1.SecretTypeOpaque
 	existingSecret.Data = secretData
 
+	if desiredType := desiredSecretType(claim); existingSecret.Type != desiredType {
+		// Secret.type is immutable, so the Secret is replaced under the same name.
+		// The new values are staged in a copy first; they may exist nowhere else.
+		logger.Info("Secret type changed, replacing Secret", slog.String("old_type", string(existingSecret.Type)), slog.String("new_type", string(desiredType)))
+		staged := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName:    stagedSecretPrefix(existingSecret.Name),
+				Namespace:       existingSecret.Namespace,
+				Labels:          map[string]string{secretsv1alpha1.ReplacementForLabel: string(claim.UID)},
+				Annotations:     existingSecret.Annotations,
+				OwnerReferences: existingSecret.OwnerReferences,
+			},
+			Type: desiredType,
+			Data: secretData,
+		}
+		for k, v := range existingSecret.Labels {
+			staged.Labels[k] = v
+		}
+		if err := r.Create(ctx, staged); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Create Secret Failed")
+			logger.Error("K8s API call failed to stage replacement Secret", slog.Any("error", err))
+			return err
+		}
+		if err := r.Delete(ctx, existingSecret); client.IgnoreNotFound(err) != nil {
+			if delErr := r.Delete(ctx, staged); client.IgnoreNotFound(delErr) != nil {
+				logger.Error("Failed to delete staged Secret", slog.Any("error", delErr))
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Delete Secret Failed")
+			return err
+		}
+		if err := r.recreateSecret(ctx, existingSecret.Name, staged); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Create Secret Failed")
+			logger.Error("K8s API call failed to recreate Secret", slog.Any("error", err))
+			return err
+		}
+		span.SetStatus(codes.Ok, "Secret Replaced")
+		return nil
+	}
+
 	logger.Debug("Attempting K8s Update Secret API call")
 	if updateErr := r.Update(ctx, existingSecret); updateErr != nil {
 		span.RecordError(updateErr)
@@ -363,6 +726,177 @@ func (r *SecretClaimReconciler) updateSecret(ctx context.Context, claim *secrets
 
 }
 
+// recreateSecret creates the Secret name from a copy staged for a type change
+// and deletes the copy. On failure the copy is left in place, so that a later
+// reconcile, possibly by another replica, creates the Secret with the same values
+// instead of generating new ones.
+func (r *SecretClaimReconciler) recreateSecret(ctx context.Context, name string, staged *corev1.Secret) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       staged.Namespace,
+			Annotations:     staged.Annotations,
+			OwnerReferences: staged.OwnerReferences,
+		},
+		Type: staged.Type,
+		Data: staged.Data,
+	}
+	for k, v := range staged.Labels {
+		if k == secretsv1alpha1.ReplacementForLabel {
+			continue
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[k] = v
+	}
+	err := retry.OnError(retry.DefaultBackoff, func(err error) bool {
+		return !errors.IsAlreadyExists(err) && !errors.IsInvalid(err)
+	}, func() error {
+		return r.Create(ctx, secret.DeepCopy())
+	})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, staged))
+}
+
+// restoreReplacedSecret recreates the claim's Secret from a copy staged for a
+// type change, if there is one. It reports whether a copy was found.
+func (r *SecretClaimReconciler) restoreReplacedSecret(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (bool, error) {
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets, client.InNamespace(claim.Namespace), client.MatchingLabels{secretsv1alpha1.ReplacementForLabel: string(claim.UID)}); err != nil {
+		return false, fmt.Errorf("failed to list staged Secrets: %w", err)
+	}
+	restored := false
+	for _, staged := range secrets.Items {
+		if !metav1.IsControlledBy(&staged, claim) {
+			continue
+		}
+		if err := r.recreateSecret(ctx, claim.Name, &staged); err != nil {
+			return restored, err
+		}
+		restored = true
+	}
+	return restored, nil
+}
+
+// finalizeClaim applies the claim's DeletionPolicy to the Secret it controls and
+// its replicas, then removes the finalizer. Secrets owned by someone else are never touched.
+func (r *SecretClaimReconciler) finalizeClaim(ctx context.Context, claim *secretsv1alpha1.SecretClaim) error {
+	logger := observability.LoggerFromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(claim, secretsv1alpha1.SecretClaimFinalizer) {
+		return nil
+	}
+
+	ctx, span := r.Tracer.Start(ctx, "SecretClaimReconciler.finalizeClaim")
+	defer span.End()
+
+	policy := claim.Spec.DeletionPolicy
+	if policy == "" {
+		policy = secretsv1alpha1.DeletionPolicyDelete
+	}
+	span.SetAttributes(attribute.String("claim.deletion_policy", policy))
+
+	if _, err := r.restoreReplacedSecret(ctx, claim); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Create Secret Failed")
+		return err
+	}
+
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: claim.Name, Namespace: claim.Namespace}, &secret)
+	switch {
+	case errors.IsNotFound(err):
+		logger.Info("Secret already gone, nothing to finalize.")
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Get Secret Failed")
+		return err
+	case !metav1.IsControlledBy(&secret.ObjectMeta, claim):
+		logger.Info("Secret is not controlled by SecretClaim, leaving it as is.")
+	case policy == secretsv1alpha1.DeletionPolicyDelete:
+		logger.Info("Deleting Secret according to deletion policy.", slog.String("policy", policy))
+		if err := r.Delete(ctx, &secret); client.IgnoreNotFound(err) != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Delete Secret Failed")
+			return err
+		}
+	default:
+		logger.Info("Releasing Secret according to deletion policy.", slog.String("policy", policy))
+		releaseSecret(&secret, claim)
+		if policy == secretsv1alpha1.DeletionPolicyRetain {
+			if err := r.recordRetention(ctx, claim, &secret); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "K8s Create Retention Record Failed")
+				return err
+			}
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[secretsv1alpha1.OrphanedFromAnnotation] = claim.Name
+			secret.Annotations[secretsv1alpha1.OrphanedFromUIDAnnotation] = string(claim.UID)
+		}
+		if err := r.Update(ctx, &secret); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "K8s Update Secret Failed")
+			return err
+		}
+	}
+
+	if err := r.finalizeReplicas(ctx, claim, policy); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Replica Finalization Failed")
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(claim, secretsv1alpha1.SecretClaimFinalizer)
+	if err := r.Update(ctx, claim); client.IgnoreNotFound(err) != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Remove Finalizer Failed")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "Finalized")
+	return nil
+}
+
+// adoptSecret takes over a Secret retained by a deleted claim of the same name.
+// The retained values are treated as current so they are not regenerated.
+func (r *SecretClaimReconciler) adoptSecret(ctx context.Context, claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret) error {
+	ctx, span := r.Tracer.Start(ctx, "SecretClaimReconciler.adoptSecret")
+	defer span.End()
+
+	retainedUID := secret.Annotations[secretsv1alpha1.OrphanedFromUIDAnnotation]
+	delete(secret.Annotations, secretsv1alpha1.OrphanedFromAnnotation)
+	delete(secret.Annotations, secretsv1alpha1.OrphanedFromUIDAnnotation)
+	if err := ctrl.SetControllerReference(claim, secret, r.Scheme); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Controller Reference Failed")
+		return err
+	}
+	if err := r.Update(ctx, secret); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Update Secret Failed")
+		return err
+	}
+	if err := r.forgetRetention(ctx, retainedUID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Delete Retention Record Failed")
+		return err
+	}
+
+	if claim.Spec.Type == "AutoGenerated" && claim.Spec.Generation != nil {
+		claim.Status.LastReconcileTrigger = claim.Spec.Generation.ReconcileTrigger
+		claim.Status.LastKeyTriggers = maps.Clone(claim.Spec.Generation.KeyTriggers)
+		claim.Status.LastKeyFormats = keyFormats(claim.Spec.Generation)
+	}
+
+	span.SetStatus(codes.Ok, "Secret Adopted")
+	return nil
+}
+
 func (r *SecretClaimReconciler) updateStatus(ctx context.Context, claim *secretsv1alpha1.SecretClaim, synced bool, msg string) {
 	logger := observability.LoggerFromContext(ctx)
 
@@ -370,6 +904,7 @@ func (r *SecretClaimReconciler) updateStatus(ctx context.Context, claim *secrets
 	defer span.End()
 
 	claim.Status.Synced = synced
+	claim.Status.ObservedGeneration = claim.Generation
 
 	if msg != "" {
 		claim.Status.ErrorMessage = msg
@@ -381,12 +916,28 @@ func (r *SecretClaimReconciler) updateStatus(ctx context.Context, claim *secrets
 		lastUpdate := metav1.NewTime(time.Now())
 		claim.Status.CreatedSecretName = claim.Name
 		claim.Status.LastUpdate = &lastUpdate
+		claim.Status.TemplatesChecksum = templates.Checksum(claim.Spec.Templates)
 
 		if claim.Spec.Type == "AutoGenerated" && claim.Spec.Generation != nil {
 			claim.Status.LastReconcileTrigger = claim.Spec.Generation.ReconcileTrigger
+			claim.Status.LastKeyTriggers = maps.Clone(claim.Spec.Generation.KeyTriggers)
+			claim.Status.LastKeyFormats = keyFormats(claim.Spec.Generation)
+		}
+		if claim.Spec.Rotation == nil && claim.Spec.Type != "Certificate" && claim.Spec.Type != "External" {
+			claim.Status.NextRotationTime = nil
+		}
+		if claim.Spec.Type != "Certificate" {
+			claim.Status.NotAfter = nil
+		}
+		if claim.Spec.Type != "External" {
+			claim.Status.SourceVersion = ""
 		}
+		setCondition(claim, secretsv1alpha1.ConditionSecretCreated, metav1.ConditionTrue, "SecretSynced", "")
+		setCondition(claim, secretsv1alpha1.ConditionReady, metav1.ConditionTrue, "SecretSynced", "Secret is in sync with the claim")
 	} else {
-		claim.Status.LastReconcileTrigger = ""
+		// LastReconcileTrigger is kept on failure: resetting it would make the next
+		// successful reconcile regenerate every key even though no trigger changed.
+		setCondition(claim, secretsv1alpha1.ConditionReady, metav1.ConditionFalse, readyFailureReason(claim), msg)
 	}
 
 	logger.Debug("Attempting K8s Status Update API call")
@@ -411,5 +962,8 @@ func (r *SecretClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&secretsv1alpha1.SecretClaim{}).
 		Named("secretclaim").
 		Owns(&corev1.Secret{}).
+		Watches(&secretsv1alpha1.SecretClaim{}, handler.EnqueueRequestsFromMapFunc(r.claimsIssuedBy)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.replicaSource)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.claimsTargeting)).
 		Complete(r)
 }
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sources"
+)
+
+// refreshInterval returns how often the claim's source is read again.
+func refreshInterval(cfg *secretsv1alpha1.SourceConfig) time.Duration {
+	if cfg.RefreshInterval != nil && cfg.RefreshInterval.Duration > 0 {
+		return cfg.RefreshInterval.Duration
+	}
+	return sources.DefaultRefreshInterval
+}
+
+// sourceKeys returns the Secret keys of an External claim known before the
+// source is read: the keys mapped in Source.Keys.
+func sourceKeys(cfg *secretsv1alpha1.SourceConfig) []string {
+	keys := make([]string, 0, len(cfg.Keys))
+	for k := range cfg.Keys {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// validateSource checks the source of an External claim.
+func validateSource(cfg *secretsv1alpha1.SourceConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("source spec is nil for External claim")
+	}
+	if !slices.Contains(sources.Supported, cfg.Provider) {
+		return fmt.Errorf("unsupported source provider: %s", cfg.Provider)
+	}
+	if cfg.Path == "" {
+		return fmt.Errorf("source path must not be empty")
+	}
+	if cfg.Provider == sources.ProviderVault && cfg.Address == "" {
+		return fmt.Errorf("vault source requires an address")
+	}
+	if cfg.RefreshInterval != nil && cfg.RefreshInterval.Duration < time.Minute {
+		return fmt.Errorf("source refresh interval must be at least 1m")
+	}
+	for key, sourceKey := range cfg.Keys {
+		if key == "" || sourceKey == "" {
+			return fmt.Errorf("source keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// sourceRefreshDue reports whether an External claim has to read its source:
+// the refresh interval elapsed, the spec changed or the last attempt failed.
+func sourceRefreshDue(claim *secretsv1alpha1.SecretClaim, now time.Time) bool {
+	return !claim.Status.Synced ||
+		claim.Status.ObservedGeneration != claim.Generation ||
+		claim.Status.NextRotationTime == nil ||
+		!now.Before(claim.Status.NextRotationTime.Time)
+}
+
+// sourceProvider builds the provider of the claim's source.
+func (r *SecretClaimReconciler) sourceProvider(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (sources.SecretSourceProvider, error) {
+	cfg := claim.Spec.Source
+	token, err := r.credentialsToken(ctx, claim.Namespace, cfg.CredentialsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sources.New(sources.Config{
+		Provider:              cfg.Provider,
+		Address:               cfg.Address,
+		Mount:                 cfg.Mount,
+		Token:                 token,
+		FileRoot:              r.SourceRoot,
+		Namespace:             claim.Namespace,
+		Client:                r.SourceClient,
+		HTTPAllowedURLs:       r.HTTPSourceURLs,
+		VaultAllowedAddresses: r.VaultAddresses,
+	})
+}
+
+// credentialsToken reads the "token" key of the credentials Secret name in
+// namespace. An empty name means the store needs no token.
+func (r *SecretClaimReconciler) credentialsToken(ctx context.Context, namespace, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	var credentials corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &credentials); err != nil {
+		return "", fmt.Errorf("failed to get credentials Secret %s: %w", name, err)
+	}
+	value, ok := credentials.Data[secretsv1alpha1.SourceCredentialsKey]
+	if !ok {
+		return "", fmt.Errorf("credentials Secret %s has no %s key", name, secretsv1alpha1.SourceCredentialsKey)
+	}
+	return string(value), nil
+}
+
+// fetchSource reads the claim's source and returns the Secret data: every
+// fetched key, or only the keys mapped in Source.Keys. The claim status records
+// the fetched version and schedules the next refresh.
+func (r *SecretClaimReconciler) fetchSource(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (map[string][]byte, error) {
+	provider, err := r.sourceProvider(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	fetched, err := provider.Fetch(ctx, claim.Spec.Source.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s source %s: %w", claim.Spec.Source.Provider, claim.Spec.Source.Path, err)
+	}
+
+	data := make(map[string][]byte, len(fetched.Data))
+	if len(claim.Spec.Source.Keys) == 0 {
+		for k, v := range fetched.Data {
+			data[k] = []byte(v)
+		}
+	} else {
+		for key, sourceKey := range claim.Spec.Source.Keys {
+			value, ok := fetched.Data[sourceKey]
+			if !ok {
+				return nil, fmt.Errorf("%s source %s has no key %s", claim.Spec.Source.Provider, claim.Spec.Source.Path, sourceKey)
+			}
+			data[key] = []byte(value)
+		}
+	}
+
+	now := time.Now()
+	if fetched.Version != claim.Status.SourceVersion {
+		changed := metav1.NewTime(now)
+		claim.Status.LastRotationTime = &changed
+	}
+	next := metav1.NewTime(now.Add(refreshInterval(claim.Spec.Source)))
+	claim.Status.SourceVersion = fetched.Version
+	claim.Status.NextRotationTime = &next
+	return data, nil
+}
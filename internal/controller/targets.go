@@ -0,0 +1,365 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+)
+
+// errReplicaNotManaged is reported for target namespaces that already hold a
+// Secret with the claim's name.
+var errReplicaNotManaged = errors.New("secret already exists and is not managed by this SecretClaim")
+
+// validateTargets checks the namespaces and the selector of the claim's Targets.
+func validateTargets(cfg *secretsv1alpha1.TargetsConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if len(cfg.Namespaces) == 0 && cfg.NamespaceSelector == nil {
+		return fmt.Errorf("targets require namespaces or a namespaceSelector")
+	}
+	for _, ns := range cfg.Namespaces {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("invalid target namespace %q: %s", ns, errs[0])
+		}
+	}
+	if cfg.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(cfg.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid target namespaceSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+// replicaOf is the value of ReplicaOfAnnotation on the claim's replicas.
+func replicaOf(claim *secretsv1alpha1.SecretClaim) string {
+	return client.ObjectKeyFromObject(claim).String()
+}
+
+// isReplicaOf reports whether secret is a replica managed by claim.
+func isReplicaOf(secret *corev1.Secret, claim *secretsv1alpha1.SecretClaim) bool {
+	return secret.Labels[secretsv1alpha1.SourceUIDLabel] == string(claim.UID)
+}
+
+// isRetainedReplicaOf reports whether secret is a replica left behind by a deleted
+// claim with the Retain policy that claim takes over.
+func isRetainedReplicaOf(secret *corev1.Secret, claim *secretsv1alpha1.SecretClaim) bool {
+	return secret.Labels[secretsv1alpha1.SourceUIDLabel] == "" &&
+		secret.Annotations[secretsv1alpha1.ReplicaOfAnnotation] == replicaOf(claim) &&
+		secret.Annotations[secretsv1alpha1.OrphanedFromAnnotation] == claim.Name
+}
+
+// matchesTargets reports whether the namespace is a target of the claim.
+func matchesTargets(claim *secretsv1alpha1.SecretClaim, ns *corev1.Namespace) bool {
+	cfg := claim.Spec.Targets
+	if cfg == nil || ns.Name == claim.Namespace {
+		return false
+	}
+	if slices.Contains(cfg.Namespaces, ns.Name) {
+		return true
+	}
+	if cfg.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cfg.NamespaceSelector)
+	return err == nil && selector.Matches(labels.Set(ns.Labels))
+}
+
+// desiredReplica is the copy of source written to the target namespace.
+func desiredReplica(claim *secretsv1alpha1.SecretClaim, source *corev1.Secret, namespace string) *corev1.Secret {
+	replicaLabels := maps.Clone(claim.Labels)
+	if replicaLabels == nil {
+		replicaLabels = map[string]string{}
+	}
+	replicaLabels[secretsv1alpha1.SourceUIDLabel] = string(claim.UID)
+
+	annotations := maps.Clone(claim.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[secretsv1alpha1.ReplicaOfAnnotation] = replicaOf(claim)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        claim.Name,
+			Namespace:   namespace,
+			Labels:      replicaLabels,
+			Annotations: annotations,
+		},
+		Type: source.Type,
+		Data: maps.Clone(source.Data),
+	}
+}
+
+// targetNamespaces returns the existing namespaces the claim replicates to and the
+// explicitly listed ones that do not exist. Terminating namespaces are skipped.
+func (r *SecretClaimReconciler) targetNamespaces(ctx context.Context, claim *secretsv1alpha1.SecretClaim) (targets, missing []string, err error) {
+	if claim.Spec.Targets == nil {
+		return nil, nil, nil
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := r.List(ctx, &namespaces); err != nil {
+		return nil, nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	existing := make(map[string]bool, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		existing[ns.Name] = true
+		if ns.Status.Phase == corev1.NamespaceTerminating || !ns.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if matchesTargets(claim, &ns) {
+			targets = append(targets, ns.Name)
+		}
+	}
+	for _, ns := range claim.Spec.Targets.Namespaces {
+		if !existing[ns] && ns != claim.Namespace && !slices.Contains(missing, ns) {
+			missing = append(missing, ns)
+		}
+	}
+	slices.Sort(targets)
+	return targets, missing, nil
+}
+
+// syncTargets replicates source into every target namespace of the claim, deletes
+// replicas in namespaces that are no longer targets and records the result in the
+// claim status. It reports whether the status changed; the error is that of the
+// first replica that could not be written.
+func (r *SecretClaimReconciler) syncTargets(ctx context.Context, claim *secretsv1alpha1.SecretClaim, source *corev1.Secret) (bool, error) {
+	if claim.Spec.Targets == nil && len(claim.Status.Targets) == 0 {
+		return meta.RemoveStatusCondition(&claim.Status.Conditions, secretsv1alpha1.ConditionTargetsSynced), nil
+	}
+
+	logger := observability.LoggerFromContext(ctx)
+
+	ctx, span := r.Tracer.Start(ctx, "SecretClaimReconciler.syncTargets")
+	defer span.End()
+
+	targets, missing, err := r.targetNamespaces(ctx, claim)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s List Namespaces Failed")
+		return false, err
+	}
+	span.SetAttributes(attribute.Int("claim.targets", len(targets)))
+
+	previous := make(map[string]secretsv1alpha1.TargetStatus, len(claim.Status.Targets))
+	for _, target := range claim.Status.Targets {
+		previous[target.Namespace] = target
+	}
+
+	var statuses []secretsv1alpha1.TargetStatus
+	var syncErr error
+	for _, ns := range targets {
+		status := secretsv1alpha1.TargetStatus{Namespace: ns, Synced: true, LastUpdate: previous[ns].LastUpdate}
+		written, err := r.syncReplica(ctx, claim, source, ns)
+		switch {
+		case err != nil:
+			logger.Error("Failed to replicate Secret", slog.String("target_namespace", ns), slog.Any("error", err))
+			status.Synced = false
+			status.Message = err.Error()
+			if syncErr == nil && !errors.Is(err, errReplicaNotManaged) {
+				syncErr = err
+			}
+		case written:
+			logger.Info("Replicated Secret to target namespace.", slog.String("target_namespace", ns))
+			now := metav1.NewTime(time.Now())
+			status.LastUpdate = &now
+		}
+		statuses = append(statuses, status)
+	}
+	for _, ns := range missing {
+		statuses = append(statuses, secretsv1alpha1.TargetStatus{Namespace: ns, Message: "namespace not found"})
+	}
+	slices.SortFunc(statuses, func(a, b secretsv1alpha1.TargetStatus) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	if err := r.deleteStaleReplicas(ctx, claim, targets); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "K8s Delete Replica Failed")
+		return false, err
+	}
+
+	changed := !equality.Semantic.DeepEqual(claim.Status.Targets, statuses)
+	claim.Status.Targets = statuses
+
+	if claim.Spec.Targets == nil {
+		changed = meta.RemoveStatusCondition(&claim.Status.Conditions, secretsv1alpha1.ConditionTargetsSynced) || changed
+	} else if failed := slices.IndexFunc(statuses, func(s secretsv1alpha1.TargetStatus) bool { return !s.Synced }); failed >= 0 {
+		msg := fmt.Sprintf("namespace %s: %s", statuses[failed].Namespace, statuses[failed].Message)
+		changed = setCondition(claim, secretsv1alpha1.ConditionTargetsSynced, metav1.ConditionFalse, "TargetNotSynced", msg) || changed
+	} else {
+		msg := fmt.Sprintf("Secret is replicated to %d namespaces", len(statuses))
+		changed = setCondition(claim, secretsv1alpha1.ConditionTargetsSynced, metav1.ConditionTrue, "TargetsSynced", msg) || changed
+	}
+
+	if syncErr != nil {
+		span.RecordError(syncErr)
+		span.SetStatus(codes.Error, "Replication Failed")
+		return changed, syncErr
+	}
+	span.SetStatus(codes.Ok, "Targets Synced")
+	return changed, nil
+}
+
+// syncReplica creates or updates the replica of source in namespace and reports
+// whether it was written. A Secret of the same name that is not a replica of the
+// claim is left alone and reported with errReplicaNotManaged.
+func (r *SecretClaimReconciler) syncReplica(ctx context.Context, claim *secretsv1alpha1.SecretClaim, source *corev1.Secret, namespace string) (bool, error) {
+	desired := desiredReplica(claim, source, namespace)
+
+	var existing corev1.Secret
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if apierrors.IsNotFound(err) {
+		return true, r.Create(ctx, desired)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !isReplicaOf(&existing, claim) && !isRetainedReplicaOf(&existing, claim) {
+		return false, errReplicaNotManaged
+	}
+
+	if existing.Type != desired.Type {
+		// Secret.type is immutable, so the replica is replaced under the same name.
+		if err := r.Delete(ctx, &existing); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		return true, r.Create(ctx, desired)
+	}
+
+	if reflect.DeepEqual(existing.Data, desired.Data) &&
+		reflect.DeepEqual(existing.Labels, desired.Labels) &&
+		reflect.DeepEqual(existing.Annotations, desired.Annotations) {
+		return false, nil
+	}
+	existing.Data = desired.Data
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	return true, r.Update(ctx, &existing)
+}
+
+// listReplicas returns the replicas of the claim in all namespaces.
+func (r *SecretClaimReconciler) listReplicas(ctx context.Context, claim *secretsv1alpha1.SecretClaim) ([]corev1.Secret, error) {
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets, client.MatchingLabels{secretsv1alpha1.SourceUIDLabel: string(claim.UID)}); err != nil {
+		return nil, fmt.Errorf("failed to list replicas: %w", err)
+	}
+	return secrets.Items, nil
+}
+
+// deleteStaleReplicas deletes the claim's replicas outside of targets.
+func (r *SecretClaimReconciler) deleteStaleReplicas(ctx context.Context, claim *secretsv1alpha1.SecretClaim, targets []string) error {
+	logger := observability.LoggerFromContext(ctx)
+
+	replicas, err := r.listReplicas(ctx, claim)
+	if err != nil {
+		return err
+	}
+	for _, replica := range replicas {
+		if slices.Contains(targets, replica.Namespace) || replica.Name != claim.Name {
+			continue
+		}
+		logger.Info("Deleting replica from namespace that is no longer a target.", slog.String("target_namespace", replica.Namespace))
+		if err := r.Delete(ctx, &replica); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalizeReplicas applies the deletion policy to the claim's replicas: Delete
+// removes them, Retain and Orphan keep them without the claim's UID. Retained
+// replicas are taken over by a new claim with the same name and namespace.
+func (r *SecretClaimReconciler) finalizeReplicas(ctx context.Context, claim *secretsv1alpha1.SecretClaim, policy string) error {
+	replicas, err := r.listReplicas(ctx, claim)
+	if err != nil {
+		return err
+	}
+	for _, replica := range replicas {
+		if policy == secretsv1alpha1.DeletionPolicyDelete {
+			if err := r.Delete(ctx, &replica); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+
+		delete(replica.Labels, secretsv1alpha1.SourceUIDLabel)
+		if policy == secretsv1alpha1.DeletionPolicyRetain {
+			if replica.Annotations == nil {
+				replica.Annotations = map[string]string{}
+			}
+			replica.Annotations[secretsv1alpha1.OrphanedFromAnnotation] = claim.Name
+		} else {
+			delete(replica.Annotations, secretsv1alpha1.ReplicaOfAnnotation)
+		}
+		if err := r.Update(ctx, &replica); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claimsTargeting maps a namespace to the claims replicating into it or having a
+// replica there, so new and relabeled namespaces get or lose the Secret.
+func (r *SecretClaimReconciler) claimsTargeting(ctx context.Context, obj client.Object) []reconcile.Request {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+
+	var claims secretsv1alpha1.SecretClaimList
+	if err := r.List(ctx, &claims); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, claim := range claims.Items {
+		hasReplica := slices.ContainsFunc(claim.Status.Targets, func(t secretsv1alpha1.TargetStatus) bool {
+			return t.Namespace == ns.Name
+		})
+		if hasReplica || matchesTargets(&claim, ns) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&claim)})
+		}
+	}
+	return requests
+}
+
+// replicaSource maps a replica to the claim it is copied from, so changes made
+// to replicas are reverted.
+func (r *SecretClaimReconciler) replicaSource(_ context.Context, obj client.Object) []reconcile.Request {
+	source := obj.GetAnnotations()[secretsv1alpha1.ReplicaOfAnnotation]
+	if source == "" {
+		return nil
+	}
+	namespace, name, ok := strings.Cut(source, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
@@ -0,0 +1,68 @@
+// Package datahash hashes Opaque values that are written straight to the created
+// Secret, so a SecretClaim can record what its Secret should hold without keeping
+// the plaintext. A hash has the form "sha256:<salt>:<digest>" with a random hex
+// encoded salt, so equal values in different claims do not hash alike.
+package datahash
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	prefix   = "sha256:"
+	saltSize = 16
+)
+
+// Sum returns a salted hash of value.
+func Sum(value []byte) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return prefix + hex.EncodeToString(salt) + ":" + hex.EncodeToString(digest(salt, value)), nil
+}
+
+// Verify reports whether hash was computed by Sum for value.
+func Verify(hash string, value []byte) bool {
+	salt, sum, err := decode(hash)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(sum, digest(salt, value)) == 1
+}
+
+// Check reports whether hash has the form of a hash returned by Sum.
+func Check(hash string) error {
+	_, _, err := decode(hash)
+	return err
+}
+
+func decode(hash string) (salt, sum []byte, err error) {
+	rest, ok := strings.CutPrefix(hash, prefix)
+	if !ok {
+		return nil, nil, fmt.Errorf("hash must start with %q", prefix)
+	}
+	saltHex, sumHex, ok := strings.Cut(rest, ":")
+	if !ok {
+		return nil, nil, fmt.Errorf("hash must have the form sha256:<salt>:<digest>")
+	}
+	if salt, err = hex.DecodeString(saltHex); err != nil || len(salt) != saltSize {
+		return nil, nil, fmt.Errorf("hash salt must be %d hex encoded bytes", saltSize)
+	}
+	if sum, err = hex.DecodeString(sumHex); err != nil || len(sum) != sha256.Size {
+		return nil, nil, fmt.Errorf("hash digest must be %d hex encoded bytes", sha256.Size)
+	}
+	return salt, sum, nil
+}
+
+func digest(salt, value []byte) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write(value)
+	return h.Sum(nil)
+}
@@ -0,0 +1,48 @@
+package datahash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSumVerify(t *testing.T) {
+	hash, err := Sum([]byte("s3cret"))
+	if err != nil {
+		t.Fatalf("Sum error: %v", err)
+	}
+	if err := Check(hash); err != nil {
+		t.Errorf("Check error: %v", err)
+	}
+	if strings.Contains(hash, "s3cret") {
+		t.Errorf("hash %q contains the value", hash)
+	}
+	if !Verify(hash, []byte("s3cret")) {
+		t.Errorf("Verify = false for the hashed value")
+	}
+	if Verify(hash, []byte("other")) {
+		t.Errorf("Verify = true for another value")
+	}
+
+	again, _ := Sum([]byte("s3cret"))
+	if again == hash {
+		t.Errorf("hashing the same value twice returned the same hash")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, hash := range []string{
+		"",
+		"s3cret",
+		"md5:00112233445566778899aabbccddeeff:00",
+		"sha256:00112233445566778899aabbccddeeff",
+		"sha256:0011:" + strings.Repeat("00", 32),
+		"sha256:00112233445566778899aabbccddeeff:" + strings.Repeat("zz", 32),
+	} {
+		if err := Check(hash); err == nil {
+			t.Errorf("Check(%q): expected an error", hash)
+		}
+		if Verify(hash, nil) {
+			t.Errorf("Verify(%q) = true", hash)
+		}
+	}
+}
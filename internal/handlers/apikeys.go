@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/audit"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+	"go.opentelemetry.io/otel/codes"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// API keys are cluster-scoped; the policy grants verbs on the apikeys resource
+// separately, and only the admin role has them by default.
+
+func (h *SecretHandler) ListAPIKeys(ctx context.Context, request api.ListAPIKeysRequestObject) (api.ListAPIKeysResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.ListAPIKeys")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildListAPIKeysErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbList, Resource: policy.ResourceAPIKeys}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for api keys", slog.String("role", claims.Role))
+		return BuildListAPIKeysErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	keys, err := h.APIKeys.List(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to list api keys")
+		logger.Error("Failed to list api keys", slog.Any("error", err))
+		return BuildListAPIKeysErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	response := api.ListAPIKeys200JSONResponse{Items: make([]api.APIKeyResponse, 0, len(keys))}
+	for _, key := range keys {
+		response.Items = append(response.Items, mapAPIKeyResponse(key))
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return response, nil
+}
+
+// CreateAPIKey issues a new API key. The key can only be scoped down from the
+// caller: its role must grant nothing the caller's role does not, its
+// namespaces must be allowed for the caller, and a caller that is limited to
+// some verbs can only hand out a subset of them.
+func (h *SecretHandler) CreateAPIKey(ctx context.Context, request api.CreateAPIKeyRequestObject) (api.CreateAPIKeyResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.CreateAPIKey")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildCreateAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	body := request.Body
+	event := audit.Event{
+		Action: audit.ActionCreateAPIKey,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   body.Name,
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbCreate, Resource: policy.ResourceAPIKeys}) {
+		event.Reason = "create on apikeys is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for api keys", slog.String("role", claims.Role))
+		return BuildCreateAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	var verbs []string
+	if body.Verbs != nil {
+		verbs = *body.Verbs
+	}
+	if err := h.validateCreateAPIKeyBody(body, verbs); err != nil {
+		span.SetStatus(codes.Error, "Wrong request format")
+		logger.Warn("Wrong request format", slog.Any("error", err))
+		return BuildCreateAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+
+	// A key created with a key would outlive it and survive its deletion.
+	reason := ""
+	if claims.APIKey != "" {
+		reason = "api keys cannot create api keys"
+	}
+	if reason == "" {
+		reason = h.roleDenied(claims, body.Role)
+	}
+	if reason == "" {
+		reason = apiKeyScopeDenied(claims, body.Namespaces, verbs)
+	}
+	if reason != "" {
+		event.Reason = reason
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for api key scope", slog.String("role", claims.Role), slog.String("reason", reason))
+		return BuildCreateAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: " + reason,
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	rawKey, key, err := auth.NewAPIKey()
+	if err == nil {
+		key.Name = body.Name
+		key.Role = body.Role
+		key.Namespaces = body.Namespaces
+		key.Verbs = verbs
+		key.CreatedBy = claims.Username
+		key.CreatedAt = time.Now().UTC().Truncate(time.Second)
+		if body.ExpiresIn != nil {
+			expiresAt := key.CreatedAt.Add(time.Duration(*body.ExpiresIn) * time.Second)
+			key.ExpiresAt = &expiresAt
+		}
+		err = h.APIKeys.Create(ctx, key)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to create api key")
+		logger.Error("Failed to create api key", slog.Any("error", err))
+		return BuildCreateAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+	logger.Info("Created api key", slog.String("id", key.ID), slog.String("name", key.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.CreateAPIKey201JSONResponse{Key: rawKey, ApiKey: mapAPIKeyResponse(key)}, nil
+}
+
+func (h *SecretHandler) DeleteAPIKey(ctx context.Context, request api.DeleteAPIKeyRequestObject) (api.DeleteAPIKeyResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.DeleteAPIKey")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildDeleteAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event := audit.Event{
+		Action: audit.ActionDeleteAPIKey,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   request.Id,
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbDelete, Resource: policy.ResourceAPIKeys}) {
+		event.Reason = "delete on apikeys is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for api keys", slog.String("role", claims.Role))
+		return BuildDeleteAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if err := h.APIKeys.Delete(ctx, request.Id); err != nil {
+		if errors.Is(err, auth.ErrAPIKeyNotFound) {
+			return BuildDeleteAPIKeyErrorResponse(ErrorResult{
+				ErrorMessage: fmt.Sprintf("api key %s not found", request.Id),
+				ErrorCode:    "NotFound",
+				StatusCode:   404,
+			}), nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to delete api key")
+		logger.Error("Failed to delete api key", slog.String("id", request.Id), slog.Any("error", err))
+		return BuildDeleteAPIKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+	logger.Info("Deleted api key", slog.String("id", request.Id))
+	span.SetStatus(codes.Ok, "Success")
+	return api.DeleteAPIKey200JSONResponse{OkResponseJSONResponse: api.OkResponseJSONResponse{Ok: BoolPnc(true)}}, nil
+}
+
+func (h *SecretHandler) validateCreateAPIKeyBody(body *api.CreateAPIKeyRequest, verbs []string) error {
+	if errs := validation.IsDNS1123Label(body.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid name %q: %s", body.Name, strings.Join(errs, ", "))
+	}
+	if !h.roleExists(body.Role) {
+		return fmt.Errorf("unknown role %q", body.Role)
+	}
+	if len(body.Namespaces) == 0 {
+		return fmt.Errorf("namespaces are required")
+	}
+	for _, verb := range verbs {
+		if !policy.IsVerb(verb) {
+			return fmt.Errorf("unknown verb %q", verb)
+		}
+	}
+	if body.ExpiresIn != nil && *body.ExpiresIn <= 0 {
+		return fmt.Errorf("expiresIn must be positive")
+	}
+	return nil
+}
+
+func (h *SecretHandler) roleExists(name string) bool {
+	for _, role := range append(slices.Clone(policy.DefaultRoles), h.cfg.Roles...) {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// roleDenied explains why claims may not hand out role, or returns an empty
+// string if they may.
+func (h *SecretHandler) roleDenied(claims *auth.Claims, role string) string {
+	if !policy.RoleWithin(h.cfg.Roles, role, claims.Role) {
+		return fmt.Sprintf("role %s grants more than the role of the caller", role)
+	}
+	return ""
+}
+
+// apiKeyScopeDenied explains why claims may not issue a key for namespaces and
+// verbs, or returns an empty string if they may.
+func apiKeyScopeDenied(claims *auth.Claims, namespaces, verbs []string) string {
+	if reason := namespacesDenied(claims, namespaces); reason != "" {
+		return reason
+	}
+	if len(claims.Verbs) == 0 {
+		return ""
+	}
+	if len(verbs) == 0 {
+		return "the caller is limited to some verbs and cannot issue a key without verbs"
+	}
+	for _, verb := range verbs {
+		if !claims.AllowsVerb(verb) {
+			return fmt.Sprintf("verb %s is not allowed for the caller", verb)
+		}
+	}
+	return ""
+}
+
+// namespacesDenied names the first of namespaces that is not allowed for
+// claims, or returns an empty string if all of them are.
+func namespacesDenied(claims *auth.Claims, namespaces []string) string {
+	for _, ns := range namespaces {
+		if !auth.IsNamespaceAllowed(ns, claims.AllowedNamespaces) {
+			return fmt.Sprintf("namespace %s is not allowed for the caller", ns)
+		}
+	}
+	return ""
+}
+
+func mapAPIKeyResponse(key auth.APIKey) api.APIKeyResponse {
+	response := api.APIKeyResponse{
+		Id:         key.ID,
+		Name:       key.Name,
+		Role:       key.Role,
+		Namespaces: key.Namespaces,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+	}
+	if len(key.Verbs) > 0 {
+		response.Verbs = &key.Verbs
+	}
+	return response
+}
@@ -2,15 +2,29 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
 	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
 )
 
 func (h *SecretHandler) AuthUser(ctx context.Context, request api.AuthUserRequestObject) (api.AuthUserResponseObject, error) {
-	user := h.cfg.FindUser(request.Body.Username)
+	logger := observability.LoggerFromContext(ctx)
 
+	user, err := h.Users.Get(ctx, request.Body.Username)
+	if err != nil && !errors.Is(err, auth.ErrUserNotFound) {
+		logger.Error("Failed to look up user", slog.Any("error", err))
+		return BuildAuthErrorResponse(ErrorResult{
+			ErrorCode:    "Internal Server Error",
+			StatusCode:   500,
+			ErrorMessage: "Something went wrong",
+		}), nil
+	}
 	if user == nil || !auth.CheckPasswordHash(request.Body.Password, user.PasswordHash) {
 		return BuildAuthErrorResponse(ErrorResult{
 			ErrorCode:    "Unauthorized",
@@ -19,9 +33,7 @@ func (h *SecretHandler) AuthUser(ctx context.Context, request api.AuthUserReques
 		}), nil
 	}
 
-	expiresIn := int64(24 * 60 * 60)
-
-	token, err := auth.GenerateJWT(user, expiresIn, h.cfg.JWT.Secret)
+	response, err := h.issueTokens(user)
 	if err != nil {
 		return BuildAuthErrorResponse(ErrorResult{
 			ErrorCode:    "Internal Server Error",
@@ -30,8 +42,211 @@ func (h *SecretHandler) AuthUser(ctx context.Context, request api.AuthUserReques
 		}), fmt.Errorf("could not generate jwt: %w", err)
 	}
 
-	return api.AuthUser200JSONResponse{
-		Token:     token,
-		ExpiresIn: &expiresIn,
+	return api.AuthUser200JSONResponse(response), nil
+}
+
+// RefreshUserToken exchanges a refresh token for a new token pair. The user is
+// looked up again, so removed users cannot refresh and role changes apply.
+// The refresh token sent is revoked; each one can be used once.
+func (h *SecretHandler) RefreshUserToken(ctx context.Context, request api.RefreshUserTokenRequestObject) (api.RefreshUserTokenResponseObject, error) {
+	logger := observability.LoggerFromContext(ctx)
+
+	if request.Body == nil || request.Body.RefreshToken == "" {
+		return BuildRefreshUserTokenErrorResponse(ErrorResult{
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+			ErrorMessage: "refreshToken is required",
+		}), nil
+	}
+
+	unauthorized := BuildRefreshUserTokenErrorResponse(ErrorResult{
+		ErrorCode:    "Unauthorized",
+		StatusCode:   401,
+		ErrorMessage: "Invalid or expired refresh token",
+	})
+	claims, err := auth.ParseRefreshToken(request.Body.RefreshToken, h.Keys)
+	if err != nil {
+		return unauthorized, nil
+	}
+	user, err := h.Users.Get(ctx, claims.Username)
+	if err != nil && !errors.Is(err, auth.ErrUserNotFound) {
+		logger.Error("Failed to look up user", slog.Any("error", err))
+		return BuildRefreshUserTokenErrorResponse(ErrorResult{
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+			ErrorMessage: "Something went wrong",
+		}), nil
+	}
+	if user == nil || user.ID != claims.Subject || issuedBeforePasswordChange(claims, user) {
+		logger.Warn("Refresh token rejected", slog.String("username", claims.Username))
+		return unauthorized, nil
+	}
+
+	// Revoking is the check for an earlier use, so that of concurrent
+	// requests with the same token only one gets new tokens.
+	err = h.Revocations.Revoke(ctx, claims.ID, claims.ExpiresAt.Time)
+	if errors.Is(err, auth.ErrAlreadyRevoked) {
+		logger.Warn("Refresh token rejected", slog.String("username", claims.Username), slog.Bool("revoked", true))
+		return unauthorized, nil
+	}
+	if err != nil {
+		logger.Error("Failed to revoke used refresh token", slog.Any("error", err))
+		return BuildRefreshUserTokenErrorResponse(ErrorResult{
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+			ErrorMessage: "Something went wrong",
+		}), nil
+	}
+
+	response, err := h.issueTokens(user)
+	if err != nil {
+		return BuildRefreshUserTokenErrorResponse(ErrorResult{
+			ErrorCode:    "Internal Server Error",
+			StatusCode:   500,
+			ErrorMessage: "Something went wrong",
+		}), fmt.Errorf("could not generate jwt: %w", err)
+	}
+	return api.RefreshUserToken200JSONResponse(response), nil
+}
+
+// LogoutUser revokes the access token of the call and the refresh token in
+// the body until they expire.
+func (h *SecretHandler) LogoutUser(ctx context.Context, request api.LogoutUserRequestObject) (api.LogoutUserResponseObject, error) {
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return BuildLogoutUserErrorResponse(ErrorResult{
+			ErrorCode:    "Unauthorized",
+			StatusCode:   401,
+			ErrorMessage: "Unauthorized",
+		}), nil
+	}
+
+	var refreshClaims *auth.Claims
+	if request.Body != nil && request.Body.RefreshToken != nil && *request.Body.RefreshToken != "" {
+		refreshClaims, err = auth.ParseRefreshToken(*request.Body.RefreshToken, h.Keys)
+		// A refresh token that does not validate can no longer be used, so
+		// there is nothing to revoke.
+		if err == nil && refreshClaims.Username != claims.Username {
+			return BuildLogoutUserErrorResponse(ErrorResult{
+				ErrorCode:    "BadRequest",
+				StatusCode:   400,
+				ErrorMessage: "Refresh token belongs to another user",
+			}), nil
+		}
+		if err != nil {
+			refreshClaims = nil
+		}
+	}
+
+	for _, c := range []*auth.Claims{claims, refreshClaims} {
+		if c == nil || c.ID == "" || c.ExpiresAt == nil {
+			continue
+		}
+		if err := h.Revocations.Revoke(ctx, c.ID, c.ExpiresAt.Time); err != nil && !errors.Is(err, auth.ErrAlreadyRevoked) {
+			logger.Error("Failed to revoke token", slog.String("username", claims.Username), slog.Any("error", err))
+			return BuildLogoutUserErrorResponse(ErrorResult{
+				ErrorCode:    "InternalError",
+				StatusCode:   500,
+				ErrorMessage: "Failed to revoke token",
+			}), nil
+		}
+	}
+
+	logger.Info("User logged out", slog.String("username", claims.Username))
+	return api.LogoutUser200JSONResponse{OkResponseJSONResponse: api.OkResponseJSONResponse{Ok: BoolPnc(true)}}, nil
+}
+
+// issuedBeforePasswordChange reports whether the token of claims predates the
+// last password change of user. Tokens issued in the second of the change are
+// kept, since issue times have second precision.
+func issuedBeforePasswordChange(claims *auth.Claims, user *cfg.User) bool {
+	if user.PasswordChangedAt == nil || claims.IssuedAt == nil {
+		return false
+	}
+	return claims.IssuedAt.Time.Before(user.PasswordChangedAt.Truncate(time.Second))
+}
+
+func (h *SecretHandler) issueTokens(user *cfg.User) (api.AuthUserResponse, error) {
+	accessTTL := h.cfg.JWT.AccessTTL()
+	refreshTTL := h.cfg.JWT.RefreshTTL()
+
+	expiresIn := int64(accessTTL / time.Second)
+	token, err := auth.GenerateJWT(user, expiresIn, h.Keys)
+	if err != nil {
+		return api.AuthUserResponse{}, err
+	}
+	refreshToken, err := auth.GenerateRefreshToken(user, refreshTTL, h.Keys)
+	if err != nil {
+		return api.AuthUserResponse{}, err
+	}
+	refreshExpiresIn := int64(refreshTTL / time.Second)
+
+	return api.AuthUserResponse{
+		Token:            token,
+		ExpiresIn:        &expiresIn,
+		RefreshToken:     &refreshToken,
+		RefreshExpiresIn: &refreshExpiresIn,
 	}, nil
 }
+
+// GetOIDCConfig tells clients which identity provider to log in with. It is
+// public so that the CLI can start a login without a token.
+func (h *SecretHandler) GetOIDCConfig(ctx context.Context, request api.GetOIDCConfigRequestObject) (api.GetOIDCConfigResponseObject, error) {
+	if h.cfg.OIDC.Issuer == "" {
+		return BuildGetOIDCConfigErrorResponse(ErrorResult{
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+			ErrorMessage: "OIDC login is not configured",
+		}), nil
+	}
+
+	response := api.GetOIDCConfig200JSONResponse{
+		Issuer:   h.cfg.OIDC.Issuer,
+		ClientId: h.cfg.OIDC.ClientID,
+	}
+	if len(h.cfg.OIDC.Scopes) > 0 {
+		response.Scopes = &h.cfg.OIDC.Scopes
+	}
+	return response, nil
+}
+
+// GetJWKS publishes the public keys that verify the tokens this server
+// issues, so that other services can check them without the server.
+func (h *SecretHandler) GetJWKS(ctx context.Context, request api.GetJWKSRequestObject) (api.GetJWKSResponseObject, error) {
+	logger := observability.LoggerFromContext(ctx)
+
+	set, err := h.Keys.JWKS()
+	if err != nil {
+		logger.Error("Failed to build JWKS", slog.Any("error", err))
+		return BuildGetJWKSErrorResponse(ErrorResult{
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+			ErrorMessage: "Internal Server Error",
+		}), nil
+	}
+	if set == nil {
+		return BuildGetJWKSErrorResponse(ErrorResult{
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+			ErrorMessage: "tokens are signed with a shared secret, there are no public keys",
+		}), nil
+	}
+
+	response := api.GetJWKS200JSONResponse{Keys: make([]api.JSONWebKey, 0, len(set.Keys))}
+	for _, key := range set.Keys {
+		response.Keys = append(response.Keys, api.JSONWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: optionalStr(key.Use),
+			Alg: optionalStr(key.Alg),
+			N:   optionalStr(key.N),
+			E:   optionalStr(key.E),
+			Crv: optionalStr(key.Crv),
+			X:   optionalStr(key.X),
+			Y:   optionalStr(key.Y),
+		})
+	}
+	return response, nil
+}
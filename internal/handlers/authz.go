@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+)
+
+// updateVerb returns rotate for an update that only asks for regeneration and
+// update for anything else, so a rotator role cannot change the spec.
+func updateVerb(body *api.UpdateSecretRequest) string {
+	rest := *body
+	rest.Regenerate, rest.RegenerateKeys = nil, nil
+	if rest == (api.UpdateSecretRequest{}) && (body.Regenerate != nil || body.RegenerateKeys != nil) {
+		return policy.VerbRotate
+	}
+	return policy.VerbUpdate
+}
+
+// claimLabelsAllowed checks verb against the current labels of a claim and, when
+// an update replaces them, against the new labels too.
+func (h *SecretHandler) claimLabelsAllowed(ctx context.Context, claims *auth.Claims, verb, namespace string, current map[string]string, updated *map[string]string) bool {
+	attrs := policy.Attributes{
+		Verb:      verb,
+		Resource:  policy.ResourceSecrets,
+		Namespace: namespace,
+		Labels:    current,
+	}
+	if !h.Authorizer.Authorize(ctx, claims, attrs) {
+		return false
+	}
+	if updated != nil {
+		attrs.Labels = *updated
+		return h.Authorizer.Authorize(ctx, claims, attrs)
+	}
+	return true
+}
+
+// targetsAllowed reports whether the caller may replicate into targets. Every
+// listed namespace has to allow verb; a selector can match any namespace and
+// needs verb granted in all of them.
+func (h *SecretHandler) targetsAllowed(ctx context.Context, claims *auth.Claims, verb string, targets *api.TargetsConfig) bool {
+	if targets.NamespaceSelector != nil && !h.Authorizer.AllowsNamespace(ctx, claims, verb, policy.ResourceSecrets, "*") {
+		return false
+	}
+	if targets.Namespaces != nil {
+		for _, ns := range *targets.Namespaces {
+			if !h.Authorizer.AllowsNamespace(ctx, claims, verb, policy.ResourceSecrets, ns) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func derefMap(m *map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	return *m
+}
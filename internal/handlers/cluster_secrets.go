@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+	"go.opentelemetry.io/otel/codes"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ClusterSecretClaims fan out to any namespace; the policy grants verbs on the
+// clustersecrets resource separately, and only the admin role has them by default.
+
+func (h *SecretHandler) CreateClusterSecret(ctx context.Context, request api.CreateClusterSecretRequestObject) (api.CreateClusterSecretResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.CreateClusterSecret")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildCreateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:     policy.VerbCreate,
+		Resource: policy.ResourceClusterSecrets,
+		Labels:   derefMap(request.Body.Labels),
+	}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secrets", slog.String("role", claims.Role))
+		return BuildCreateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	body := request.Body
+	if err := validateCreateSecretBody(&api.CreateSecretRequest{
+		Name:             body.Name,
+		Type:             api.CreateSecretRequestType(body.Type),
+		Data:             body.Data,
+		EncryptedData:    body.EncryptedData,
+		GenerationConfig: body.GenerationConfig,
+		Rotation:         body.Rotation,
+		Certificate:      body.Certificate,
+		Source:           body.Source,
+		DeletionPolicy:   body.DeletionPolicy,
+		SecretType:       body.SecretType,
+		DockerConfig:     body.DockerConfig,
+		Targets:          &body.Targets,
+		Push:             body.Push,
+		Templates:        body.Templates,
+	}); err != nil {
+		span.SetStatus(codes.Error, "Wrong request format")
+		logger.Warn("Wrong request format", slog.String("request_type", string(body.Type)), slog.Any("error", err))
+		return BuildCreateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+
+	err = h.K8sManager.CreateClusterSecretClaim(ctx, body.Name, string(body.Type), body.Data, body.EncryptedData, body.GenerationConfig, body.Rotation, body.Templates, body.DeletionPolicy, body.SecretType, body.DockerConfig, body.Certificate, body.Source, &body.Targets, body.Push, body.Labels, body.Annotations)
+	if err != nil {
+		return BuildCreateClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+
+	logger.Info("Successfully created cluster secret claim", slog.String("name", body.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.CreateClusterSecret201JSONResponse{
+		OkResponseJSONResponse: api.OkResponseJSONResponse{
+			Ok: BoolPnc(true),
+		},
+	}, nil
+}
+
+func (h *SecretHandler) ListClusterSecrets(ctx context.Context, request api.ListClusterSecretsRequestObject) (api.ListClusterSecretsResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.ListClusterSecrets")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildListClusterSecretsErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbList, policy.ResourceClusterSecrets, "") {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secrets", slog.String("role", claims.Role))
+		return BuildListClusterSecretsErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	claimList, err := h.K8sManager.ListClusterSecretClaim(ctx)
+	if err != nil {
+		return BuildListClusterSecretsErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+
+	views := make([]secretsv1alpha1.SecretClaim, 0, len(claimList.Items))
+	for i := range claimList.Items {
+		if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+			Verb:     policy.VerbList,
+			Resource: policy.ResourceClusterSecrets,
+			Labels:   claimList.Items[i].Labels,
+		}) {
+			continue
+		}
+		views = append(views, *clusterClaimView(&claimList.Items[i]))
+	}
+	items := mapSecretListToResponseList(views)
+	for i := range items {
+		items[i].Namespace = nil
+	}
+
+	logger.Info("Successfully fetched cluster secret claims list", slog.Int("count", len(items)))
+	span.SetStatus(codes.Ok, "Success")
+	return api.ListClusterSecrets200JSONResponse{
+		Items: items,
+	}, nil
+}
+
+func (h *SecretHandler) GetClusterSecret(ctx context.Context, request api.GetClusterSecretRequestObject) (api.GetClusterSecretResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.GetClusterSecret")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildGetClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbGet, policy.ResourceClusterSecrets, "") {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secrets", slog.String("role", claims.Role))
+		return BuildGetClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	cluster, err := h.K8sManager.GetClusterSecretClaim(ctx, request.Name)
+	if err != nil {
+		return BuildGetClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:     policy.VerbGet,
+		Resource: policy.ResourceClusterSecrets,
+		Labels:   cluster.Labels,
+	}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secret claim labels", slog.String("name", request.Name), slog.String("role", claims.Role))
+		return BuildGetClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	var actualData *corev1.Secret = nil
+	if cluster.Status.Synced && cluster.Status.SourceNamespace != "" {
+		actualData, err = h.K8sManager.GetActualSecret(ctx, request.Name, cluster.Status.SourceNamespace)
+		if err != nil {
+			return BuildGetClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+		}
+	}
+
+	if actualData == nil && cluster.Spec.Type == "Opaque" {
+		logger.Warn("ClusterSecretClaim is not synced yet; no actual secret data available", slog.String("name", request.Name))
+		return BuildGetClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "ClusterSecretClaim is not synced yet; no actual secret data available",
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+
+	secretResponse := mapClaimToSecretResponse(clusterClaimView(cluster), actualData)
+	secretResponse.Namespace = nil
+
+	logger.Info("Successfully fetched cluster secret claim", slog.String("name", request.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.GetClusterSecret200JSONResponse(secretResponse), nil
+}
+
+func (h *SecretHandler) UpdateClusterSecret(ctx context.Context, request api.UpdateClusterSecretRequestObject) (api.UpdateClusterSecretResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.UpdateClusterSecret")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildUpdateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	verb := updateVerb(request.Body)
+	if !h.Authorizer.AllowsNamespace(ctx, claims, verb, policy.ResourceClusterSecrets, "") {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secrets", slog.String("role", claims.Role))
+		return BuildUpdateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if err := validateUpdateSecretBody(request.Body); err != nil {
+		span.SetStatus(codes.Error, "Wrong request format")
+		logger.Warn("Wrong request format", slog.Any("error", err))
+		return BuildUpdateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+
+	existing, err := h.K8sManager.GetClusterSecretClaim(ctx, request.Name)
+	if err != nil {
+		return BuildUpdateClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	attrs := policy.Attributes{Verb: verb, Resource: policy.ResourceClusterSecrets, Labels: existing.Labels}
+	newAttrs := attrs
+	if request.Body.Labels != nil {
+		newAttrs.Labels = *request.Body.Labels
+	}
+	if !h.Authorizer.Authorize(ctx, claims, attrs) || !h.Authorizer.Authorize(ctx, claims, newAttrs) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secret claim labels", slog.String("name", request.Name), slog.String("role", claims.Role))
+		return BuildUpdateClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	newType := ""
+	if request.Body.Type != nil {
+		newType = string(*request.Body.Type)
+	}
+	regenerate := false
+	if request.Body.Regenerate != nil {
+		regenerate = *request.Body.Regenerate
+	}
+
+	err = h.K8sManager.UpdateClusterSecretClaim(ctx, request.Name,
+		newType, regenerate, request.Body.RegenerateKeys, request.Body.Data, request.Body.EncryptedData, request.Body.GenerationConfig, request.Body.Rotation,
+		request.Body.Templates, request.Body.DeletionPolicy, request.Body.SecretType, request.Body.DockerConfig, request.Body.Certificate, request.Body.Source, request.Body.Targets, request.Body.Push, request.Body.Labels, request.Body.Annotations)
+	if err != nil {
+		return BuildUpdateClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+
+	logger.Info("Successfully updated cluster secret claim", slog.String("name", request.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.UpdateClusterSecret200JSONResponse{
+		OkResponseJSONResponse: api.OkResponseJSONResponse{
+			Ok: BoolPnc(true),
+		},
+	}, nil
+}
+
+func (h *SecretHandler) DeleteClusterSecret(ctx context.Context, request api.DeleteClusterSecretRequestObject) (api.DeleteClusterSecretResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.DeleteClusterSecret")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildDeleteClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbDelete, policy.ResourceClusterSecrets, "") {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secrets", slog.String("role", claims.Role))
+		return BuildDeleteClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	existing, err := h.K8sManager.GetClusterSecretClaim(ctx, request.Name)
+	if err != nil {
+		return BuildDeleteClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:     policy.VerbDelete,
+		Resource: policy.ResourceClusterSecrets,
+		Labels:   existing.Labels,
+	}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for cluster secret claim labels", slog.String("name", request.Name), slog.String("role", claims.Role))
+		return BuildDeleteClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	err = h.K8sManager.DeleteClusterSecretClaim(ctx, request.Name)
+	if err != nil {
+		return BuildDeleteClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+
+	logger.Info("Successfully deleted cluster secret claim", slog.String("name", request.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.DeleteClusterSecret200JSONResponse{
+		OkResponseJSONResponse: api.OkResponseJSONResponse{
+			Ok: BoolPnc(true),
+		},
+	}, nil
+}
+
+// clusterClaimView presents a ClusterSecretClaim as a SecretClaim so the SecretClaim
+// response mappers can be reused.
+func clusterClaimView(cluster *secretsv1alpha1.ClusterSecretClaim) *secretsv1alpha1.SecretClaim {
+	return &secretsv1alpha1.SecretClaim{
+		ObjectMeta: cluster.ObjectMeta,
+		Spec:       cluster.Spec,
+		Status:     cluster.Status.SecretClaimStatus,
+	}
+}
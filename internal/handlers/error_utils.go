@@ -52,6 +52,15 @@ func HandleK8sError(ctx context.Context, err error) ErrorResult {
 				ErrorMessage: fmt.Sprintf("Secret already exists: %s", k8sMessage),
 			}
 
+		case http.StatusBadRequest, http.StatusUnprocessableEntity: // 400, 422 (в т.ч. отказ admission webhook)
+			span.SetStatus(codes.Error, "K8s API: Invalid")
+			logger.Warn("K8s API Rejected Request", slog.Any("k8s_status", status), slog.Any("k8s_message", k8sMessage), slog.Any("error", err.Error()))
+			return ErrorResult{
+				StatusCode:   http.StatusBadRequest,
+				ErrorCode:    "BadRequest",
+				ErrorMessage: fmt.Sprintf("Invalid request: %s", k8sMessage),
+			}
+
 		case http.StatusNotFound: // 404
 			span.SetStatus(codes.Error, "K8s API: Not Found")
 			logger.Warn("K8s API Resource Not Found (404)", slog.Any("k8s_status", status), slog.Any("k8s_message", k8sMessage), slog.Any("error", err.Error()))
@@ -160,6 +169,118 @@ func BuildDeleteSecretErrorResponse(res ErrorResult) api.DeleteSecretResponseObj
 	}
 }
 
+func BuildRevealSecretErrorResponse(res ErrorResult) api.RevealSecretResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.RevealSecret400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.RevealSecret403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.RevealSecret404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.RevealSecret500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildCreateClusterSecretErrorResponse(res ErrorResult) api.CreateClusterSecretResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.CreateClusterSecret400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.CreateClusterSecret403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 409:
+		return api.CreateClusterSecret409JSONResponse{ConflictJSONResponse: api.ConflictJSONResponse(commonBody)}
+	case 404:
+		return api.CreateClusterSecret404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.CreateClusterSecret500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildListClusterSecretsErrorResponse(res ErrorResult) api.ListClusterSecretsResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.ListClusterSecrets400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.ListClusterSecrets403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.ListClusterSecrets404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.ListClusterSecrets500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildGetClusterSecretErrorResponse(res ErrorResult) api.GetClusterSecretResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.GetClusterSecret400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.GetClusterSecret403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.GetClusterSecret404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.GetClusterSecret500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildUpdateClusterSecretErrorResponse(res ErrorResult) api.UpdateClusterSecretResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.UpdateClusterSecret400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.UpdateClusterSecret403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.UpdateClusterSecret404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.UpdateClusterSecret500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildDeleteClusterSecretErrorResponse(res ErrorResult) api.DeleteClusterSecretResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.DeleteClusterSecret400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.DeleteClusterSecret403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.DeleteClusterSecret404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.DeleteClusterSecret500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildRevealClusterSecretErrorResponse(res ErrorResult) api.RevealClusterSecretResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.RevealClusterSecret400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 403:
+		return api.RevealClusterSecret403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.RevealClusterSecret404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.RevealClusterSecret500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildGetSealingKeyErrorResponse(res ErrorResult) api.GetSealingKeyResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 401:
+		return api.GetSealingKey401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 404:
+		return api.GetSealingKey404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.GetSealingKey500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
 func BuildAuthErrorResponse(res ErrorResult) api.AuthUserResponseObject {
 	commonBody := getCommonBody(res)
 	switch res.StatusCode {
@@ -171,3 +292,177 @@ func BuildAuthErrorResponse(res ErrorResult) api.AuthUserResponseObject {
 		return api.AuthUser500JSONResponse{InternalJSONResponse: commonBody}
 	}
 }
+
+func BuildGetOIDCConfigErrorResponse(res ErrorResult) api.GetOIDCConfigResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 404:
+		return api.GetOIDCConfig404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.GetOIDCConfig500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildRefreshUserTokenErrorResponse(res ErrorResult) api.RefreshUserTokenResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.RefreshUserToken400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 401:
+		return api.RefreshUserToken401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	default:
+		return api.RefreshUserToken500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildLogoutUserErrorResponse(res ErrorResult) api.LogoutUserResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.LogoutUser400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 401:
+		return api.LogoutUser401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	default:
+		return api.LogoutUser500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildGetJWKSErrorResponse(res ErrorResult) api.GetJWKSResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 404:
+		return api.GetJWKS404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.GetJWKS500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildListAPIKeysErrorResponse(res ErrorResult) api.ListAPIKeysResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 401:
+		return api.ListAPIKeys401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.ListAPIKeys403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	default:
+		return api.ListAPIKeys500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildCreateAPIKeyErrorResponse(res ErrorResult) api.CreateAPIKeyResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.CreateAPIKey400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 401:
+		return api.CreateAPIKey401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.CreateAPIKey403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	default:
+		return api.CreateAPIKey500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildDeleteAPIKeyErrorResponse(res ErrorResult) api.DeleteAPIKeyResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 401:
+		return api.DeleteAPIKey401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.DeleteAPIKey403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.DeleteAPIKey404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.DeleteAPIKey500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildChangePasswordErrorResponse(res ErrorResult) api.ChangePasswordResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.ChangePassword400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 401:
+		return api.ChangePassword401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 409:
+		return api.ChangePassword409JSONResponse{ConflictJSONResponse: api.ConflictJSONResponse(commonBody)}
+	default:
+		return api.ChangePassword500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildListUsersErrorResponse(res ErrorResult) api.ListUsersResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 401:
+		return api.ListUsers401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.ListUsers403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	default:
+		return api.ListUsers500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildCreateUserErrorResponse(res ErrorResult) api.CreateUserResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.CreateUser400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 401:
+		return api.CreateUser401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.CreateUser403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 409:
+		return api.CreateUser409JSONResponse{ConflictJSONResponse: api.ConflictJSONResponse(commonBody)}
+	default:
+		return api.CreateUser500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildGetUserErrorResponse(res ErrorResult) api.GetUserResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 401:
+		return api.GetUser401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.GetUser403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.GetUser404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	default:
+		return api.GetUser500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildUpdateUserErrorResponse(res ErrorResult) api.UpdateUserResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 400:
+		return api.UpdateUser400JSONResponse{BadRequestJSONResponse: api.BadRequestJSONResponse(commonBody)}
+	case 401:
+		return api.UpdateUser401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.UpdateUser403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.UpdateUser404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	case 409:
+		return api.UpdateUser409JSONResponse{ConflictJSONResponse: api.ConflictJSONResponse(commonBody)}
+	default:
+		return api.UpdateUser500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
+
+func BuildDeleteUserErrorResponse(res ErrorResult) api.DeleteUserResponseObject {
+	commonBody := getCommonBody(res)
+	switch res.StatusCode {
+	case 401:
+		return api.DeleteUser401JSONResponse{UnauthorizedJSONResponse: api.UnauthorizedJSONResponse(commonBody)}
+	case 403:
+		return api.DeleteUser403JSONResponse{ForbiddenJSONResponse: api.ForbiddenJSONResponse(commonBody)}
+	case 404:
+		return api.DeleteUser404JSONResponse{NotFoundJSONResponse: api.NotFoundJSONResponse(commonBody)}
+	case 409:
+		return api.DeleteUser409JSONResponse{ConflictJSONResponse: api.ConflictJSONResponse(commonBody)}
+	default:
+		return api.DeleteUser500JSONResponse{InternalJSONResponse: commonBody}
+	}
+}
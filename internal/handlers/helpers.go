@@ -1,11 +1,25 @@
 package handlers
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/robfig/cron/v3"
+
 	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/certs"
+	"github.com/mogilyoy/k8s-secret-manager/internal/keygen"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sealing"
+	"github.com/mogilyoy/k8s-secret-manager/internal/secrettypes"
+	"github.com/mogilyoy/k8s-secret-manager/internal/templates"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func StrPnc(v string) *string {
@@ -24,17 +38,43 @@ func MapStrStrPnc(v map[string]string) *map[string]string {
 	return &v
 }
 
+// optionalStr returns nil for an empty string, which is left out of responses.
+func optionalStr(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// maskedValue replaces every Secret value in responses other than the reveal
+// endpoints.
+const maskedValue = "********"
+
+// secretData decodes the values of a Secret.
+func secretData(data map[string][]byte) map[string]string {
+	result := make(map[string]string, len(data))
+	for k, v := range data {
+		result[k] = string(v)
+	}
+	return result
+}
+
 func mapClaimToSecretResponse(claim *secretsv1alpha1.SecretClaim, secret *corev1.Secret) api.SecretResponse {
-	externalStatus := "Pending"
+	externalStatus := claimCurrentStatus(claim)
 	var errorMessage *string = nil
 	var secretName *string = nil
 	var lastSyncTime *time.Time = nil
+	var lastRotationTime *time.Time = nil
+	var nextRotationTime *time.Time = nil
+	var notAfter *time.Time = nil
+	var sourceVersion *string = nil
+	var pushStatus *api.PushStatus = nil
+	var observedGeneration *int64 = nil
+	var conditions *[]api.Condition = nil
+	var targetStatuses *[]api.TargetStatus = nil
 
 	if claim.Status.ErrorMessage != "" {
-		externalStatus = "Error"
 		errorMessage = &claim.Status.ErrorMessage
-	} else if claim.Status.Synced {
-		externalStatus = "Ready"
 	}
 
 	if claim.Status.CreatedSecretName != "" {
@@ -43,6 +83,41 @@ func mapClaimToSecretResponse(claim *secretsv1alpha1.SecretClaim, secret *corev1
 	if claim.Status.LastUpdate != nil && !claim.Status.LastUpdate.IsZero() {
 		lastSyncTime = &claim.Status.LastUpdate.Time
 	}
+	if claim.Status.LastRotationTime != nil {
+		lastRotationTime = &claim.Status.LastRotationTime.Time
+	}
+	if claim.Status.NextRotationTime != nil {
+		nextRotationTime = &claim.Status.NextRotationTime.Time
+	}
+	if claim.Status.NotAfter != nil {
+		notAfter = &claim.Status.NotAfter.Time
+	}
+	if claim.Status.SourceVersion != "" {
+		sourceVersion = &claim.Status.SourceVersion
+	}
+	if st := claim.Status.Push; st != nil {
+		pushStatus = &api.PushStatus{Result: st.Result}
+		if st.Message != "" {
+			pushStatus.Message = StrPnc(st.Message)
+		}
+		if st.Version != "" {
+			pushStatus.Version = StrPnc(st.Version)
+		}
+		if st.LastPushTime != nil {
+			pushStatus.LastPushTime = &st.LastPushTime.Time
+		}
+	}
+	if claim.Status.ObservedGeneration != 0 {
+		observedGeneration = &claim.Status.ObservedGeneration
+	}
+	if len(claim.Status.Conditions) > 0 {
+		mapped := mapConditions(claim.Status.Conditions)
+		conditions = &mapped
+	}
+	if len(claim.Status.Targets) > 0 {
+		mapped := mapTargetStatuses(claim.Status.Targets)
+		targetStatuses = &mapped
+	}
 
 	var generationConfig *api.GenerationConfig
 	if claim.Spec.Generation != nil {
@@ -53,12 +128,149 @@ func mapClaimToSecretResponse(claim *secretsv1alpha1.SecretClaim, secret *corev1
 			Encoding: &enc,
 			DataKeys: &dKeys,
 		}
+		if len(claim.Spec.Generation.Keys) > 0 {
+			keys := make(map[string]api.KeyGeneration, len(claim.Spec.Generation.Keys))
+			for key, cfg := range claim.Spec.Generation.Keys {
+				keys[key] = toAPIKeyGeneration(cfg)
+			}
+			generationConfig.Keys = &keys
+		}
 	}
 
-	secretData := make(map[string]string)
+	var rotation *api.RotationConfig
+	if claim.Spec.Rotation != nil {
+		rotation = &api.RotationConfig{}
+		if claim.Spec.Rotation.Interval != nil {
+			rotation.Interval = StrPnc(claim.Spec.Rotation.Interval.Duration.String())
+		}
+		if claim.Spec.Rotation.Schedule != "" {
+			rotation.Schedule = StrPnc(claim.Spec.Rotation.Schedule)
+		}
+	}
+
+	var certificate *api.CertificateConfig
+	if cfg := claim.Spec.Certificate; cfg != nil {
+		certificate = &api.CertificateConfig{
+			CommonName: StrPnc(cfg.CommonName),
+			IsCA:       BoolPnc(cfg.IsCA),
+		}
+		if len(cfg.DNSNames) > 0 {
+			certificate.DnsNames = &cfg.DNSNames
+		}
+		if len(cfg.IPAddresses) > 0 {
+			certificate.IpAddresses = &cfg.IPAddresses
+		}
+		if cfg.KeyAlgorithm != "" {
+			algorithm := api.CertificateConfigKeyAlgorithm(cfg.KeyAlgorithm)
+			certificate.KeyAlgorithm = &algorithm
+		}
+		if cfg.KeySize != 0 {
+			certificate.KeySize = IntPnc(cfg.KeySize)
+		}
+		if cfg.Duration != nil {
+			certificate.Duration = StrPnc(cfg.Duration.Duration.String())
+		}
+		if cfg.RenewBefore != nil {
+			certificate.RenewBefore = StrPnc(cfg.RenewBefore.Duration.String())
+		}
+		if cfg.IssuerRef != "" {
+			certificate.IssuerRef = StrPnc(cfg.IssuerRef)
+		}
+	}
+
+	var source *api.SourceConfig
+	if cfg := claim.Spec.Source; cfg != nil {
+		source = &api.SourceConfig{
+			Provider: api.SourceConfigProvider(cfg.Provider),
+			Path:     cfg.Path,
+		}
+		if cfg.Address != "" {
+			source.Address = StrPnc(cfg.Address)
+		}
+		if cfg.Mount != "" {
+			source.Mount = StrPnc(cfg.Mount)
+		}
+		if cfg.CredentialsSecretRef != "" {
+			source.CredentialsSecretRef = StrPnc(cfg.CredentialsSecretRef)
+		}
+		if len(cfg.Keys) > 0 {
+			source.Keys = MapStrStrPnc(cfg.Keys)
+		}
+		if cfg.RefreshInterval != nil {
+			source.RefreshInterval = StrPnc(cfg.RefreshInterval.Duration.String())
+		}
+	}
+
+	var push *api.PushConfig
+	if cfg := claim.Spec.Push; cfg != nil {
+		push = &api.PushConfig{
+			Provider: api.PushConfigProvider(cfg.Provider),
+			Path:     cfg.Path,
+		}
+		if cfg.Address != "" {
+			push.Address = StrPnc(cfg.Address)
+		}
+		if cfg.Mount != "" {
+			push.Mount = StrPnc(cfg.Mount)
+		}
+		if cfg.CredentialsSecretRef != "" {
+			push.CredentialsSecretRef = StrPnc(cfg.CredentialsSecretRef)
+		}
+		if len(cfg.Keys) > 0 {
+			push.Keys = MapStrStrPnc(cfg.Keys)
+		}
+		if cfg.ConflictPolicy != "" {
+			policy := api.PushConfigConflictPolicy(cfg.ConflictPolicy)
+			push.ConflictPolicy = &policy
+		}
+	}
+
+	var targets *api.TargetsConfig
+	if cfg := claim.Spec.Targets; cfg != nil {
+		targets = &api.TargetsConfig{}
+		if len(cfg.Namespaces) > 0 {
+			targets.Namespaces = &cfg.Namespaces
+		}
+		if cfg.NamespaceSelector != nil {
+			targets.NamespaceSelector = MapStrStrPnc(cfg.NamespaceSelector.MatchLabels)
+		}
+	}
+
+	var templatesPtr *map[string]string
+	if len(claim.Spec.Templates) > 0 {
+		templatesPtr = &claim.Spec.Templates
+	}
+
+	var encryptedData *map[string]string
+	if len(claim.Spec.EncryptedData) > 0 {
+		encryptedData = &claim.Spec.EncryptedData
+	}
+
+	deletionPolicy := api.DeletionPolicy(secretsv1alpha1.DeletionPolicyDelete)
+	if claim.Spec.DeletionPolicy != "" {
+		deletionPolicy = api.DeletionPolicy(claim.Spec.DeletionPolicy)
+	}
+
+	secretType := api.SecretType(secrettypes.SecretType(claim.Spec.SecretType))
+	if claim.Spec.Type == "Certificate" {
+		secretType = api.SecretTypeKubernetesIoTls
+	}
+
+	// The registry password lives only in the password and .dockerconfigjson keys of
+	// the Secret, which are masked below like every other value.
+	var dockerConfig *api.DockerConfig
+	if claim.Spec.DockerConfig != nil {
+		dockerConfig = &api.DockerConfig{
+			Registry: claim.Spec.DockerConfig.Registry,
+			Username: claim.Spec.DockerConfig.Username,
+		}
+	}
+
+	// Values are masked; only the reveal endpoints return them.
+	maskedData := make(map[string]string)
 	if secret != nil {
-		for k, v := range secret.Data {
-			secretData[k] = string(v)
+		for k := range secret.Data {
+			maskedData[k] = maskedValue
 		}
 	}
 
@@ -74,15 +286,34 @@ func mapClaimToSecretResponse(claim *secretsv1alpha1.SecretClaim, secret *corev1
 		Annotations:       &claim.Annotations,
 		// -----------------------------------
 
-		Data:             &secretData,
+		Data:             &maskedData,
+		EncryptedData:    encryptedData,
 		GenerationConfig: generationConfig,
+		Rotation:         rotation,
+		Certificate:      certificate,
+		Source:           source,
+		Push:             push,
+		Templates:        templatesPtr,
+		DeletionPolicy:   &deletionPolicy,
+		SecretType:       &secretType,
+		DockerConfig:     dockerConfig,
+		Targets:          targets,
 
 		Status: api.SecretStatus{
-			CurrentStatus: api.SecretStatusCurrentStatus(externalStatus),
-			Synced:        claim.Status.Synced,
-			SecretName:    secretName,
-			LastSyncTime:  lastSyncTime,
-			ErrorMessage:  errorMessage,
+			CurrentStatus:    api.SecretStatusCurrentStatus(externalStatus),
+			Synced:           claim.Status.Synced,
+			SecretName:       secretName,
+			LastSyncTime:     lastSyncTime,
+			ErrorMessage:     errorMessage,
+			LastRotationTime: lastRotationTime,
+			NextRotationTime: nextRotationTime,
+			NotAfter:         notAfter,
+			SourceVersion:    sourceVersion,
+			Push:             pushStatus,
+
+			ObservedGeneration: observedGeneration,
+			Conditions:         conditions,
+			Targets:            targetStatuses,
 		},
 	}
 }
@@ -90,12 +321,7 @@ func mapClaimToSecretResponse(claim *secretsv1alpha1.SecretClaim, secret *corev1
 func mapSecretListToResponseList(claims []secretsv1alpha1.SecretClaim) []api.SecretSummary {
 	result := make([]api.SecretSummary, 0, len(claims))
 	for _, claim := range claims {
-		externalStatus := "Pending"
-		if claim.Status.ErrorMessage != "" {
-			externalStatus = "Error"
-		} else if claim.Status.Synced {
-			externalStatus = "Ready"
-		}
+		externalStatus := claimCurrentStatus(&claim)
 
 		result = append(result, api.SecretSummary{
 			Name:              claim.Name,
@@ -111,3 +337,575 @@ func mapSecretListToResponseList(claims []secretsv1alpha1.SecretClaim) []api.Sec
 	}
 	return result
 }
+
+// validateTemplates checks that templates parse and do not shadow keys passed in the same request.
+// validateCreateSecretBody checks a create request before it reaches Kubernetes. The
+// error text is returned to the client as is.
+func validateCreateSecretBody(body *api.CreateSecretRequest) error {
+	isValidType := body.Type == api.CreateSecretRequestTypeOpaque || body.Type == api.CreateSecretRequestTypeAutoGenerated || body.Type == api.CreateSecretRequestTypeCertificate || body.Type == api.CreateSecretRequestTypeExternal
+	isAutoGenerated := body.Type == api.CreateSecretRequestTypeAutoGenerated
+	isCertificate := body.Type == api.CreateSecretRequestTypeCertificate
+	isExternal := body.Type == api.CreateSecretRequestTypeExternal
+	configProvided := body.GenerationConfig != nil
+	certificateProvided := body.Certificate != nil
+	sourceProvided := body.Source != nil
+	dataProvided := body.Data != nil && len(*body.Data) > 0
+
+	if isAutoGenerated && !configProvided || (!isAutoGenerated && configProvided) || isCertificate != certificateProvided || isExternal != sourceProvided || (isExternal && dataProvided) || !isValidType {
+		return fmt.Errorf("Wrong request format")
+	}
+
+	if body.Rotation != nil {
+		if err := validateRotationConfig(body.Rotation); err != nil || !isAutoGenerated {
+			return fmt.Errorf("Wrong request format: rotation requires AutoGenerated type and a valid interval or cron schedule")
+		}
+	}
+
+	if err := validateKeyGenerations(body.GenerationConfig); err != nil {
+		return fmt.Errorf("Wrong request format: %w", err)
+	}
+
+	if isCertificate {
+		if err := validateCertificateConfig(body.Certificate); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if isExternal {
+		if err := validateSourceConfig(body.Source); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if body.Push != nil {
+		if isExternal {
+			return fmt.Errorf("Wrong request format: push is not allowed for External claims")
+		}
+		if err := validatePushConfig(body.Push); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if err := validateEncryptedData(body.EncryptedData, body.Data, body.Type == api.CreateSecretRequestTypeOpaque); err != nil {
+		return fmt.Errorf("Wrong request format: %w", err)
+	}
+
+	if err := validateSecretType(body.SecretType, body.DockerConfig, body.Type, opaqueKeys(body.Data, body.EncryptedData), body.GenerationConfig, body.Source, body.Templates); err != nil {
+		return fmt.Errorf("Wrong request format: %w", err)
+	}
+
+	if body.DeletionPolicy != nil && !validDeletionPolicy(body.DeletionPolicy) {
+		return fmt.Errorf("Wrong request format: deletionPolicy must be one of Delete, Retain, Orphan")
+	}
+
+	if body.Targets != nil {
+		if err := validateTargets(body.Targets); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if body.Templates != nil {
+		if err := validateTemplates(body.Templates, opaqueKeys(body.Data, body.EncryptedData), body.GenerationConfig, body.Source); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateUpdateSecretBody checks an update request before it reaches Kubernetes. The
+// error text is returned to the client as is.
+func validateUpdateSecretBody(body *api.UpdateSecretRequest) error {
+	dataProvided := body.Data != nil && len(*body.Data) > 0
+	configProvided := body.GenerationConfig != nil
+	rotationProvided := body.Rotation != nil
+	regenerateKeysProvided := body.RegenerateKeys != nil && len(*body.RegenerateKeys) > 0
+	certificateProvided := body.Certificate != nil
+	sourceProvided := body.Source != nil
+
+	if body.Type != nil {
+		isAutoGenerated := *body.Type == api.UpdateSecretRequestTypeAutoGenerated
+		isCertificate := *body.Type == api.UpdateSecretRequestTypeCertificate
+		isExternal := *body.Type == api.UpdateSecretRequestTypeExternal
+		if (isAutoGenerated && dataProvided) || (!isAutoGenerated && (configProvided || rotationProvided || regenerateKeysProvided)) ||
+			(isCertificate && dataProvided) || (!isCertificate && certificateProvided) ||
+			(isExternal && dataProvided) || (!isExternal && sourceProvided) {
+			return fmt.Errorf("Wrong request format: AutoGenerated requires GenerationConfig, Opaque requires Data, Certificate requires Certificate, External requires Source")
+		}
+	}
+
+	if err := validateEncryptedData(body.EncryptedData, body.Data, body.Type == nil || *body.Type == api.UpdateSecretRequestTypeOpaque); err != nil {
+		return fmt.Errorf("Wrong request format: %w", err)
+	}
+
+	if err := validateKeyGenerations(body.GenerationConfig); err != nil {
+		return fmt.Errorf("Wrong request format: %w", err)
+	}
+
+	if certificateProvided {
+		if err := validateCertificateConfig(body.Certificate); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if sourceProvided {
+		if err := validateSourceConfig(body.Source); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	// A push without path stops pushing.
+	if body.Push != nil && body.Push.Path != "" {
+		if body.Type != nil && *body.Type == api.UpdateSecretRequestTypeExternal {
+			return fmt.Errorf("Wrong request format: push is not allowed for External claims")
+		}
+		if err := validatePushConfig(body.Push); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if rotationProvided {
+		if err := validateRotationConfig(body.Rotation); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if body.Templates != nil {
+		if err := validateTemplates(body.Templates, opaqueKeys(body.Data, body.EncryptedData), body.GenerationConfig, body.Source); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if body.Targets != nil {
+		if err := validateTargets(body.Targets); err != nil {
+			return fmt.Errorf("Wrong request format: %w", err)
+		}
+	}
+
+	if !validSecretTypeUpdate(body.SecretType, body.DockerConfig) {
+		return fmt.Errorf("Wrong request format: unsupported secretType or incomplete dockerConfig")
+	}
+
+	if body.DeletionPolicy != nil && !validDeletionPolicy(body.DeletionPolicy) {
+		return fmt.Errorf("Wrong request format: deletionPolicy must be one of Delete, Retain, Orphan")
+	}
+	return nil
+}
+
+func validateTemplates(tmpls *map[string]string, data *map[string]string, generationConfig *api.GenerationConfig, source *api.SourceConfig) error {
+	var reservedKeys []string
+	if data != nil {
+		for k := range *data {
+			reservedKeys = append(reservedKeys, k)
+		}
+	}
+	reservedKeys = append(reservedKeys, generatedKeys(generationConfig)...)
+	reservedKeys = append(reservedKeys, sourceKeys(source)...)
+	return templates.Validate(*tmpls, reservedKeys)
+}
+
+// validateEncryptedData checks that encryptedData is only sent for Opaque claims, does
+// not repeat keys of data and holds sealed values. Whether a value opens is only known
+// to the controller.
+func validateEncryptedData(encryptedData, data *map[string]string, opaque bool) error {
+	if encryptedData == nil || len(*encryptedData) == 0 {
+		return nil
+	}
+	if !opaque {
+		return fmt.Errorf("encryptedData is only allowed for Opaque claims")
+	}
+	for _, key := range slices.Sorted(maps.Keys(*encryptedData)) {
+		if data != nil {
+			if _, ok := (*data)[key]; ok {
+				return fmt.Errorf("key %s is set in both data and encryptedData", key)
+			}
+		}
+		if err := sealing.Check((*encryptedData)[key]); err != nil {
+			return fmt.Errorf("encryptedData key %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// opaqueKeys returns data with the keys of encryptedData added, for the checks that
+// only look at the keys of an Opaque claim.
+func opaqueKeys(data, encryptedData *map[string]string) *map[string]string {
+	if encryptedData == nil || len(*encryptedData) == 0 {
+		return data
+	}
+	keys := maps.Clone(*encryptedData)
+	if data != nil {
+		maps.Copy(keys, *data)
+	}
+	return &keys
+}
+
+// validateSecretType checks that the requested Secret type can be built from the keys
+// passed in the same request.
+func validateSecretType(secretType *api.SecretType, docker *api.DockerConfig, claimType api.CreateSecretRequestType, data *map[string]string, generationConfig *api.GenerationConfig, source *api.SourceConfig, tmpls *map[string]string) error {
+	typeName := ""
+	if secretType != nil {
+		typeName = string(*secretType)
+	}
+	if claimType == api.CreateSecretRequestTypeAutoGenerated && !secrettypes.Generatable(typeName, keyFormats(generationConfig)) {
+		return fmt.Errorf("secret type %s cannot be generated with the configured key formats, use an Opaque claim", typeName)
+	}
+
+	var keys []string
+	if claimType == api.CreateSecretRequestTypeCertificate {
+		if typeName != "" && typeName != string(api.SecretTypeKubernetesIoTls) {
+			return fmt.Errorf("certificate claims always create %s secrets", api.SecretTypeKubernetesIoTls)
+		}
+		typeName = string(api.SecretTypeKubernetesIoTls)
+		keys = append(keys, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	if claimType == api.CreateSecretRequestTypeExternal && len(sourceKeys(source)) == 0 && secrettypes.SecretType(typeName) != corev1.SecretTypeOpaque {
+		return fmt.Errorf("source keys must list the Secret keys of %s secrets", typeName)
+	}
+	keys = append(keys, sourceKeys(source)...)
+	if data != nil {
+		keys = append(keys, slices.Collect(maps.Keys(*data))...)
+	}
+	keys = append(keys, generatedKeys(generationConfig)...)
+	if tmpls != nil {
+		keys = append(keys, slices.Collect(maps.Keys(*tmpls))...)
+	}
+
+	return secrettypes.Validate(typeName, keys, toSecretTypesDockerConfig(docker))
+}
+
+// keyFormats maps the data keys of generationConfig to their requested format.
+func keyFormats(generationConfig *api.GenerationConfig) map[string]string {
+	if generationConfig == nil || generationConfig.Keys == nil {
+		return nil
+	}
+	formats := make(map[string]string, len(*generationConfig.Keys))
+	for key, cfg := range *generationConfig.Keys {
+		if cfg.Format != nil {
+			formats[key] = string(*cfg.Format)
+		}
+	}
+	return formats
+}
+
+// generatedKeys returns the data keys of generationConfig and the public keys
+// written next to its key pairs.
+func generatedKeys(generationConfig *api.GenerationConfig) []string {
+	if generationConfig == nil || generationConfig.DataKeys == nil {
+		return nil
+	}
+	keys := slices.Clone(*generationConfig.DataKeys)
+	for key, format := range keyFormats(generationConfig) {
+		if keygen.IsKeyPair(format) {
+			keys = append(keys, keygen.PublicKey(key))
+		}
+	}
+	return keys
+}
+
+// validateKeyGenerations checks the per-key formats and password policies of generationConfig.
+func validateKeyGenerations(generationConfig *api.GenerationConfig) error {
+	if generationConfig == nil || generationConfig.Keys == nil {
+		return nil
+	}
+	for key, cfg := range *generationConfig.Keys {
+		format, bits := "", 0
+		if cfg.Format != nil {
+			format = string(*cfg.Format)
+		}
+		if cfg.Bits != nil {
+			bits = *cfg.Bits
+		}
+		if err := keygen.Validate(format, bits); err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+
+		policy := keygen.PasswordPolicy{}
+		if cfg.Length != nil {
+			if *cfg.Length < 8 {
+				return fmt.Errorf("key %s: secrets should be at least 8 symbols", key)
+			}
+			policy.Length = *cfg.Length
+		}
+		if cfg.Charset != nil {
+			policy.Charset = *cfg.Charset
+		}
+		if cfg.MinDigits != nil {
+			policy.MinDigits = *cfg.MinDigits
+		}
+		if cfg.MinSymbols != nil {
+			policy.MinSymbols = *cfg.MinSymbols
+		}
+		if cfg.MinUppercase != nil {
+			policy.MinUppercase = *cfg.MinUppercase
+		}
+		if cfg.ExcludeAmbiguous != nil {
+			policy.ExcludeAmbiguous = *cfg.ExcludeAmbiguous
+		}
+		if cfg.Prefix != nil {
+			policy.Prefix = *cfg.Prefix
+		}
+
+		if format != "" && format != keygen.FormatPassword {
+			if policy.HasRules() {
+				return fmt.Errorf("key %s: password policy is only supported for the password format", key)
+			}
+			continue
+		}
+		if policy.Length == 0 {
+			policy.Length = int(generationConfig.Length)
+		}
+		if policy.Charset == "" && generationConfig.Encoding != nil {
+			policy.Charset = keygen.Charset(string(*generationConfig.Encoding))
+		}
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// toAPIKeyGeneration converts the CRD per-key generation settings for responses.
+func toAPIKeyGeneration(cfg secretsv1alpha1.KeyGeneration) api.KeyGeneration {
+	var gen api.KeyGeneration
+	if cfg.Format != "" {
+		format := api.KeyGenerationFormat(cfg.Format)
+		gen.Format = &format
+	}
+	if cfg.Bits != 0 {
+		gen.Bits = IntPnc(cfg.Bits)
+	}
+	if cfg.Length != 0 {
+		gen.Length = IntPnc(cfg.Length)
+	}
+	if cfg.Charset != "" {
+		gen.Charset = StrPnc(cfg.Charset)
+	}
+	if cfg.MinDigits != 0 {
+		gen.MinDigits = IntPnc(cfg.MinDigits)
+	}
+	if cfg.MinSymbols != 0 {
+		gen.MinSymbols = IntPnc(cfg.MinSymbols)
+	}
+	if cfg.MinUppercase != 0 {
+		gen.MinUppercase = IntPnc(cfg.MinUppercase)
+	}
+	if cfg.ExcludeAmbiguous {
+		gen.ExcludeAmbiguous = BoolPnc(true)
+	}
+	if cfg.Prefix != "" {
+		gen.Prefix = StrPnc(cfg.Prefix)
+	}
+	return gen
+}
+
+// validSecretTypeUpdate checks the secret type fields of a partial update. The full
+// key set is only known after merging with the stored claim, so the controller and
+// the admission webhook validate the rest.
+func validSecretTypeUpdate(secretType *api.SecretType, docker *api.DockerConfig) bool {
+	if secretType != nil && !slices.Contains(secrettypes.Supported, string(*secretType)) {
+		return false
+	}
+	return docker == nil || (docker.Registry != "" && docker.Username != "")
+}
+
+func toSecretTypesDockerConfig(docker *api.DockerConfig) *secrettypes.DockerConfig {
+	if docker == nil {
+		return nil
+	}
+	return &secrettypes.DockerConfig{Registry: docker.Registry, Username: docker.Username}
+}
+
+// validateCertificateConfig checks the certificate settings of a Certificate claim.
+func validateCertificateConfig(certificate *api.CertificateConfig) error {
+	req := certs.Request{Duration: certs.DefaultDuration}
+	if certificate.CommonName != nil {
+		req.CommonName = *certificate.CommonName
+	}
+	if certificate.DnsNames != nil {
+		req.DNSNames = *certificate.DnsNames
+	}
+	if certificate.IpAddresses != nil {
+		req.IPAddresses = *certificate.IpAddresses
+	}
+	if certificate.KeyAlgorithm != nil {
+		req.KeyAlgorithm = string(*certificate.KeyAlgorithm)
+	}
+	if certificate.KeySize != nil {
+		req.KeySize = *certificate.KeySize
+	}
+	if certificate.Duration != nil && *certificate.Duration != "" {
+		duration, err := time.ParseDuration(*certificate.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid certificate duration: %w", err)
+		}
+		req.Duration = duration
+	}
+	if err := certs.ValidateRequest(req); err != nil {
+		return err
+	}
+
+	if certificate.RenewBefore != nil && *certificate.RenewBefore != "" {
+		renewBefore, err := time.ParseDuration(*certificate.RenewBefore)
+		if err != nil {
+			return fmt.Errorf("invalid certificate renewBefore: %w", err)
+		}
+		if renewBefore <= 0 || renewBefore >= req.Duration {
+			return fmt.Errorf("certificate renewBefore must be positive and shorter than its duration")
+		}
+	}
+	return nil
+}
+
+// validateSourceConfig checks the source settings of an External claim.
+func validateSourceConfig(source *api.SourceConfig) error {
+	switch source.Provider {
+	case api.SourceConfigProviderFile, api.SourceConfigProviderHttp, api.SourceConfigProviderVault:
+	default:
+		return fmt.Errorf("source provider must be one of file, http, vault")
+	}
+	if source.Path == "" {
+		return fmt.Errorf("source path is required")
+	}
+	if source.Provider == api.SourceConfigProviderVault && (source.Address == nil || *source.Address == "") {
+		return fmt.Errorf("vault source requires an address")
+	}
+	if source.RefreshInterval != nil && *source.RefreshInterval != "" {
+		interval, err := time.ParseDuration(*source.RefreshInterval)
+		if err != nil {
+			return fmt.Errorf("invalid source refreshInterval: %w", err)
+		}
+		if interval < time.Minute {
+			return fmt.Errorf("source refreshInterval must be at least 1m")
+		}
+	}
+	return nil
+}
+
+// validatePushConfig checks the settings of the store the claim pushes to.
+func validatePushConfig(push *api.PushConfig) error {
+	switch push.Provider {
+	case api.PushConfigProviderHttp, api.PushConfigProviderVault:
+	default:
+		return fmt.Errorf("push provider must be one of http, vault")
+	}
+	if push.Path == "" {
+		return fmt.Errorf("push path is required")
+	}
+	if push.Provider == api.PushConfigProviderVault && (push.Address == nil || *push.Address == "") {
+		return fmt.Errorf("vault push requires an address")
+	}
+	if push.ConflictPolicy != nil && *push.ConflictPolicy != api.Fail && *push.ConflictPolicy != api.Overwrite {
+		return fmt.Errorf("push conflictPolicy must be one of Fail, Overwrite")
+	}
+	return nil
+}
+
+// sourceKeys returns the Secret keys mapped by the source of an External claim.
+func sourceKeys(source *api.SourceConfig) []string {
+	if source == nil || source.Keys == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(*source.Keys))
+}
+
+// validDeletionPolicy reports whether policy is one of the supported deletion policies.
+func validDeletionPolicy(policy *api.DeletionPolicy) bool {
+	switch *policy {
+	case api.Delete, api.Retain, api.Orphan:
+		return true
+	}
+	return false
+}
+
+// validateTargets checks the target namespace names and selector labels.
+func validateTargets(targets *api.TargetsConfig) error {
+	if targets.Namespaces != nil {
+		for _, ns := range *targets.Namespaces {
+			if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+				return fmt.Errorf("invalid target namespace %q: %s", ns, errs[0])
+			}
+		}
+	}
+	if targets.NamespaceSelector != nil {
+		if _, err := labels.ValidatedSelectorFromSet(*targets.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid target namespaceSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateRotationConfig(rotation *api.RotationConfig) error {
+	if rotation.Interval != nil && *rotation.Interval != "" {
+		interval, err := time.ParseDuration(*rotation.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid rotation interval: %w", err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("rotation interval must be positive")
+		}
+	}
+	if rotation.Schedule != nil && *rotation.Schedule != "" {
+		if _, err := cron.ParseStandard(*rotation.Schedule); err != nil {
+			return fmt.Errorf("invalid rotation schedule: %w", err)
+		}
+	}
+	return nil
+}
+
+// claimCurrentStatus derives Pending/Ready/Error from the Ready condition,
+// falling back to Synced and ErrorMessage for claims without conditions.
+func claimCurrentStatus(claim *secretsv1alpha1.SecretClaim) string {
+	ready := meta.FindStatusCondition(claim.Status.Conditions, secretsv1alpha1.ConditionReady)
+	if ready == nil {
+		if claim.Status.ErrorMessage != "" {
+			return "Error"
+		} else if claim.Status.Synced {
+			return "Ready"
+		}
+		return "Pending"
+	}
+
+	switch {
+	case ready.Status == metav1.ConditionFalse:
+		return "Error"
+	case ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == claim.Generation:
+		return "Ready"
+	default:
+		return "Pending"
+	}
+}
+
+func mapConditions(conditions []metav1.Condition) []api.Condition {
+	result := make([]api.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		condition := api.Condition{
+			Type:               c.Type,
+			Status:             string(c.Status),
+			Reason:             StrPnc(c.Reason),
+			LastTransitionTime: &c.LastTransitionTime.Time,
+		}
+		if c.Message != "" {
+			condition.Message = StrPnc(c.Message)
+		}
+		result = append(result, condition)
+	}
+	return result
+}
+
+func mapTargetStatuses(targets []secretsv1alpha1.TargetStatus) []api.TargetStatus {
+	result := make([]api.TargetStatus, 0, len(targets))
+	for _, t := range targets {
+		target := api.TargetStatus{
+			Namespace: t.Namespace,
+			Synced:    t.Synced,
+		}
+		if t.Message != "" {
+			target.Message = StrPnc(t.Message)
+		}
+		if t.LastUpdate != nil {
+			target.LastUpdate = &t.LastUpdate.Time
+		}
+		result = append(result, target)
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ReloadableHandler serves requests with the handler most recently passed to
+// Swap. A request is served entirely by the handler it started with, so that
+// requests in flight during a config reload see either the old config or the
+// new one, never a mix of both.
+type ReloadableHandler struct {
+	current atomic.Pointer[http.Handler]
+}
+
+func NewReloadableHandler(handler http.Handler) *ReloadableHandler {
+	r := &ReloadableHandler{}
+	r.Swap(handler)
+	return r
+}
+
+// Swap makes handler serve the requests that start from now on.
+func (r *ReloadableHandler) Swap(handler http.Handler) {
+	r.current.Store(&handler)
+}
+
+func (r *ReloadableHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	(*r.current.Load()).ServeHTTP(w, req)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/audit"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+	"go.opentelemetry.io/otel/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RevealSecret returns the plaintext values of the Secret of a SecretClaim, which
+// GetSecret masks. It needs the reveal verb, and every call, allowed or not, is
+// audited.
+func (h *SecretHandler) RevealSecret(ctx context.Context, request api.RevealSecretRequestObject) (api.RevealSecretResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.RevealSecret")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildRevealSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event := audit.Event{
+		Action:    audit.ActionRevealSecret,
+		Actor:     claims.Username,
+		Role:      claims.Role,
+		Namespace: request.Params.Namespace,
+		Name:      request.Name,
+	}
+
+	denied := func() (api.RevealSecretResponseObject, error) {
+		event.Reason = "reveal is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for revealing secret",
+			slog.String("namespace", request.Params.Namespace),
+			slog.String("role", claims.Role))
+		return BuildRevealSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: revealing secret values requires the reveal verb or the " + auth.PermissionRevealSecrets + " permission",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbReveal, policy.ResourceSecrets, request.Params.Namespace) {
+		return denied()
+	}
+
+	claim, err := h.K8sManager.GetSecretClaim(ctx, request.Name, request.Params.Namespace)
+	if err != nil {
+		return BuildRevealSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:      policy.VerbReveal,
+		Resource:  policy.ResourceSecrets,
+		Namespace: request.Params.Namespace,
+		Labels:    claim.Labels,
+	}) {
+		return denied()
+	}
+	secret, err := h.K8sManager.GetActualSecret(ctx, request.Name, request.Params.Namespace)
+	if err != nil {
+		return BuildRevealSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	// A Secret of the same name the claim does not manage is not revealed.
+	if !metav1.IsControlledBy(secret, claim) {
+		span.SetStatus(codes.Error, "Secret not managed by the claim")
+		logger.Warn("Secret is not managed by the SecretClaim; not revealing it", slog.String("namespace", request.Params.Namespace), slog.String("name", request.Name))
+		return BuildRevealSecretErrorResponse(ErrorResult{
+			ErrorMessage: "SecretClaim has no secret data available",
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+
+	data := secretData(secret.Data)
+	event.Keys = slices.Sorted(maps.Keys(data))
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+
+	logger.Info("Revealed secret values", slog.String("namespace", request.Params.Namespace), slog.String("name", request.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.RevealSecret200JSONResponse{
+		Name:      request.Name,
+		Namespace: StrPnc(request.Params.Namespace),
+		Data:      data,
+	}, nil
+}
+
+// RevealClusterSecret returns the plaintext values of the source Secret of a
+// ClusterSecretClaim. It needs the reveal verb on clustersecrets.
+func (h *SecretHandler) RevealClusterSecret(ctx context.Context, request api.RevealClusterSecretRequestObject) (api.RevealClusterSecretResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.RevealClusterSecret")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildRevealClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event := audit.Event{
+		Action: audit.ActionRevealSecret,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   request.Name,
+	}
+
+	denied := func() (api.RevealClusterSecretResponseObject, error) {
+		event.Reason = "reveal is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for revealing cluster secret", slog.String("role", claims.Role))
+		return BuildRevealClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: revealing cluster secret values requires the reveal verb or the " + auth.PermissionRevealSecrets + " permission",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbReveal, policy.ResourceClusterSecrets, "") {
+		return denied()
+	}
+
+	cluster, err := h.K8sManager.GetClusterSecretClaim(ctx, request.Name)
+	if err != nil {
+		return BuildRevealClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:     policy.VerbReveal,
+		Resource: policy.ResourceClusterSecrets,
+		Labels:   cluster.Labels,
+	}) {
+		return denied()
+	}
+	if cluster.Status.SourceNamespace == "" {
+		span.SetStatus(codes.Error, "No source Secret")
+		logger.Warn("ClusterSecretClaim has no source Secret yet", slog.String("name", request.Name))
+		return BuildRevealClusterSecretErrorResponse(ErrorResult{
+			ErrorMessage: "ClusterSecretClaim is not synced yet; no actual secret data available",
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+	secret, err := h.K8sManager.GetActualSecret(ctx, request.Name, cluster.Status.SourceNamespace)
+	if err != nil {
+		return BuildRevealClusterSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+
+	data := secretData(secret.Data)
+	event.Keys = slices.Sorted(maps.Keys(data))
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+
+	logger.Info("Revealed cluster secret values", slog.String("name", request.Name))
+	span.SetStatus(codes.Ok, "Success")
+	return api.RevealClusterSecret200JSONResponse{
+		Name: request.Name,
+		Data: data,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"go.opentelemetry.io/otel/codes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// GetSealingKey returns the public key clients seal encryptedData values to. The
+// key is public, so any authenticated user may read it.
+func (h *SecretHandler) GetSealingKey(ctx context.Context, request api.GetSealingKeyRequestObject) (api.GetSealingKeyResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.GetSealingKey")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	namespace := h.cfg.Sealing.Namespace
+	if namespace == "" {
+		span.SetStatus(codes.Error, "Sealing is not configured")
+		logger.Warn("Sealing key requested but no sealing namespace is configured")
+		return BuildGetSealingKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Sealing is not configured",
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+
+	cm, err := h.K8sManager.GetSealingPublicKey(ctx, namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return BuildGetSealingKeyErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+
+	if err != nil || cm.Data[secretsv1alpha1.SealingPublicKeyKey] == "" || cm.Data[secretsv1alpha1.SealingKeyIDKey] == "" {
+		span.SetStatus(codes.Error, "Sealing key not published")
+		logger.Warn("Sealing public key is not published yet", slog.String("namespace", namespace))
+		return BuildGetSealingKeyErrorResponse(ErrorResult{
+			ErrorMessage: "Sealing public key is not published yet",
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+
+	span.SetStatus(codes.Ok, "Success")
+	return api.GetSealingKey200JSONResponse{
+		KeyId:     cm.Data[secretsv1alpha1.SealingKeyIDKey],
+		PublicKey: cm.Data[secretsv1alpha1.SealingPublicKeyKey],
+	}, nil
+}
@@ -3,10 +3,13 @@ package handlers
 import (
 	"context"
 	"log/slog"
+	"slices"
 
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
 	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
 	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
 	"go.opentelemetry.io/otel/codes"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -28,7 +31,12 @@ func (h *SecretHandler) CreateSecret(ctx context.Context, request api.CreateSecr
 		}), nil
 	}
 
-	if !auth.IsNamespaceAllowed(request.Body.Namespace, claims.AllowedNamespaces) || claims.Role == "developer" {
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:      policy.VerbCreate,
+		Resource:  policy.ResourceSecrets,
+		Namespace: request.Body.Namespace,
+		Labels:    derefMap(request.Body.Labels),
+	}) {
 		span.SetStatus(codes.Error, "Access denied")
 		logger.Warn("Access denied for namespace",
 			slog.String("namespace", request.Body.Namespace),
@@ -41,21 +49,29 @@ func (h *SecretHandler) CreateSecret(ctx context.Context, request api.CreateSecr
 		}), nil
 	}
 
-	isValidType := request.Body.Type == api.CreateSecretRequestTypeOpaque || request.Body.Type == api.CreateSecretRequestTypeAutoGenerated
-	isAutoGenerated := request.Body.Type == api.CreateSecretRequestTypeAutoGenerated
-	configProvided := request.Body.GenerationConfig != nil
+	if request.Body.Targets != nil && !h.targetsAllowed(ctx, claims, policy.VerbCreate, request.Body.Targets) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for target namespaces",
+			slog.Any("targets", request.Body.Targets),
+			slog.String("role", claims.Role))
+		return BuildCreateSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions for targets",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
 
-	if isAutoGenerated && !configProvided || (!isAutoGenerated && configProvided) || !isValidType {
+	if err := validateCreateSecretBody(request.Body); err != nil {
 		span.SetStatus(codes.Error, "Wrong request format")
-		logger.Warn("Wrong request format", slog.Any("request_type", string(request.Body.Type)), slog.Any("data_provided", request.Body.Data), slog.Any("config_provided", configProvided))
+		logger.Warn("Wrong request format", slog.String("request_type", string(request.Body.Type)), slog.Any("error", err))
 		return BuildCreateSecretErrorResponse(ErrorResult{
-			ErrorMessage: "Wrong request format",
+			ErrorMessage: err.Error(),
 			ErrorCode:    "BadRequest",
 			StatusCode:   400,
 		}), nil
 	}
 
-	err = h.K8sManager.CreateSecretClaim(ctx, request.Body.Name, request.Body.Namespace, string(request.Body.Type), request.Body.Data, request.Body.GenerationConfig, request.Body.Labels, request.Body.Annotations)
+	err = h.K8sManager.CreateSecretClaim(ctx, request.Body.Name, request.Body.Namespace, string(request.Body.Type), request.Body.Data, request.Body.EncryptedData, request.Body.GenerationConfig, request.Body.Rotation, request.Body.Templates, request.Body.DeletionPolicy, request.Body.SecretType, request.Body.DockerConfig, request.Body.Certificate, request.Body.Source, request.Body.Targets, request.Body.Push, request.Body.Labels, request.Body.Annotations)
 	if err != nil {
 		return BuildCreateSecretErrorResponse(HandleK8sError(ctx, err)), nil
 	}
@@ -86,10 +102,11 @@ func (h *SecretHandler) ListSecrets(ctx context.Context, request api.ListSecrets
 			StatusCode:   500,
 		}), nil
 	}
-	if !auth.IsNamespaceAllowed(request.Params.Namespace, claims.AllowedNamespaces) {
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbList, policy.ResourceSecrets, request.Params.Namespace) {
 		span.SetStatus(codes.Error, "Access denied")
 		logger.Warn("Access denied for namespace",
-			slog.String("namespace", request.Params.Namespace))
+			slog.String("namespace", request.Params.Namespace),
+			slog.String("role", claims.Role))
 
 		return BuildListSecretsErrorResponse(ErrorResult{
 			ErrorMessage: "Access denied: insufficient role or namespace permissions",
@@ -103,9 +120,18 @@ func (h *SecretHandler) ListSecrets(ctx context.Context, request api.ListSecrets
 		return BuildListSecretsErrorResponse(HandleK8sError(ctx, err)), nil
 	}
 
-	secretSummaryItems := mapSecretListToResponseList(secretClaimList.Items)
-
-	logger.Info("Successfully fetched secret claims list", slog.String("namespace", request.Params.Namespace), slog.Int("count", len(secretClaimList.Items)))
+	// Claims outside the label selectors of the caller's rules are left out.
+	visible := slices.DeleteFunc(secretClaimList.Items, func(claim secretsv1alpha1.SecretClaim) bool {
+		return !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+			Verb:      policy.VerbList,
+			Resource:  policy.ResourceSecrets,
+			Namespace: request.Params.Namespace,
+			Labels:    claim.Labels,
+		})
+	})
+	secretSummaryItems := mapSecretListToResponseList(visible)
+
+	logger.Info("Successfully fetched secret claims list", slog.String("namespace", request.Params.Namespace), slog.Int("count", len(visible)))
 	span.SetStatus(codes.Ok, "Success")
 	return api.ListSecrets200JSONResponse{
 		Items: secretSummaryItems,
@@ -129,10 +155,11 @@ func (h *SecretHandler) GetSecret(ctx context.Context, request api.GetSecretRequ
 		}), nil
 	}
 
-	if !auth.IsNamespaceAllowed(request.Params.Namespace, claims.AllowedNamespaces) {
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbGet, policy.ResourceSecrets, request.Params.Namespace) {
 		span.SetStatus(codes.Error, "Access denied")
 		logger.Warn("Access denied for namespace",
-			slog.String("namespace", request.Params.Namespace))
+			slog.String("namespace", request.Params.Namespace),
+			slog.String("role", claims.Role))
 
 		return BuildGetSecretErrorResponse(ErrorResult{
 			ErrorMessage: "Access denied: insufficient role or namespace permissions",
@@ -147,6 +174,25 @@ func (h *SecretHandler) GetSecret(ctx context.Context, request api.GetSecretRequ
 		return BuildGetSecretErrorResponse(HandleK8sError(ctx, err)), nil
 	}
 
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:      policy.VerbGet,
+		Resource:  policy.ResourceSecrets,
+		Namespace: request.Params.Namespace,
+		Labels:    secret.Labels,
+	}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for secret claim labels",
+			slog.String("namespace", request.Params.Namespace),
+			slog.String("name", request.Name),
+			slog.String("role", claims.Role))
+
+		return BuildGetSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
 	var actualData *corev1.Secret = nil
 	if secret.Status.Synced {
 
@@ -189,7 +235,8 @@ func (h *SecretHandler) UpdateSecret(ctx context.Context, request api.UpdateSecr
 		}), nil
 	}
 
-	if !auth.IsNamespaceAllowed(request.Params.Namespace, claims.AllowedNamespaces) || claims.Role == "developer" {
+	verb := updateVerb(request.Body)
+	if !h.Authorizer.AllowsNamespace(ctx, claims, verb, policy.ResourceSecrets, request.Params.Namespace) {
 		span.SetStatus(codes.Error, "Access denied")
 		logger.Warn("Access denied for namespace",
 			slog.String("namespace", request.Params.Namespace),
@@ -201,33 +248,58 @@ func (h *SecretHandler) UpdateSecret(ctx context.Context, request api.UpdateSecr
 		}), nil
 	}
 
-	dataProvided := request.Body.Data != nil && len(*request.Body.Data) > 0
-	configProvided := request.Body.GenerationConfig != nil
-	typeProvided := request.Body.Type != nil
-	newType := ""
-	if typeProvided {
-		newType = string(*request.Body.Type)
+	if request.Body.Targets != nil && !h.targetsAllowed(ctx, claims, verb, request.Body.Targets) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for target namespaces",
+			slog.Any("targets", request.Body.Targets),
+			slog.String("role", claims.Role))
+		return BuildUpdateSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions for targets",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
 	}
 
-	if typeProvided {
-		isAutoGenerated := newType == string(api.UpdateSecretRequestTypeAutoGenerated)
-		if (isAutoGenerated && dataProvided) || (!isAutoGenerated && configProvided) {
-			return BuildUpdateSecretErrorResponse(ErrorResult{
-				ErrorMessage: "Wrong request format: AutoGenerated requires GenerationConfig, Opaque requires Data",
-				ErrorCode:    "BadRequest",
-				StatusCode:   400,
-			}), nil
-		}
+	if err := validateUpdateSecretBody(request.Body); err != nil {
+		span.SetStatus(codes.Error, "Wrong request format")
+		logger.Warn("Wrong request format", slog.Any("error", err))
+		return BuildUpdateSecretErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
 	}
 
+	existing, err := h.K8sManager.GetSecretClaim(ctx, request.Name, request.Params.Namespace)
+	if err != nil {
+		return BuildUpdateSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	// New labels must stay within the caller's label selectors as well.
+	if !h.claimLabelsAllowed(ctx, claims, verb, request.Params.Namespace, existing.Labels, request.Body.Labels) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for secret claim labels",
+			slog.String("namespace", request.Params.Namespace),
+			slog.String("name", request.Name),
+			slog.String("role", claims.Role))
+		return BuildUpdateSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	newType := ""
+	if request.Body.Type != nil {
+		newType = string(*request.Body.Type)
+	}
 	regenerate := false
 	if request.Body.Regenerate != nil {
 		regenerate = *request.Body.Regenerate
 	}
 
 	err = h.K8sManager.UpdateSecretClaim(ctx, request.Name, request.Params.Namespace,
-		newType, regenerate, request.Body.Data, request.Body.GenerationConfig,
-		request.Body.Labels, request.Body.Annotations)
+		newType, regenerate, request.Body.RegenerateKeys, request.Body.Data, request.Body.EncryptedData, request.Body.GenerationConfig, request.Body.Rotation,
+		request.Body.Templates, request.Body.DeletionPolicy, request.Body.SecretType, request.Body.DockerConfig, request.Body.Certificate, request.Body.Source, request.Body.Targets, request.Body.Push, request.Body.Labels, request.Body.Annotations)
 	if err != nil {
 		return BuildUpdateSecretErrorResponse(HandleK8sError(ctx, err)), nil
 	}
@@ -259,7 +331,7 @@ func (h *SecretHandler) DeleteSecret(ctx context.Context, request api.DeleteSecr
 		}), nil
 	}
 
-	if !auth.IsNamespaceAllowed(request.Params.Namespace, claims.AllowedNamespaces) || claims.Role == "developer" {
+	if !h.Authorizer.AllowsNamespace(ctx, claims, policy.VerbDelete, policy.ResourceSecrets, request.Params.Namespace) {
 		span.SetStatus(codes.Error, "Access denied")
 		logger.Warn("Access denied for namespace",
 			slog.String("namespace", request.Params.Namespace),
@@ -272,6 +344,29 @@ func (h *SecretHandler) DeleteSecret(ctx context.Context, request api.DeleteSecr
 		}), nil
 	}
 
+	existing, err := h.K8sManager.GetSecretClaim(ctx, request.Name, request.Params.Namespace)
+	if err != nil {
+		return BuildDeleteSecretErrorResponse(HandleK8sError(ctx, err)), nil
+	}
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{
+		Verb:      policy.VerbDelete,
+		Resource:  policy.ResourceSecrets,
+		Namespace: request.Params.Namespace,
+		Labels:    existing.Labels,
+	}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for secret claim labels",
+			slog.String("namespace", request.Params.Namespace),
+			slog.String("name", request.Name),
+			slog.String("role", claims.Role))
+
+		return BuildDeleteSecretErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role or namespace permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
 	err = h.K8sManager.DeleteSecretClaim(ctx, request.Name, request.Params.Namespace)
 	if err != nil {
 		return BuildDeleteSecretErrorResponse(HandleK8sError(ctx, err)), nil
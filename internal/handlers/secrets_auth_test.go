@@ -1,17 +1,38 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
 	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/audit"
 	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
 	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
 	"github.com/mogilyoy/k8s-secret-manager/internal/k8s"
+	authMiddleware "github.com/mogilyoy/k8s-secret-manager/internal/middleware"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sealing"
+	"github.com/mogilyoy/k8s-secret-manager/internal/secrettypes"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func newTestSecretHandler(t *testing.T) *SecretHandler {
@@ -39,10 +61,15 @@ func newTestSecretHandler(t *testing.T) *SecretHandler {
 		Tracer: otel.Tracer("test"),
 	}
 
+	authorizer, err := policy.NewRoleAuthorizer(nil)
+	require.NoError(t, err)
+
 	return &SecretHandler{
 		K8sManager: k8sClient,
 		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
 		Tracer:     otel.Tracer("test"),
+		Authorizer: authorizer,
+		Users:      auth.NewConfigUserStore(nil, auth.NewMemoryUserStore()),
 	}
 }
 
@@ -140,6 +167,637 @@ func TestSecretHandler_CreateSecret_WrongFormat(t *testing.T) {
 	}
 }
 
+func TestSecretHandler_CreateSecret_RotationForOpaque(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	interval := "2160h"
+	req := api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "secret1",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeOpaque,
+			Data:      &map[string]string{"foo": "bar"},
+			Rotation:  &api.RotationConfig{Interval: &interval},
+		},
+	}
+
+	resp, err := handler.CreateSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	respErr := resp.(api.CreateSecret400JSONResponse)
+	if *respErr.ErrorCode != "BadRequest" {
+		t.Errorf("expected BadRequest error, got %s", *respErr.ErrorCode)
+	}
+}
+
+func TestSecretHandler_CreateSecret_InvalidRotationSchedule(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	encoding := api.Alphanumeric
+	keys := []string{"password"}
+	schedule := "every day"
+	req := api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "secret1",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeAutoGenerated,
+			GenerationConfig: &api.GenerationConfig{
+				Length:   16,
+				Encoding: &encoding,
+				DataKeys: &keys,
+			},
+			Rotation: &api.RotationConfig{Schedule: &schedule},
+		},
+	}
+
+	resp, err := handler.CreateSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+}
+
+func TestSecretHandler_CreateSecret_InvalidTemplate(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	encoding := api.Alphanumeric
+	keys := []string{"password"}
+	tmpls := map[string]string{"dsn": "postgres://app:{{ .password @db"}
+	req := api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "secret1",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeAutoGenerated,
+			GenerationConfig: &api.GenerationConfig{
+				Length:   16,
+				Encoding: &encoding,
+				DataKeys: &keys,
+			},
+			Templates: &tmpls,
+		},
+	}
+
+	resp, err := handler.CreateSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+}
+
+func TestSecretHandler_CreateSecret_DeletionPolicy(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	data := map[string]string{"foo": "bar"}
+	invalid := api.DeletionPolicy("Keep")
+	req := api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:           "secret1",
+			Namespace:      "default",
+			Type:           api.CreateSecretRequestTypeOpaque,
+			Data:           &data,
+			DeletionPolicy: &invalid,
+		},
+	}
+
+	resp, err := handler.CreateSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+
+	retain := api.Retain
+	req.Body.DeletionPolicy = &retain
+	resp, err = handler.CreateSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "secret1", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	if claim.Spec.DeletionPolicy != "Retain" {
+		t.Errorf("DeletionPolicy = %q, want Retain", claim.Spec.DeletionPolicy)
+	}
+}
+
+func TestSecretHandler_CreateSecret_SecretType(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	data := map[string]string{"tls.crt": "cert"}
+	tlsType := api.SecretTypeKubernetesIoTls
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:       "tls",
+			Namespace:  "default",
+			Type:       api.CreateSecretRequestTypeOpaque,
+			Data:       &data,
+			SecretType: &tlsType,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for a TLS secret without tls.key, got %T", resp)
+	}
+
+	encoding := api.Alphanumeric
+	keys := []string{"password"}
+	dockerType := api.SecretTypeKubernetesIoDockerconfigjson
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "pull",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeAutoGenerated,
+			GenerationConfig: &api.GenerationConfig{
+				Length:   16,
+				Encoding: &encoding,
+				DataKeys: &keys,
+			},
+			SecretType:   &dockerType,
+			DockerConfig: &api.DockerConfig{Registry: "registry.example.com", Username: "ci"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "pull", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	if claim.Spec.SecretType != "kubernetes.io/dockerconfigjson" || claim.Spec.DockerConfig == nil || claim.Spec.DockerConfig.Registry != "registry.example.com" {
+		t.Errorf("unexpected spec: %+v", claim.Spec)
+	}
+}
+
+func TestSecretHandler_CreateSecret_Certificate(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "api-tls",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeCertificate,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for a Certificate claim without certificate, got %T", resp)
+	}
+
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "api-tls",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeCertificate,
+			Certificate: &api.CertificateConfig{
+				CommonName:  StrPnc("api"),
+				Duration:    StrPnc("1h"),
+				RenewBefore: StrPnc("2h"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for renewBefore longer than duration, got %T", resp)
+	}
+
+	algorithm := api.RSA
+	dnsNames := []string{"api.default.svc"}
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "api-tls",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeCertificate,
+			Certificate: &api.CertificateConfig{
+				CommonName:   StrPnc("api"),
+				DnsNames:     &dnsNames,
+				KeyAlgorithm: &algorithm,
+				Duration:     StrPnc("720h"),
+				IssuerRef:    StrPnc("internal-ca"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "api-tls", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	cfg := claim.Spec.Certificate
+	if cfg == nil || cfg.KeyAlgorithm != "RSA" || cfg.IssuerRef != "internal-ca" || cfg.Duration == nil || cfg.Duration.Duration.String() != "720h0m0s" {
+		t.Errorf("unexpected certificate spec: %+v", cfg)
+	}
+}
+
+func TestSecretHandler_CreateSecret_External(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "db",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeExternal,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for an External claim without source, got %T", resp)
+	}
+
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "db",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeExternal,
+			Source: &api.SourceConfig{
+				Provider: api.SourceConfigProviderVault,
+				Path:     "apps/db",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for a vault source without address, got %T", resp)
+	}
+
+	keys := map[string]string{"password": "db_password"}
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:      "db",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeExternal,
+			Source: &api.SourceConfig{
+				Provider:        api.SourceConfigProviderVault,
+				Path:            "apps/db",
+				Address:         StrPnc("https://vault.example.com"),
+				Keys:            &keys,
+				RefreshInterval: StrPnc("10m"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "db", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	source := mapClaimToSecretResponse(claim, nil).Source
+	if source == nil || source.Address == nil || *source.Address != "https://vault.example.com" || source.RefreshInterval == nil || *source.RefreshInterval != "10m0s" {
+		t.Errorf("unexpected source in response: %+v", source)
+	}
+}
+
+func TestSecretHandler_CreateSecret_KeyFormats(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	encoding := api.Alphanumeric
+	dataKeys := []string{"ssh-privatekey"}
+	sshFormat := api.SshRsa
+	bits := 1024
+	keys := map[string]api.KeyGeneration{"ssh-privatekey": {Format: &sshFormat, Bits: &bits}}
+	secretType := api.SecretTypeKubernetesIoSshAuth
+	request := api.CreateSecretRequest{
+		Name:      "deploy-key",
+		Namespace: "default",
+		Type:      api.CreateSecretRequestTypeAutoGenerated,
+		GenerationConfig: &api.GenerationConfig{
+			Length:   32,
+			Encoding: &encoding,
+			DataKeys: &dataKeys,
+			Keys:     &keys,
+		},
+		SecretType: &secretType,
+	}
+
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: &request})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for a 1024 bit RSA key, got %T", resp)
+	}
+
+	passwordFormat := api.Password
+	keys["ssh-privatekey"] = api.KeyGeneration{Format: &passwordFormat}
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: &request})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for an ssh-auth secret holding a password, got %T", resp)
+	}
+
+	bits = 4096
+	keys["ssh-privatekey"] = api.KeyGeneration{Format: &sshFormat, Bits: &bits}
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: &request})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "deploy-key", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	if got := claim.Spec.Generation.Keys["ssh-privatekey"]; got.Format != "ssh-rsa" || got.Bits != 4096 {
+		t.Errorf("unexpected key generation: %+v", got)
+	}
+}
+
+func TestSecretHandler_CreateSecret_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	encoding := api.Alphanumeric
+	dataKeys := []string{"db_pass"}
+	keys := map[string]api.KeyGeneration{"db_pass": {MinSymbols: IntPnc(2)}}
+	request := api.CreateSecretRequest{
+		Name:      "db",
+		Namespace: "default",
+		Type:      api.CreateSecretRequestTypeAutoGenerated,
+		GenerationConfig: &api.GenerationConfig{
+			Length:   16,
+			Encoding: &encoding,
+			DataKeys: &dataKeys,
+			Keys:     &keys,
+		},
+	}
+
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: &request})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for symbols missing from the alphanumeric charset, got %T", resp)
+	}
+
+	keys["db_pass"] = api.KeyGeneration{
+		Length:           IntPnc(24),
+		Charset:          StrPnc("abcdefABCDEF23456789-_"),
+		MinDigits:        IntPnc(2),
+		MinSymbols:       IntPnc(2),
+		ExcludeAmbiguous: BoolPnc(true),
+		Prefix:           StrPnc("db_"),
+	}
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: &request})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "db", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	got := claim.Spec.Generation.Keys["db_pass"]
+	if got.Length != 24 || got.Charset != "abcdefABCDEF23456789-_" || got.MinDigits != 2 || got.MinSymbols != 2 || !got.ExcludeAmbiguous || got.Prefix != "db_" {
+		t.Errorf("unexpected password policy: %+v", got)
+	}
+}
+
+func TestSecretHandler_CreateSecret_Targets(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default", "team-a"},
+		Role:              "operator",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	newRequest := func(targets api.TargetsConfig) *api.CreateSecretRequest {
+		return &api.CreateSecretRequest{
+			Name:      "shared",
+			Namespace: "default",
+			Type:      api.CreateSecretRequestTypeOpaque,
+			Data:      &map[string]string{"token": "abc"},
+			Targets:   &targets,
+		}
+	}
+
+	forbidden := []api.TargetsConfig{
+		{Namespaces: &[]string{"team-a", "team-b"}},
+		{NamespaceSelector: &map[string]string{"team": "a"}},
+	}
+	for _, targets := range forbidden {
+		resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: newRequest(targets)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(api.CreateSecret403JSONResponse); !ok {
+			t.Fatalf("expected 403 for targets %+v, got %T", targets, resp)
+		}
+	}
+
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: newRequest(api.TargetsConfig{Namespaces: &[]string{"Team_A"}})})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret403JSONResponse); !ok {
+		t.Fatalf("expected 403 for a namespace outside the allowed list, got %T", resp)
+	}
+
+	claims.Role = "admin"
+	claims.AllowedNamespaces = []string{"*"}
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: newRequest(api.TargetsConfig{Namespaces: &[]string{"Team_A"}})})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400 for an invalid namespace name, got %T", resp)
+	}
+
+	resp, err = handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: newRequest(api.TargetsConfig{
+		Namespaces:        &[]string{"team-a"},
+		NamespaceSelector: &map[string]string{"team": "payments"},
+	})})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "shared", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	got := claim.Spec.Targets
+	if got == nil || !slices.Equal(got.Namespaces, []string{"team-a"}) || got.NamespaceSelector.MatchLabels["team"] != "payments" {
+		t.Errorf("unexpected targets: %+v", got)
+	}
+}
+
+func TestSecretHandler_CreateSecret_Push(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "operator",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	address := "https://vault.example.com"
+	keys := map[string]string{"password": "db_password"}
+	newRequest := func(name string, claimType api.CreateSecretRequestType, push api.PushConfig) *api.CreateSecretRequest {
+		request := &api.CreateSecretRequest{
+			Name:      name,
+			Namespace: "default",
+			Type:      claimType,
+			Push:      &push,
+		}
+		if claimType == api.CreateSecretRequestTypeExternal {
+			request.Source = &api.SourceConfig{Provider: api.SourceConfigProviderVault, Path: "apps/db", Address: &address}
+		} else {
+			request.Data = &map[string]string{"password": "secret"}
+		}
+		return request
+	}
+
+	policy := api.PushConfigConflictPolicy("Sometimes")
+	invalid := []*api.CreateSecretRequest{
+		newRequest("external", api.CreateSecretRequestTypeExternal, api.PushConfig{Provider: api.PushConfigProviderVault, Path: "apps/db", Address: &address}),
+		newRequest("bad-provider", api.CreateSecretRequestTypeOpaque, api.PushConfig{Provider: "aws", Path: "apps/db"}),
+		newRequest("no-path", api.CreateSecretRequestTypeOpaque, api.PushConfig{Provider: api.PushConfigProviderHttp}),
+		newRequest("no-address", api.CreateSecretRequestTypeOpaque, api.PushConfig{Provider: api.PushConfigProviderVault, Path: "apps/db"}),
+		newRequest("bad-policy", api.CreateSecretRequestTypeOpaque, api.PushConfig{Provider: api.PushConfigProviderVault, Path: "apps/db", Address: &address, ConflictPolicy: &policy}),
+	}
+	for _, request := range invalid {
+		resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: request})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+			t.Fatalf("expected 400 for %s, got %T", request.Name, resp)
+		}
+	}
+
+	overwrite := api.Overwrite
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: newRequest("pushed", api.CreateSecretRequestTypeOpaque, api.PushConfig{
+		Provider:       api.PushConfigProviderVault,
+		Path:           "apps/db",
+		Address:        &address,
+		Keys:           &keys,
+		ConflictPolicy: &overwrite,
+	})})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201 response, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "pushed", "default")
+	if err != nil {
+		t.Fatalf("failed to get SecretClaim: %v", err)
+	}
+	got := mapClaimToSecretResponse(claim, nil).Push
+	if got == nil || got.Provider != api.PushConfigProviderVault || got.Path != "apps/db" || *got.Address != address || (*got.Keys)["password"] != "db_password" || *got.ConflictPolicy != api.Overwrite {
+		t.Errorf("unexpected push in response: %+v", got)
+	}
+}
+
 type clientWithError struct {
 	client.Client
 }
@@ -176,10 +834,14 @@ func getHandlerWithErrorClient(t *testing.T) *SecretHandler {
 		Tracer: otel.Tracer("test"),
 	}
 
+	authorizer, err := policy.NewRoleAuthorizer(nil)
+	require.NoError(t, err)
+
 	handler := &SecretHandler{
 		K8sManager: k8sErrClient,
 		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
 		Tracer:     otel.Tracer("test"),
+		Authorizer: authorizer,
 	}
 	return handler
 }
@@ -326,7 +988,54 @@ func TestSecretHandler_ListSecrets_ClaimsError(t *testing.T) {
 	}
 }
 
-func TestSecretHandler_GetSecret_SyncedSuccess(t *testing.T) {
+func TestSecretHandler_GetSecret_SyncedSuccess(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	claim := &secretsv1alpha1.SecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "default"},
+		Spec:       secretsv1alpha1.SecretClaimSpec{Type: "Opaque"},
+		Status:     secretsv1alpha1.SecretClaimStatus{Synced: true},
+	}
+	actual := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "default"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+	if err := handler.K8sManager.(*k8s.K8sDynamicClient).Client.Create(ctx, claim); err != nil {
+		t.Fatalf("failed create secretclaim: %v", err)
+	}
+	if err := handler.K8sManager.(*k8s.K8sDynamicClient).Client.Create(ctx, actual); err != nil {
+		t.Fatalf("failed create actual secret: %v", err)
+	}
+
+	req := api.GetSecretRequestObject{
+		Name: "mysecret",
+		Params: api.GetSecretParams{
+			Namespace: "default",
+		},
+	}
+
+	resp, err := handler.GetSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := resp.(api.GetSecret200JSONResponse)
+	if got.Name != "mysecret" {
+		t.Errorf("expected name mysecret, got %s", got.Name)
+	}
+	if len(*got.Data) == 0 {
+		t.Error("expected non-empty data")
+	}
+}
+
+func TestSecretHandler_GetSecret_Conditions(t *testing.T) {
 	ctx := context.Background()
 	claims := &auth.Claims{
 		AllowedNamespaces: []string{"default"},
@@ -338,19 +1047,32 @@ func TestSecretHandler_GetSecret_SyncedSuccess(t *testing.T) {
 
 	claim := &secretsv1alpha1.SecretClaim{
 		ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "default"},
-		Spec:       secretsv1alpha1.SecretClaimSpec{Type: "Opaque"},
-		Status:     secretsv1alpha1.SecretClaimStatus{Synced: true},
-	}
-	actual := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "default"},
-		Data:       map[string][]byte{"key": []byte("value")},
+		Spec: secretsv1alpha1.SecretClaimSpec{
+			Type:       "AutoGenerated",
+			Generation: &secretsv1alpha1.GenerationConfig{Length: 16, DataKeys: []string{"password"}},
+		},
+		Status: secretsv1alpha1.SecretClaimStatus{
+			Synced: false,
+			Conditions: []metav1.Condition{
+				{
+					Type:               secretsv1alpha1.ConditionReady,
+					Status:             metav1.ConditionFalse,
+					Reason:             "SecretNotOwned",
+					Message:            "secret mysecret already exists and is not managed by this SecretClaim",
+					LastTransitionTime: metav1.Now(),
+				},
+				{
+					Type:               secretsv1alpha1.ConditionOwnershipConflict,
+					Status:             metav1.ConditionTrue,
+					Reason:             "SecretNotOwned",
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
 	}
 	if err := handler.K8sManager.(*k8s.K8sDynamicClient).Client.Create(ctx, claim); err != nil {
 		t.Fatalf("failed create secretclaim: %v", err)
 	}
-	if err := handler.K8sManager.(*k8s.K8sDynamicClient).Client.Create(ctx, actual); err != nil {
-		t.Fatalf("failed create actual secret: %v", err)
-	}
 
 	req := api.GetSecretRequestObject{
 		Name: "mysecret",
@@ -365,11 +1087,18 @@ func TestSecretHandler_GetSecret_SyncedSuccess(t *testing.T) {
 	}
 
 	got := resp.(api.GetSecret200JSONResponse)
-	if got.Name != "mysecret" {
-		t.Errorf("expected name mysecret, got %s", got.Name)
+	if got.Status.CurrentStatus != api.SecretStatusCurrentStatusError {
+		t.Errorf("expected status Error, got %s", got.Status.CurrentStatus)
 	}
-	if len(*got.Data) == 0 {
-		t.Error("expected non-empty data")
+	if got.Status.Conditions == nil || len(*got.Status.Conditions) != 2 {
+		t.Fatalf("expected 2 conditions, got %v", got.Status.Conditions)
+	}
+	ready := (*got.Status.Conditions)[0]
+	if ready.Type != "Ready" || ready.Status != "False" || *ready.Reason != "SecretNotOwned" {
+		t.Errorf("unexpected Ready condition: %+v", ready)
+	}
+	if (*got.Status.Conditions)[1].Message != nil {
+		t.Errorf("expected empty message to be omitted")
 	}
 }
 
@@ -776,6 +1505,302 @@ func TestSecretHandler_DeleteSecret_K8sError(t *testing.T) {
 	}
 }
 
+func TestSecretHandler_CreateClusterSecret_Success(t *testing.T) {
+	ctx := context.Background()
+	claims := &auth.Claims{
+		AllowedNamespaces: []string{"*"},
+		Role:              "admin",
+	}
+	ctx = auth.ContextWithClaims(ctx, claims)
+
+	handler := newTestSecretHandler(t)
+
+	req := api.CreateClusterSecretRequestObject{
+		Body: &api.CreateClusterSecretRequest{
+			Name:    "shared",
+			Type:    api.CreateClusterSecretRequestTypeOpaque,
+			Data:    &map[string]string{"foo": "bar"},
+			Targets: api.TargetsConfig{Namespaces: &[]string{"team-a", "team-b"}},
+		},
+	}
+
+	resp, err := handler.CreateClusterSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ok := *resp.(api.CreateClusterSecret201JSONResponse).Ok
+	if !ok {
+		t.Errorf("expected Ok: true, got false")
+	}
+
+	var claim secretsv1alpha1.ClusterSecretClaim
+	err = handler.K8sManager.(*k8s.K8sDynamicClient).Client.Get(ctx, types.NamespacedName{Name: "shared"}, &claim)
+	if err != nil {
+		t.Fatalf("failed to get cluster secret claim from fake client: %v", err)
+	}
+	if claim.Spec.Targets == nil || len(claim.Spec.Targets.Namespaces) != 2 {
+		t.Errorf("expected 2 target namespaces, got %+v", claim.Spec.Targets)
+	}
+}
+
+func TestSecretHandler_CreateClusterSecret_Forbidden(t *testing.T) {
+	handler := newTestSecretHandler(t)
+
+	for _, role := range []string{"developer", "operator"} {
+		ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+			AllowedNamespaces: []string{"*"},
+			Role:              role,
+		})
+
+		req := api.CreateClusterSecretRequestObject{
+			Body: &api.CreateClusterSecretRequest{
+				Name:    "shared",
+				Type:    api.CreateClusterSecretRequestTypeOpaque,
+				Data:    &map[string]string{"foo": "bar"},
+				Targets: api.TargetsConfig{Namespaces: &[]string{"team-a"}},
+			},
+		}
+
+		resp, err := handler.CreateClusterSecret(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		respErr, ok := resp.(api.CreateClusterSecret403JSONResponse)
+		if !ok {
+			t.Fatalf("role %s: expected 403, got %T", role, resp)
+		}
+		if *respErr.ErrorCode != "Forbidden" {
+			t.Errorf("expected Forbidden error, got %s", *respErr.ErrorCode)
+		}
+	}
+}
+
+func TestSecretHandler_CreateClusterSecret_WrongFormat(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"*"},
+		Role:              "admin",
+	})
+
+	handler := newTestSecretHandler(t)
+
+	req := api.CreateClusterSecretRequestObject{
+		Body: &api.CreateClusterSecretRequest{
+			Name:    "shared",
+			Type:    api.CreateClusterSecretRequestTypeAutoGenerated,
+			Targets: api.TargetsConfig{Namespaces: &[]string{"team-a"}},
+		},
+	}
+
+	resp, err := handler.CreateClusterSecret(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(api.CreateClusterSecret400JSONResponse); !ok {
+		t.Fatalf("expected 400, got %T", resp)
+	}
+}
+
+func TestSecretHandler_GetClusterSecret_SyncedSuccess(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"*"},
+		Role:              "admin",
+	})
+
+	handler := newTestSecretHandler(t)
+	cl := handler.K8sManager.(*k8s.K8sDynamicClient).Client
+
+	cluster := &secretsv1alpha1.ClusterSecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec:       secretsv1alpha1.SecretClaimSpec{Type: "Opaque"},
+		Status: secretsv1alpha1.ClusterSecretClaimStatus{
+			SecretClaimStatus: secretsv1alpha1.SecretClaimStatus{Synced: true},
+			SourceNamespace:   "secret-manager-system",
+		},
+	}
+	actual := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "secret-manager-system"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+	if err := cl.Create(ctx, cluster); err != nil {
+		t.Fatalf("failed create clustersecretclaim: %v", err)
+	}
+	if err := cl.Create(ctx, actual); err != nil {
+		t.Fatalf("failed create actual secret: %v", err)
+	}
+
+	resp, err := handler.GetClusterSecret(ctx, api.GetClusterSecretRequestObject{Name: "shared"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := resp.(api.GetClusterSecret200JSONResponse)
+	if got.Name != "shared" {
+		t.Errorf("expected name shared, got %s", got.Name)
+	}
+	if got.Namespace != nil {
+		t.Errorf("expected no namespace, got %s", *got.Namespace)
+	}
+	if got.Data == nil || (*got.Data)["key"] != maskedValue {
+		t.Errorf("expected masked data from the source secret, got %v", got.Data)
+	}
+
+	list, err := handler.ListClusterSecrets(ctx, api.ListClusterSecretsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items := list.(api.ListClusterSecrets200JSONResponse).Items
+	if len(items) != 1 || items[0].Name != "shared" {
+		t.Errorf("expected one item named shared, got %+v", items)
+	}
+}
+
+func TestSecretHandler_CreateSecret_EncryptedData(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "admin",
+	})
+
+	handler := newTestSecretHandler(t)
+
+	priv, err := sealing.GenerateKey()
+	require.NoError(t, err)
+	sealed, err := sealing.Seal(priv.PublicKey(), sealing.Scope("default", "db"), "password", []byte("s3cret"))
+	require.NoError(t, err)
+
+	resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{
+		Body: &api.CreateSecretRequest{
+			Name:          "db",
+			Namespace:     "default",
+			Type:          api.CreateSecretRequestTypeOpaque,
+			Data:          &map[string]string{"username": "app"},
+			EncryptedData: &map[string]string{"password": sealed},
+		},
+	})
+	require.NoError(t, err)
+	if _, ok := resp.(api.CreateSecret201JSONResponse); !ok {
+		t.Fatalf("expected 201, got %T", resp)
+	}
+
+	claim, err := handler.K8sManager.GetSecretClaim(ctx, "db", "default")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"password": sealed}, claim.Spec.EncryptedData)
+	require.Equal(t, map[string]string{"username": "app"}, claim.Spec.Data)
+
+	got := mapClaimToSecretResponse(claim, nil)
+	require.NotNil(t, got.EncryptedData)
+	require.Equal(t, sealed, (*got.EncryptedData)["password"])
+
+	encoding := api.Alphanumeric
+	invalid := []*api.CreateSecretRequest{
+		{Name: "plain", Namespace: "default", Type: api.CreateSecretRequestTypeOpaque, EncryptedData: &map[string]string{"password": "s3cret"}},
+		{Name: "twice", Namespace: "default", Type: api.CreateSecretRequestTypeOpaque, Data: &map[string]string{"password": "s3cret"}, EncryptedData: &map[string]string{"password": sealed}},
+		{Name: "generated", Namespace: "default", Type: api.CreateSecretRequestTypeAutoGenerated, GenerationConfig: &api.GenerationConfig{Length: 16, Encoding: &encoding, DataKeys: &[]string{"token"}}, EncryptedData: &map[string]string{"password": sealed}},
+	}
+	for _, body := range invalid {
+		resp, err := handler.CreateSecret(ctx, api.CreateSecretRequestObject{Body: body})
+		require.NoError(t, err)
+		if _, ok := resp.(api.CreateSecret400JSONResponse); !ok {
+			t.Errorf("%s: expected 400, got %T", body.Name, resp)
+		}
+	}
+}
+
+func TestSecretHandler_GetSealingKey(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"dev-one"},
+		Role:              "developer",
+	})
+
+	handler := newTestSecretHandler(t)
+
+	resp, err := handler.GetSealingKey(ctx, api.GetSealingKeyRequestObject{})
+	require.NoError(t, err)
+	if _, ok := resp.(api.GetSealingKey404JSONResponse); !ok {
+		t.Fatalf("expected 404 without a sealing namespace, got %T", resp)
+	}
+
+	handler.cfg.Sealing.Namespace = "secret-manager-system"
+	resp, err = handler.GetSealingKey(ctx, api.GetSealingKeyRequestObject{})
+	require.NoError(t, err)
+	if _, ok := resp.(api.GetSealingKey404JSONResponse); !ok {
+		t.Fatalf("expected 404 before the key is published, got %T", resp)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: secretsv1alpha1.SealingPublicKeyConfigMap, Namespace: "secret-manager-system"},
+		Data: map[string]string{
+			secretsv1alpha1.SealingPublicKeyKey: "-----BEGIN PUBLIC KEY-----",
+			secretsv1alpha1.SealingKeyIDKey:     "0123456789abcdef",
+		},
+	}
+	require.NoError(t, handler.K8sManager.(*k8s.K8sDynamicClient).Client.Create(ctx, cm))
+
+	resp, err = handler.GetSealingKey(ctx, api.GetSealingKeyRequestObject{})
+	require.NoError(t, err)
+	got, ok := resp.(api.GetSealingKey200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	require.Equal(t, "0123456789abcdef", got.KeyId)
+	require.Equal(t, "-----BEGIN PUBLIC KEY-----", got.PublicKey)
+}
+
+func TestSecretHandler_GetOIDCConfig(t *testing.T) {
+	handler := newTestSecretHandler(t)
+
+	resp, err := handler.GetOIDCConfig(context.Background(), api.GetOIDCConfigRequestObject{})
+	require.NoError(t, err)
+	if _, ok := resp.(api.GetOIDCConfig404JSONResponse); !ok {
+		t.Fatalf("expected 404 without an issuer, got %T", resp)
+	}
+
+	handler.cfg.OIDC = cfg.OIDCConfig{Issuer: "https://idp.example.com", ClientID: "ksec", Scopes: []string{"openid", "email"}}
+	resp, err = handler.GetOIDCConfig(context.Background(), api.GetOIDCConfigRequestObject{})
+	require.NoError(t, err)
+	got, ok := resp.(api.GetOIDCConfig200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	require.Equal(t, "https://idp.example.com", got.Issuer)
+	require.Equal(t, "ksec", got.ClientId)
+	require.Equal(t, []string{"openid", "email"}, *got.Scopes)
+}
+
+func TestSecretHandler_GetJWKS(t *testing.T) {
+	handler := newTestSecretHandler(t)
+	handler.Keys = auth.NewHMACKeySet("mysecretjwtkey")
+
+	resp, err := handler.GetJWKS(context.Background(), api.GetJWKSRequestObject{})
+	require.NoError(t, err)
+	if _, ok := resp.(api.GetJWKS404JSONResponse); !ok {
+		t.Fatalf("expected 404 with an HMAC secret, got %T", resp)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "signing.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	handler.Keys, err = auth.LoadKeySet(cfg.JWTConfig{
+		Keys:      []cfg.SigningKeyConfig{{ID: "2026-10", File: keyFile}},
+		ActiveKey: "2026-10",
+	})
+	require.NoError(t, err)
+
+	resp, err = handler.GetJWKS(context.Background(), api.GetJWKSRequestObject{})
+	require.NoError(t, err)
+	got, ok := resp.(api.GetJWKS200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	require.Len(t, got.Keys, 1)
+	require.Equal(t, "2026-10", got.Keys[0].Kid)
+	require.Equal(t, "OKP", got.Keys[0].Kty)
+	require.Equal(t, "EdDSA", *got.Keys[0].Alg)
+	require.Nil(t, got.Keys[0].N)
+}
+
 func TestSecretHandler_AuthUser_Success(t *testing.T) {
 	password := "secret"
 
@@ -798,6 +1823,8 @@ func TestSecretHandler_AuthUser_Success(t *testing.T) {
 		cfg:    cfgMock,
 		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
 		Tracer: otel.Tracer("test"),
+		Keys:   auth.NewHMACKeySet(cfgMock.JWT.Secret),
+		Users:  auth.NewConfigUserStore(cfgMock.Users, auth.NewMemoryUserStore()),
 	}
 
 	req := api.AuthUserRequestObject{
@@ -836,6 +1863,8 @@ func TestSecretHandler_AuthUser_WrongCredentials(t *testing.T) {
 		cfg:    cfgMock,
 		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
 		Tracer: otel.Tracer("test"),
+		Keys:   auth.NewHMACKeySet(cfgMock.JWT.Secret),
+		Users:  auth.NewConfigUserStore(cfgMock.Users, auth.NewMemoryUserStore()),
 	}
 
 	req := api.AuthUserRequestObject{
@@ -882,6 +1911,8 @@ func TestSecretHandler_AuthUser_JWTError(t *testing.T) {
 		cfg:    cfgMock,
 		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
 		Tracer: otel.Tracer("test"),
+		Keys:   auth.NewHMACKeySet(cfgMock.JWT.Secret),
+		Users:  auth.NewConfigUserStore(cfgMock.Users, auth.NewMemoryUserStore()),
 	}
 
 	req := api.AuthUserRequestObject{
@@ -905,3 +1936,710 @@ func TestSecretHandler_AuthUser_JWTError(t *testing.T) {
 		t.Errorf("expected Internal Server Error error code, got %s", *respErr.ErrorCode)
 	}
 }
+
+func TestSecretHandler_RefreshUserToken(t *testing.T) {
+	handler := &SecretHandler{
+		cfg: cfg.Config{
+			Users: []cfg.User{{ID: "1", Username: "testuser", Role: "developer"}},
+			JWT:   cfg.JWTConfig{Secret: "mysecretjwtkey"},
+		},
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Tracer:      otel.Tracer("test"),
+		Revocations: auth.NewMemoryRevocationStore(),
+		Keys:        auth.NewHMACKeySet("mysecretjwtkey"),
+	}
+	handler.Users = auth.NewConfigUserStore(handler.cfg.Users, auth.NewMemoryUserStore())
+	tokens, err := handler.issueTokens(&handler.cfg.Users[0])
+	require.NoError(t, err)
+	require.Equal(t, int64(15*60), *tokens.ExpiresIn)
+
+	refresh := func(token string) api.RefreshUserTokenResponseObject {
+		resp, err := handler.RefreshUserToken(context.Background(), api.RefreshUserTokenRequestObject{
+			Body: &api.RefreshUserTokenRequest{RefreshToken: token},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := refresh(*tokens.RefreshToken)
+	refreshed, ok := resp.(api.RefreshUserToken200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	require.NotEqual(t, *tokens.RefreshToken, *refreshed.RefreshToken)
+
+	// Refresh tokens are single use.
+	if _, ok := refresh(*tokens.RefreshToken).(api.RefreshUserToken401JSONResponse); !ok {
+		t.Fatal("expected a used refresh token to be rejected")
+	}
+	// Access tokens are not refresh tokens.
+	if _, ok := refresh(refreshed.Token).(api.RefreshUserToken401JSONResponse); !ok {
+		t.Fatal("expected an access token to be rejected")
+	}
+	// Of concurrent refreshes with the same token only one succeeds.
+	var wg sync.WaitGroup
+	results := make(chan api.RefreshUserTokenResponseObject, 8)
+	for range cap(results) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := handler.RefreshUserToken(context.Background(), api.RefreshUserTokenRequestObject{
+				Body: &api.RefreshUserTokenRequest{RefreshToken: *refreshed.RefreshToken},
+			})
+			if err == nil {
+				results <- resp
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+	succeeded := 0
+	for resp := range results {
+		if _, ok := resp.(api.RefreshUserToken200JSONResponse); ok {
+			succeeded++
+		}
+	}
+	require.Equal(t, 1, succeeded)
+	// Removed users cannot refresh.
+	tokens, err = handler.issueTokens(&handler.cfg.Users[0])
+	require.NoError(t, err)
+	handler.Users = auth.NewConfigUserStore(nil, auth.NewMemoryUserStore())
+	if _, ok := refresh(*tokens.RefreshToken).(api.RefreshUserToken401JSONResponse); !ok {
+		t.Fatal("expected a removed user to be rejected")
+	}
+}
+
+func TestSecretHandler_LogoutUser(t *testing.T) {
+	handler := &SecretHandler{
+		cfg: cfg.Config{
+			Users: []cfg.User{{ID: "1", Username: "testuser", Role: "developer"}, {ID: "2", Username: "other", Role: "developer"}},
+			JWT:   cfg.JWTConfig{Secret: "mysecretjwtkey"},
+		},
+		Logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Tracer:      otel.Tracer("test"),
+		Revocations: auth.NewMemoryRevocationStore(),
+		Keys:        auth.NewHMACKeySet("mysecretjwtkey"),
+	}
+	ctx := context.Background()
+	tokens, err := handler.issueTokens(&handler.cfg.Users[0])
+	require.NoError(t, err)
+	accessClaims, err := authMiddleware.GetClaimsFromToken(tokens.Token, handler.Keys)
+	require.NoError(t, err)
+	refreshClaims, err := auth.ParseRefreshToken(*tokens.RefreshToken, handler.Keys)
+	require.NoError(t, err)
+
+	otherTokens, err := handler.issueTokens(&handler.cfg.Users[1])
+	require.NoError(t, err)
+	resp, err := handler.LogoutUser(auth.ContextWithClaims(ctx, accessClaims), api.LogoutUserRequestObject{
+		Body: &api.LogoutUserRequest{RefreshToken: otherTokens.RefreshToken},
+	})
+	require.NoError(t, err)
+	if _, ok := resp.(api.LogoutUser400JSONResponse); !ok {
+		t.Fatalf("expected 400 for another user's refresh token, got %T", resp)
+	}
+
+	resp, err = handler.LogoutUser(auth.ContextWithClaims(ctx, accessClaims), api.LogoutUserRequestObject{
+		Body: &api.LogoutUserRequest{RefreshToken: tokens.RefreshToken},
+	})
+	require.NoError(t, err)
+	if _, ok := resp.(api.LogoutUser200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	for _, id := range []string{accessClaims.ID, refreshClaims.ID} {
+		revoked, err := handler.Revocations.IsRevoked(ctx, id)
+		require.NoError(t, err)
+		require.True(t, revoked)
+	}
+}
+
+func createRevealFixture(t *testing.T, ctx context.Context, cl client.Client) {
+	t.Helper()
+
+	claim := &secretsv1alpha1.SecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: types.UID("claim-uid")},
+		Spec:       secretsv1alpha1.SecretClaimSpec{Type: "Opaque"},
+		Status:     secretsv1alpha1.SecretClaimStatus{Synced: true},
+	}
+	require.NoError(t, cl.Create(ctx, claim))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	require.NoError(t, controllerutil.SetControllerReference(claim, secret, cl.Scheme()))
+	require.NoError(t, cl.Create(ctx, secret))
+}
+
+func TestSecretHandler_GetSecret_MasksValues(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "developer",
+	})
+	handler := newTestSecretHandler(t)
+	createRevealFixture(t, ctx, handler.K8sManager.(*k8s.K8sDynamicClient).Client)
+
+	resp, err := handler.GetSecret(ctx, api.GetSecretRequestObject{Name: "db", Params: api.GetSecretParams{Namespace: "default"}})
+	require.NoError(t, err)
+
+	got, ok := resp.(api.GetSecret200JSONResponse)
+	require.True(t, ok, "expected 200, got %T", resp)
+	require.NotNil(t, got.Data)
+	require.Equal(t, map[string]string{"password": maskedValue}, *got.Data)
+}
+
+func TestSecretHandler_GetSecret_MasksRegistryPassword(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"default"},
+		Role:              "developer",
+	})
+	handler := newTestSecretHandler(t)
+	cl := handler.K8sManager.(*k8s.K8sDynamicClient).Client
+
+	claim := &secretsv1alpha1.SecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull", Namespace: "default", UID: types.UID("pull-uid")},
+		Spec: secretsv1alpha1.SecretClaimSpec{
+			Type:         "Opaque",
+			SecretType:   string(corev1.SecretTypeDockerConfigJson),
+			DockerConfig: &secretsv1alpha1.DockerConfig{Registry: "registry.example.com", Username: "ci"},
+		},
+		Status: secretsv1alpha1.SecretClaimStatus{Synced: true},
+	}
+	require.NoError(t, cl.Create(ctx, claim))
+	config, err := secrettypes.DockerConfigJSON(secrettypes.DockerConfig{Registry: "registry.example.com", Username: "ci"},
+		map[string][]byte{"password": []byte("s3cr3t")})
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull", Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{"password": []byte("s3cr3t"), corev1.DockerConfigJsonKey: config},
+	}
+	require.NoError(t, controllerutil.SetControllerReference(claim, secret, cl.Scheme()))
+	require.NoError(t, cl.Create(ctx, secret))
+
+	resp, err := handler.GetSecret(ctx, api.GetSecretRequestObject{Name: "pull", Params: api.GetSecretParams{Namespace: "default"}})
+	require.NoError(t, err)
+	got, ok := resp.(api.GetSecret200JSONResponse)
+	require.True(t, ok, "expected 200, got %T", resp)
+
+	body, err := json.Marshal(got)
+	require.NoError(t, err)
+	require.NotContains(t, string(body), "s3cr3t")
+	require.NotContains(t, string(body), base64.StdEncoding.EncodeToString([]byte("ci:s3cr3t")))
+	require.Equal(t, map[string]string{"password": maskedValue, corev1.DockerConfigJsonKey: maskedValue}, *got.Data)
+}
+
+func TestSecretHandler_RevealSecret_RequiresPermission(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		Username:          "dev",
+		AllowedNamespaces: []string{"default"},
+		Role:              "developer",
+	})
+	handler := newTestSecretHandler(t)
+	var buf bytes.Buffer
+	handler.Audit = audit.New(slog.New(slog.NewJSONHandler(&buf, nil)))
+	createRevealFixture(t, ctx, handler.K8sManager.(*k8s.K8sDynamicClient).Client)
+
+	resp, err := handler.RevealSecret(ctx, api.RevealSecretRequestObject{Name: "db", Params: api.RevealSecretParams{Namespace: "default"}})
+	require.NoError(t, err)
+	require.IsType(t, api.RevealSecret403JSONResponse{}, resp)
+	require.Contains(t, buf.String(), `"allowed":false`)
+	require.Contains(t, buf.String(), `"actor":"dev"`)
+}
+
+func TestSecretHandler_RevealSecret_Success(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		Username:          "admin",
+		AllowedNamespaces: []string{"*"},
+		Role:              "admin",
+		Permissions:       []string{auth.PermissionRevealSecrets},
+	})
+	handler := newTestSecretHandler(t)
+	var buf bytes.Buffer
+	handler.Audit = audit.New(slog.New(slog.NewJSONHandler(&buf, nil)))
+	createRevealFixture(t, ctx, handler.K8sManager.(*k8s.K8sDynamicClient).Client)
+
+	resp, err := handler.RevealSecret(ctx, api.RevealSecretRequestObject{Name: "db", Params: api.RevealSecretParams{Namespace: "default"}})
+	require.NoError(t, err)
+
+	got, ok := resp.(api.RevealSecret200JSONResponse)
+	require.True(t, ok, "expected 200, got %T", resp)
+	require.Equal(t, map[string]string{"password": "s3cr3t"}, got.Data)
+	require.Contains(t, buf.String(), `"allowed":true`)
+	require.Contains(t, buf.String(), `"keys":["password"]`)
+	require.NotContains(t, buf.String(), "s3cr3t")
+}
+
+func TestSecretHandler_RevealSecret_NotControlled(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"*"},
+		Role:              "admin",
+		Permissions:       []string{auth.PermissionRevealSecrets},
+	})
+	handler := newTestSecretHandler(t)
+	cl := handler.K8sManager.(*k8s.K8sDynamicClient).Client
+	require.NoError(t, cl.Create(ctx, &secretsv1alpha1.SecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       secretsv1alpha1.SecretClaimSpec{Type: "Opaque"},
+	}))
+	require.NoError(t, cl.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("foreign")},
+	}))
+
+	resp, err := handler.RevealSecret(ctx, api.RevealSecretRequestObject{Name: "db", Params: api.RevealSecretParams{Namespace: "default"}})
+	require.NoError(t, err)
+	require.IsType(t, api.RevealSecret404JSONResponse{}, resp)
+}
+
+func TestSecretHandler_RevealClusterSecret_RequiresAdmin(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"*"},
+		Role:              "operator",
+		Permissions:       []string{auth.PermissionRevealSecrets},
+	})
+	handler := newTestSecretHandler(t)
+
+	resp, err := handler.RevealClusterSecret(ctx, api.RevealClusterSecretRequestObject{Name: "shared"})
+	require.NoError(t, err)
+	require.IsType(t, api.RevealClusterSecret403JSONResponse{}, resp)
+}
+
+func TestSecretHandler_PolicyRotatorRole(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{
+		AllowedNamespaces: []string{"*"},
+		Role:              "rotator",
+	})
+
+	handler := newTestSecretHandler(t)
+	authorizer, err := policy.NewRoleAuthorizer([]cfg.Role{{Name: "rotator", Rules: []cfg.PolicyRule{{
+		Resources:     []string{policy.ResourceSecrets},
+		Verbs:         []string{policy.VerbList, policy.VerbRotate},
+		LabelSelector: "rotation=managed",
+	}}}})
+	require.NoError(t, err)
+	handler.Authorizer = authorizer
+
+	cl := handler.K8sManager.(*k8s.K8sDynamicClient).Client
+	for name, labels := range map[string]map[string]string{"managed": {"rotation": "managed"}, "manual": nil} {
+		require.NoError(t, cl.Create(ctx, &secretsv1alpha1.SecretClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: secretsv1alpha1.SecretClaimSpec{
+				Type:       "AutoGenerated",
+				Generation: &secretsv1alpha1.GenerationConfig{Length: 16, Encoding: "base64", DataKeys: []string{"password"}},
+			},
+		}))
+	}
+
+	list, err := handler.ListSecrets(ctx, api.ListSecretsRequestObject{Params: api.ListSecretsParams{Namespace: "default"}})
+	require.NoError(t, err)
+	items := list.(api.ListSecrets200JSONResponse).Items
+	require.Len(t, items, 1)
+	require.Equal(t, "managed", items[0].Name)
+
+	rotate := func(name string, body api.UpdateSecretRequest) api.UpdateSecretResponseObject {
+		resp, err := handler.UpdateSecret(ctx, api.UpdateSecretRequestObject{Name: name, Params: api.UpdateSecretParams{Namespace: "default"}, Body: &body})
+		require.NoError(t, err)
+		return resp
+	}
+	require.IsType(t, api.UpdateSecret200JSONResponse{}, rotate("managed", api.UpdateSecretRequest{Regenerate: BoolPnc(true)}))
+	require.IsType(t, api.UpdateSecret403JSONResponse{}, rotate("manual", api.UpdateSecretRequest{Regenerate: BoolPnc(true)}))
+	require.IsType(t, api.UpdateSecret403JSONResponse{}, rotate("managed", api.UpdateSecretRequest{Templates: &map[string]string{"url": "x"}}))
+
+	resp, err := handler.GetSecret(ctx, api.GetSecretRequestObject{Name: "managed", Params: api.GetSecretParams{Namespace: "default"}})
+	require.NoError(t, err)
+	require.IsType(t, api.GetSecret403JSONResponse{}, resp)
+}
+
+func TestSecretHandler_APIKeys(t *testing.T) {
+	handler := newTestSecretHandler(t)
+	handler.APIKeys = auth.NewMemoryAPIKeyStore()
+	admin := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "root", Role: "admin", AllowedNamespaces: []string{"staging"}})
+	operator := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "op", Role: "operator", AllowedNamespaces: []string{"staging"}})
+
+	create := func(ctx context.Context, body api.CreateAPIKeyRequest) api.CreateAPIKeyResponseObject {
+		resp, err := handler.CreateAPIKey(ctx, api.CreateAPIKeyRequestObject{Body: &body})
+		require.NoError(t, err)
+		return resp
+	}
+	body := api.CreateAPIKeyRequest{Name: "ci-deploy", Role: "operator", Namespaces: []string{"staging"}, Verbs: &[]string{"get", "rotate"}}
+
+	if _, ok := create(operator, body).(api.CreateAPIKey403JSONResponse); !ok {
+		t.Fatal("expected operators not to create api keys")
+	}
+	if _, ok := create(admin, api.CreateAPIKeyRequest{Name: "ci", Role: "operator", Namespaces: []string{"production"}}).(api.CreateAPIKey403JSONResponse); !ok {
+		t.Fatal("expected a key for a namespace the caller is not allowed to be rejected")
+	}
+	for _, bad := range []api.CreateAPIKeyRequest{
+		{Name: "CI Deploy", Role: "operator", Namespaces: []string{"staging"}},
+		{Name: "ci", Role: "editor", Namespaces: []string{"staging"}},
+		{Name: "ci", Role: "operator"},
+		{Name: "ci", Role: "operator", Namespaces: []string{"staging"}, Verbs: &[]string{"explode"}},
+	} {
+		if _, ok := create(admin, bad).(api.CreateAPIKey400JSONResponse); !ok {
+			t.Fatalf("expected 400 for %+v", bad)
+		}
+	}
+
+	resp := create(admin, body)
+	created, ok := resp.(api.CreateAPIKey201JSONResponse)
+	if !ok {
+		t.Fatalf("expected 201, got %T", resp)
+	}
+	require.Equal(t, "root", created.ApiKey.CreatedBy)
+	claims, err := auth.AuthenticateAPIKey(context.Background(), handler.APIKeys, created.Key)
+	require.NoError(t, err)
+	require.Equal(t, "operator", claims.Role)
+	require.Equal(t, []string{"get", "rotate"}, claims.Verbs)
+
+	// A key limited to some verbs cannot issue keys with more.
+	scoped := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "apikey:admin", Role: "admin", AllowedNamespaces: []string{"staging"}, Verbs: []string{"create", "get"}})
+	if _, ok := create(scoped, api.CreateAPIKeyRequest{Name: "wide", Role: "operator", Namespaces: []string{"staging"}}).(api.CreateAPIKey403JSONResponse); !ok {
+		t.Fatal("expected a verb-limited caller to be unable to issue an unlimited key")
+	}
+
+	listResp, err := handler.ListAPIKeys(admin, api.ListAPIKeysRequestObject{})
+	require.NoError(t, err)
+	list, ok := listResp.(api.ListAPIKeys200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", listResp)
+	}
+	require.Len(t, list.Items, 1)
+	require.Equal(t, created.ApiKey.Id, list.Items[0].Id)
+
+	deleteResp, err := handler.DeleteAPIKey(admin, api.DeleteAPIKeyRequestObject{Id: created.ApiKey.Id})
+	require.NoError(t, err)
+	if _, ok := deleteResp.(api.DeleteAPIKey200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", deleteResp)
+	}
+	deleteResp, err = handler.DeleteAPIKey(admin, api.DeleteAPIKeyRequestObject{Id: created.ApiKey.Id})
+	require.NoError(t, err)
+	if _, ok := deleteResp.(api.DeleteAPIKey404JSONResponse); !ok {
+		t.Fatalf("expected 404 for a deleted key, got %T", deleteResp)
+	}
+	_, err = auth.AuthenticateAPIKey(context.Background(), handler.APIKeys, created.Key)
+	require.ErrorIs(t, err, auth.ErrUnauthorizedToken)
+
+	// A role allowed to issue keys cannot hand out a role that grants more.
+	handler.cfg.Roles = []cfg.Role{{Name: "key-issuer", Rules: []cfg.PolicyRule{
+		{Resources: []string{policy.ResourceSecrets}, Verbs: []string{policy.VerbList, policy.VerbGet}},
+		{Resources: []string{policy.ResourceAPIKeys}, Verbs: []string{policy.VerbCreate}},
+	}}}
+	handler.Authorizer, err = policy.NewRoleAuthorizer(handler.cfg.Roles)
+	require.NoError(t, err)
+	issuer := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "issuer", Role: "key-issuer", AllowedNamespaces: []string{"staging"}})
+	for _, role := range []string{"admin", "operator"} {
+		if _, ok := create(issuer, api.CreateAPIKeyRequest{Name: "escalated", Role: role, Namespaces: []string{"staging"}}).(api.CreateAPIKey403JSONResponse); !ok {
+			t.Fatalf("expected a key with role %s to be rejected for the key-issuer role", role)
+		}
+	}
+	if _, ok := create(issuer, api.CreateAPIKeyRequest{Name: "reader", Role: "developer", Namespaces: []string{"staging"}}).(api.CreateAPIKey201JSONResponse); !ok {
+		t.Fatal("expected a key with a role within the caller's to be created")
+	}
+
+	// Keys cannot create keys, which would outlive them.
+	resp = create(issuer, api.CreateAPIKeyRequest{Name: "key-issuer", Role: "key-issuer", Namespaces: []string{"staging"}})
+	parent, ok := resp.(api.CreateAPIKey201JSONResponse)
+	if !ok {
+		t.Fatalf("expected 201, got %T", resp)
+	}
+	claims, err = auth.AuthenticateAPIKey(context.Background(), handler.APIKeys, parent.Key)
+	require.NoError(t, err)
+	if _, ok := create(auth.ContextWithClaims(context.Background(), claims), api.CreateAPIKeyRequest{Name: "child", Role: "developer", Namespaces: []string{"staging"}}).(api.CreateAPIKey403JSONResponse); !ok {
+		t.Fatal("expected an api key to be unable to create api keys")
+	}
+}
+
+func TestSecretHandler_Users(t *testing.T) {
+	handler := newTestSecretHandler(t)
+	handler.Keys = auth.NewHMACKeySet("mysecretjwtkey")
+	handler.Revocations = auth.NewMemoryRevocationStore()
+	handler.Users = auth.NewConfigUserStore([]cfg.User{{ID: "0", Username: "root", Role: "admin", AllowedNamespaces: []string{"*"}}}, auth.NewMemoryUserStore())
+	admin := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "root", Role: "admin", AllowedNamespaces: []string{"staging", "team-a"}})
+	operator := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "op", Role: "operator", AllowedNamespaces: []string{"staging"}})
+
+	create := func(ctx context.Context, body api.CreateUserRequest) api.CreateUserResponseObject {
+		resp, err := handler.CreateUser(ctx, api.CreateUserRequestObject{Body: &body})
+		require.NoError(t, err)
+		return resp
+	}
+	login := func(username, password string) bool {
+		resp, err := handler.AuthUser(context.Background(), api.AuthUserRequestObject{Body: &api.AuthUserRequest{Username: username, Password: password}})
+		require.NoError(t, err)
+		_, ok := resp.(api.AuthUser200JSONResponse)
+		return ok
+	}
+	body := api.CreateUserRequest{Username: "jane", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}}
+
+	if _, ok := create(operator, body).(api.CreateUser403JSONResponse); !ok {
+		t.Fatal("expected operators not to create users")
+	}
+	for _, bad := range []api.CreateUserRequest{
+		{Username: "Jane Doe", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}},
+		{Username: "jane", Password: "short", Role: "developer", AllowedNamespaces: []string{"staging"}},
+		{Username: "jane", Password: "first-password", Role: "editor", AllowedNamespaces: []string{"staging"}},
+		{Username: "jane", Password: "first-password", Role: "developer"},
+		{Username: "jane", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}, Permissions: &[]string{"secrets:everything"}},
+	} {
+		if _, ok := create(admin, bad).(api.CreateUser400JSONResponse); !ok {
+			t.Fatalf("expected 400 for %+v", bad)
+		}
+	}
+	if _, ok := create(admin, api.CreateUserRequest{Username: "jane", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"production"}}).(api.CreateUser403JSONResponse); !ok {
+		t.Fatal("expected a user for a namespace the caller is not allowed to be rejected")
+	}
+	if _, ok := create(admin, api.CreateUserRequest{Username: "jane", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}, Permissions: &[]string{auth.PermissionRevealSecrets}}).(api.CreateUser403JSONResponse); !ok {
+		t.Fatal("expected a permission the caller does not have to be rejected")
+	}
+
+	resp := create(admin, body)
+	created, ok := resp.(api.CreateUser201JSONResponse)
+	if !ok {
+		t.Fatalf("expected 201, got %T", resp)
+	}
+	require.False(t, created.FromConfig)
+	require.True(t, login("jane", "first-password"))
+	if _, ok := create(admin, body).(api.CreateUser409JSONResponse); !ok {
+		t.Fatal("expected 409 for an existing user")
+	}
+	if _, ok := create(admin, api.CreateUserRequest{Username: "root", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}}).(api.CreateUser409JSONResponse); !ok {
+		t.Fatal("expected 409 for a user of the config file")
+	}
+
+	listResp, err := handler.ListUsers(admin, api.ListUsersRequestObject{})
+	require.NoError(t, err)
+	list, ok := listResp.(api.ListUsers200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", listResp)
+	}
+	require.Len(t, list.Items, 2)
+	require.Equal(t, "root", list.Items[0].Username)
+	require.True(t, list.Items[0].FromConfig)
+
+	// Users of the config file are read-only.
+	role := "operator"
+	updateResp, err := handler.UpdateUser(admin, api.UpdateUserRequestObject{Username: "root", Body: &api.UpdateUserRequest{Role: &role}})
+	require.NoError(t, err)
+	if _, ok := updateResp.(api.UpdateUser409JSONResponse); !ok {
+		t.Fatalf("expected 409 for a user of the config file, got %T", updateResp)
+	}
+	wide := []string{"production"}
+	updateResp, err = handler.UpdateUser(admin, api.UpdateUserRequestObject{Username: "jane", Body: &api.UpdateUserRequest{AllowedNamespaces: &wide}})
+	require.NoError(t, err)
+	if _, ok := updateResp.(api.UpdateUser403JSONResponse); !ok {
+		t.Fatalf("expected 403 for namespaces the caller is not allowed, got %T", updateResp)
+	}
+	password := "reset-password"
+	updateResp, err = handler.UpdateUser(admin, api.UpdateUserRequestObject{Username: "jane", Body: &api.UpdateUserRequest{Role: &role, Password: &password}})
+	require.NoError(t, err)
+	updated, ok := updateResp.(api.UpdateUser200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", updateResp)
+	}
+	require.Equal(t, "operator", updated.Role)
+	require.False(t, login("jane", "first-password"))
+	require.True(t, login("jane", "reset-password"))
+
+	// Users change their own password with the current one.
+	jane := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "jane", Role: "operator", RegisteredClaims: jwt.RegisteredClaims{Subject: created.Id}})
+	changePassword := func(ctx context.Context, current, next string) api.ChangePasswordResponseObject {
+		resp, err := handler.ChangePassword(ctx, api.ChangePasswordRequestObject{Body: &api.ChangePasswordRequest{CurrentPassword: current, NewPassword: next}})
+		require.NoError(t, err)
+		return resp
+	}
+	if _, ok := changePassword(jane, "wrong-password", "third-password").(api.ChangePassword400JSONResponse); !ok {
+		t.Fatal("expected a wrong current password to be rejected")
+	}
+	if _, ok := changePassword(jane, "reset-password", "short").(api.ChangePassword400JSONResponse); !ok {
+		t.Fatal("expected a short password to be rejected")
+	}
+	if _, ok := changePassword(admin, "any-password", "third-password").(api.ChangePassword409JSONResponse); !ok {
+		t.Fatal("expected users of the config file to be unable to change their password")
+	}
+	apiKey := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "apikey:ci", Role: "operator"})
+	if _, ok := changePassword(apiKey, "any-password", "third-password").(api.ChangePassword400JSONResponse); !ok {
+		t.Fatal("expected callers without a password to be rejected")
+	}
+	if _, ok := changePassword(jane, "reset-password", "third-password").(api.ChangePassword200JSONResponse); !ok {
+		t.Fatal("expected the password to be changed")
+	}
+	require.True(t, login("jane", "third-password"))
+
+	// Refresh tokens issued before a password change are rejected.
+	user, err := handler.Users.Get(context.Background(), "jane")
+	require.NoError(t, err)
+	tokens, err := handler.issueTokens(user)
+	require.NoError(t, err)
+	changedAt := time.Now().Add(2 * time.Second)
+	user.PasswordChangedAt = &changedAt
+	require.NoError(t, handler.Users.Update(context.Background(), *user))
+	refreshResp, err := handler.RefreshUserToken(context.Background(), api.RefreshUserTokenRequestObject{Body: &api.RefreshUserTokenRequest{RefreshToken: *tokens.RefreshToken}})
+	require.NoError(t, err)
+	if _, ok := refreshResp.(api.RefreshUserToken401JSONResponse); !ok {
+		t.Fatalf("expected a refresh token from before the password change to be rejected, got %T", refreshResp)
+	}
+
+	deleteResp, err := handler.DeleteUser(admin, api.DeleteUserRequestObject{Username: "root"})
+	require.NoError(t, err)
+	if _, ok := deleteResp.(api.DeleteUser409JSONResponse); !ok {
+		t.Fatalf("expected 409 for a user of the config file, got %T", deleteResp)
+	}
+	deleteResp, err = handler.DeleteUser(admin, api.DeleteUserRequestObject{Username: "jane"})
+	require.NoError(t, err)
+	if _, ok := deleteResp.(api.DeleteUser200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", deleteResp)
+	}
+	getResp, err := handler.GetUser(admin, api.GetUserRequestObject{Username: "jane"})
+	require.NoError(t, err)
+	if _, ok := getResp.(api.GetUser404JSONResponse); !ok {
+		t.Fatalf("expected 404 for a deleted user, got %T", getResp)
+	}
+	require.False(t, login("jane", "third-password"))
+
+	// A role allowed to manage users cannot hand out a role that grants more
+	// or a Kubernetes identity it does not have.
+	handler.cfg.Roles = []cfg.Role{{Name: "user-manager", Rules: []cfg.PolicyRule{
+		{Resources: []string{policy.ResourceSecrets}, Verbs: []string{policy.VerbList, policy.VerbGet}},
+		{Resources: []string{policy.ResourceUsers}, Verbs: []string{policy.VerbList, policy.VerbGet, policy.VerbCreate, policy.VerbUpdate}},
+	}}}
+	handler.Authorizer, err = policy.NewRoleAuthorizer(handler.cfg.Roles)
+	require.NoError(t, err)
+	manager := auth.ContextWithClaims(context.Background(), &auth.Claims{Username: "manager", Role: "user-manager", AllowedNamespaces: []string{"staging"}, KubernetesUser: "manager@example.com", KubernetesGroups: []string{"team-a"}})
+	for _, role := range []string{"admin", "operator"} {
+		if _, ok := create(manager, api.CreateUserRequest{Username: "bob", Password: "first-password", Role: role, AllowedNamespaces: []string{"staging"}}).(api.CreateUser403JSONResponse); !ok {
+			t.Fatalf("expected a user with role %s to be rejected for the user-manager role", role)
+		}
+	}
+	if _, ok := create(manager, api.CreateUserRequest{Username: "bob", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}, KubernetesUser: optionalStr("kubernetes-admin")}).(api.CreateUser403JSONResponse); !ok {
+		t.Fatal("expected a Kubernetes user the caller does not have to be rejected")
+	}
+	if _, ok := create(manager, api.CreateUserRequest{Username: "bob", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}, KubernetesGroups: &[]string{"system:masters"}}).(api.CreateUser403JSONResponse); !ok {
+		t.Fatal("expected a Kubernetes group the caller does not have to be rejected")
+	}
+	resp = create(manager, api.CreateUserRequest{Username: "bob", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}, KubernetesGroups: &[]string{"team-a"}})
+	created, ok = resp.(api.CreateUser201JSONResponse)
+	if !ok {
+		t.Fatalf("expected a user with a group of the caller to be created, got %T", resp)
+	}
+	// Users named after a cluster user are not reviewed as it.
+	require.Equal(t, "ksm:bob", *created.KubernetesUser)
+	for _, body := range []api.UpdateUserRequest{
+		{Role: &role},
+		{KubernetesUser: optionalStr("kubernetes-admin")},
+		{KubernetesGroups: &[]string{"system:masters"}},
+	} {
+		updateResp, err = handler.UpdateUser(manager, api.UpdateUserRequestObject{Username: "bob", Body: &body})
+		require.NoError(t, err)
+		if _, ok := updateResp.(api.UpdateUser403JSONResponse); !ok {
+			t.Fatalf("expected 403 for %+v, got %T", body, updateResp)
+		}
+	}
+	if _, ok := create(admin, api.CreateUserRequest{Username: "carol", Password: "first-password", Role: "developer", AllowedNamespaces: []string{"staging"}, KubernetesUser: optionalStr("kubernetes-admin")}).(api.CreateUser201JSONResponse); !ok {
+		t.Fatal("expected admins to set any Kubernetes user")
+	}
+	updateResp, err = handler.UpdateUser(manager, api.UpdateUserRequestObject{Username: "carol", Body: &api.UpdateUserRequest{Password: &password}})
+	require.NoError(t, err)
+	if _, ok := updateResp.(api.UpdateUser403JSONResponse); !ok {
+		t.Fatalf("expected 403 for a user with a Kubernetes user the caller does not have, got %T", updateResp)
+	}
+	// Users stored before the default Kubernetes user are reviewed as their username.
+	require.NoError(t, handler.Users.Create(context.Background(), cfg.User{ID: "2", Username: "kubernetes-admin", Role: "developer", AllowedNamespaces: []string{"staging"}}))
+	updateResp, err = handler.UpdateUser(manager, api.UpdateUserRequestObject{Username: "kubernetes-admin", Body: &api.UpdateUserRequest{Password: &password}})
+	require.NoError(t, err)
+	if _, ok := updateResp.(api.UpdateUser403JSONResponse); !ok {
+		t.Fatalf("expected 403 for a user reviewed as a cluster user, got %T", updateResp)
+	}
+}
+
+func TestSecretHandler_WithConfig(t *testing.T) {
+	hash, err := auth.HashPassword("first-password")
+	require.NoError(t, err)
+	handler := newTestSecretHandler(t)
+	handler.Keys = auth.NewHMACKeySet("old-secret")
+	handler.Revocations = auth.NewMemoryRevocationStore()
+	handler.Users = auth.NewConfigUserStore([]cfg.User{{ID: "0", Username: "root", PasswordHash: hash, Role: "admin"}}, auth.NewMemoryUserStore())
+	require.NoError(t, handler.Users.Create(context.Background(), cfg.User{ID: "1", Username: "jane", PasswordHash: hash, Role: "developer"}))
+
+	login := func(h *SecretHandler, username string) bool {
+		resp, err := h.AuthUser(context.Background(), api.AuthUserRequestObject{Body: &api.AuthUserRequest{Username: username, Password: "first-password"}})
+		require.NoError(t, err)
+		_, ok := resp.(api.AuthUser200JSONResponse)
+		return ok
+	}
+
+	config := cfg.Config{Users: []cfg.User{{ID: "2", Username: "alice", PasswordHash: hash, Role: "admin"}}}
+	reloaded := handler.WithConfig(config, handler.Authorizer, auth.NewHMACKeySet("new-secret"))
+
+	if !login(reloaded, "alice") || login(reloaded, "root") {
+		t.Fatal("expected the reloaded handler to use the users of the new config")
+	}
+	if !login(reloaded, "jane") {
+		t.Fatal("expected managed users to carry over")
+	}
+	if !login(handler, "root") || login(handler, "alice") {
+		t.Fatal("expected the original handler to keep its config")
+	}
+	require.Same(t, handler.Revocations, reloaded.Revocations)
+
+	resp, err := reloaded.AuthUser(context.Background(), api.AuthUserRequestObject{Body: &api.AuthUserRequest{Username: "alice", Password: "first-password"}})
+	require.NoError(t, err)
+	token := resp.(api.AuthUser200JSONResponse).Token
+	require.Error(t, handler.Keys.Parse(token, &auth.Claims{}), "expected tokens to be signed with the new secret")
+	require.NoError(t, reloaded.Keys.Parse(token, &auth.Claims{}))
+}
+
+func TestReloadableHandler_ConcurrentSwap(t *testing.T) {
+	// Each config keeps the issuer and client ID of its OIDC section equal, so
+	// a response mixing two configs is detected.
+	oidcHandler := func(name string) http.Handler {
+		handler := newTestSecretHandler(t).WithConfig(cfg.Config{OIDC: cfg.OIDCConfig{Issuer: name, ClientID: name}}, nil, nil)
+		mux := chi.NewMux()
+		api.HandlerFromMux(api.NewStrictHandler(handler, nil), mux)
+		return mux
+	}
+	configs := []http.Handler{oidcHandler("a"), oidcHandler("b")}
+	reloadable := NewReloadableHandler(configs[0])
+
+	stop := make(chan struct{})
+	var swaps sync.WaitGroup
+	swaps.Add(1)
+	go func() {
+		defer swaps.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				reloadable.Swap(configs[i%2])
+			}
+		}
+	}()
+
+	var readers sync.WaitGroup
+	errs := make(chan error, 8)
+	for range 8 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for range 200 {
+				rec := httptest.NewRecorder()
+				reloadable.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/user/oidc", nil))
+				var body api.OIDCConfigResponse
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					errs <- fmt.Errorf("status %d: %w", rec.Code, err)
+					return
+				}
+				if body.Issuer != body.ClientId || (body.Issuer != "a" && body.Issuer != "b") {
+					errs <- fmt.Errorf("response mixes configs: %+v", body)
+					return
+				}
+			}
+		}()
+	}
+	readers.Wait()
+	close(stop)
+	swaps.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
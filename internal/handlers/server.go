@@ -4,25 +4,57 @@ import (
 	"log/slog"
 
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/audit"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
 	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
 	"github.com/mogilyoy/k8s-secret-manager/internal/k8s"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
 	"go.opentelemetry.io/otel/trace"
 )
 
 var _ api.StrictServerInterface = &SecretHandler{}
 
 type SecretHandler struct {
-	K8sManager k8s.SecretClaimsInterface
-	Logger     *slog.Logger
-	Tracer     trace.Tracer
-	cfg        cfg.Config
+	K8sManager  k8s.SecretClaimsInterface
+	Logger      *slog.Logger
+	Tracer      trace.Tracer
+	Audit       *audit.Logger
+	Authorizer  policy.Authorizer
+	Revocations auth.RevocationStore
+	Keys        *auth.KeySet
+	APIKeys     auth.APIKeyStore
+	Users       *auth.ConfigUserStore
+	cfg         cfg.Config
 }
 
-func NewSecretHandler(k8sMgr k8s.SecretClaimsInterface, config cfg.Config, logger *slog.Logger, tracer trace.Tracer) *SecretHandler {
+func NewSecretHandler(k8sMgr k8s.SecretClaimsInterface, config cfg.Config, authorizer policy.Authorizer, logger *slog.Logger, tracer trace.Tracer) *SecretHandler {
 	return &SecretHandler{
 		K8sManager: k8sMgr,
 		cfg:        config,
 		Logger:     logger,
 		Tracer:     tracer,
+		Audit:      audit.New(logger),
+		Authorizer: authorizer,
+		// Replaced by main when a shared store is configured.
+		Revocations: auth.NewMemoryRevocationStore(),
+		// Replaced by main when asymmetric signing keys are configured.
+		Keys: auth.NewHMACKeySet(config.JWT.Secret),
+		// Replaced by main when a shared store is configured.
+		APIKeys: auth.NewMemoryAPIKeyStore(),
+		// The store of managed users is replaced by main when a shared one is
+		// configured.
+		Users: auth.NewConfigUserStore(config.Users, auth.NewMemoryUserStore()),
 	}
 }
+
+// WithConfig returns a copy of h for a reloaded config. The copy shares the
+// Kubernetes client and the stores of h, so that revocations, API keys and
+// managed users carry over.
+func (h *SecretHandler) WithConfig(config cfg.Config, authorizer policy.Authorizer, keys *auth.KeySet) *SecretHandler {
+	next := *h
+	next.cfg = config
+	next.Authorizer = authorizer
+	next.Keys = keys
+	next.Users = h.Users.WithUsers(config.Users)
+	return &next
+}
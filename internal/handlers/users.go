@@ -0,0 +1,664 @@
+package handlers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/audit"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
+	"github.com/mogilyoy/k8s-secret-manager/internal/policy"
+	"go.opentelemetry.io/otel/codes"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Users are cluster-scoped like API keys; the policy grants verbs on the users
+// resource separately, and only the admin role has them by default. Users of
+// the config file are listed but cannot be changed through the API.
+
+// knownPermissions are the permissions users can be granted.
+var knownPermissions = []string{auth.PermissionRevealSecrets}
+
+func (h *SecretHandler) ListUsers(ctx context.Context, request api.ListUsersRequestObject) (api.ListUsersResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.ListUsers")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildListUsersErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbList, Resource: policy.ResourceUsers}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for users", slog.String("role", claims.Role))
+		return BuildListUsersErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	users, err := h.Users.List(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to list users")
+		logger.Error("Failed to list users", slog.Any("error", err))
+		return BuildListUsersErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	response := api.ListUsers200JSONResponse{Items: make([]api.UserResponse, 0, len(users))}
+	for _, user := range users {
+		response.Items = append(response.Items, h.mapUserResponse(user))
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return response, nil
+}
+
+// CreateUser adds a user that logs in with /user/auth. Like API keys, users can
+// only be created within the scope of the caller.
+func (h *SecretHandler) CreateUser(ctx context.Context, request api.CreateUserRequestObject) (api.CreateUserResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.CreateUser")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildCreateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	body := request.Body
+	event := audit.Event{
+		Action: audit.ActionCreateUser,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   body.Username,
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbCreate, Resource: policy.ResourceUsers}) {
+		event.Reason = "create on users is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for users", slog.String("role", claims.Role))
+		return BuildCreateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	user := cfg.User{
+		ID:                uuid.NewString(),
+		Username:          body.Username,
+		Role:              body.Role,
+		AllowedNamespaces: body.AllowedNamespaces,
+	}
+	if body.Permissions != nil {
+		user.Permissions = *body.Permissions
+	}
+	if body.KubernetesUser != nil {
+		user.KubernetesUser = *body.KubernetesUser
+	}
+	if body.KubernetesGroups != nil {
+		user.KubernetesGroups = *body.KubernetesGroups
+	}
+	if user.KubernetesUser == "" {
+		user.KubernetesUser = managedKubernetesUser(user.Username)
+	}
+	err = validateUsername(body.Username)
+	if err == nil {
+		err = auth.ValidatePassword(body.Password)
+	}
+	if err == nil {
+		err = h.validateUser(user)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "Wrong request format")
+		logger.Warn("Wrong request format", slog.Any("error", err))
+		return BuildCreateUserErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+
+	if reason := h.userScopeDenied(claims, user); reason != "" {
+		event.Reason = reason
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for user scope", slog.String("role", claims.Role), slog.String("reason", reason))
+		return BuildCreateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: " + reason,
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	user.PasswordHash, err = auth.HashPassword(body.Password)
+	if err == nil {
+		err = h.Users.Create(ctx, user)
+	}
+	if errors.Is(err, auth.ErrUserExists) {
+		return BuildCreateUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s already exists", user.Username),
+			ErrorCode:    "Conflict",
+			StatusCode:   409,
+		}), nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to create user")
+		logger.Error("Failed to create user", slog.Any("error", err))
+		return BuildCreateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+	logger.Info("Created user", slog.String("username", user.Username), slog.String("role", user.Role))
+	span.SetStatus(codes.Ok, "Success")
+	return api.CreateUser201JSONResponse(h.mapUserResponse(user)), nil
+}
+
+func (h *SecretHandler) GetUser(ctx context.Context, request api.GetUserRequestObject) (api.GetUserResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.GetUser")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildGetUserErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbGet, Resource: policy.ResourceUsers}) {
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for users", slog.String("role", claims.Role))
+		return BuildGetUserErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	user, err := h.Users.Get(ctx, request.Username)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		return BuildGetUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s not found", request.Username),
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get user")
+		logger.Error("Failed to get user", slog.String("username", request.Username), slog.Any("error", err))
+		return BuildGetUserErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	span.SetStatus(codes.Ok, "Success")
+	return api.GetUser200JSONResponse(h.mapUserResponse(*user)), nil
+}
+
+// UpdateUser changes the fields set in the request. Both the user before and
+// after the change must be within the scope of the caller, so that callers
+// cannot take over wider users by resetting their password. A new password
+// rejects the refresh tokens issued before.
+func (h *SecretHandler) UpdateUser(ctx context.Context, request api.UpdateUserRequestObject) (api.UpdateUserResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.UpdateUser")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event := audit.Event{
+		Action: audit.ActionUpdateUser,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   request.Username,
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbUpdate, Resource: policy.ResourceUsers}) {
+		event.Reason = "update on users is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for users", slog.String("role", claims.Role))
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if h.Users.FromConfig(request.Username) {
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s is defined in the config file and can only be changed there", request.Username),
+			ErrorCode:    "Conflict",
+			StatusCode:   409,
+		}), nil
+	}
+
+	existing, err := h.Users.Get(ctx, request.Username)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s not found", request.Username),
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get user")
+		logger.Error("Failed to get user", slog.String("username", request.Username), slog.Any("error", err))
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	body := request.Body
+	user := *existing
+	if body.Role != nil {
+		user.Role = *body.Role
+	}
+	if body.AllowedNamespaces != nil {
+		user.AllowedNamespaces = *body.AllowedNamespaces
+	}
+	if body.Permissions != nil {
+		user.Permissions = *body.Permissions
+	}
+	if body.KubernetesUser != nil {
+		user.KubernetesUser = cmp.Or(*body.KubernetesUser, managedKubernetesUser(user.Username))
+	}
+	if body.KubernetesGroups != nil {
+		user.KubernetesGroups = *body.KubernetesGroups
+	}
+	err = h.validateUser(user)
+	if err == nil && body.Password != nil {
+		err = auth.ValidatePassword(*body.Password)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "Wrong request format")
+		logger.Warn("Wrong request format", slog.Any("error", err))
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+
+	reason := h.userScopeDenied(claims, *existing)
+	if reason == "" {
+		reason = h.userScopeDenied(claims, user)
+	}
+	if reason != "" {
+		event.Reason = reason
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for user scope", slog.String("role", claims.Role), slog.String("reason", reason))
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: " + reason,
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if body.Password != nil {
+		user.PasswordHash, err = auth.HashPassword(*body.Password)
+		changedAt := time.Now().UTC()
+		user.PasswordChangedAt = &changedAt
+	}
+	if err == nil {
+		err = h.Users.Update(ctx, user)
+	}
+	if errors.Is(err, auth.ErrUserNotFound) {
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s not found", request.Username),
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to update user")
+		logger.Error("Failed to update user", slog.String("username", request.Username), slog.Any("error", err))
+		return BuildUpdateUserErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+	logger.Info("Updated user", slog.String("username", user.Username), slog.Bool("password_changed", body.Password != nil))
+	span.SetStatus(codes.Ok, "Success")
+	return api.UpdateUser200JSONResponse(h.mapUserResponse(user)), nil
+}
+
+// DeleteUser removes a user. Its access tokens stay valid until they expire,
+// its refresh tokens are rejected right away.
+func (h *SecretHandler) DeleteUser(ctx context.Context, request api.DeleteUserRequestObject) (api.DeleteUserResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.DeleteUser")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildDeleteUserErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event := audit.Event{
+		Action: audit.ActionDeleteUser,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   request.Username,
+	}
+
+	if !h.Authorizer.Authorize(ctx, claims, policy.Attributes{Verb: policy.VerbDelete, Resource: policy.ResourceUsers}) {
+		event.Reason = "delete on users is not allowed by the role"
+		h.Audit.Record(ctx, event)
+		span.SetStatus(codes.Error, "Access denied")
+		logger.Warn("Access denied for users", slog.String("role", claims.Role))
+		return BuildDeleteUserErrorResponse(ErrorResult{
+			ErrorMessage: "Access denied: insufficient role permissions",
+			ErrorCode:    "Forbidden",
+			StatusCode:   403,
+		}), nil
+	}
+
+	if h.Users.FromConfig(request.Username) {
+		return BuildDeleteUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s is defined in the config file and can only be removed there", request.Username),
+			ErrorCode:    "Conflict",
+			StatusCode:   409,
+		}), nil
+	}
+
+	user, err := h.Users.Get(ctx, request.Username)
+	if err == nil {
+		if reason := h.userScopeDenied(claims, *user); reason != "" {
+			event.Reason = reason
+			h.Audit.Record(ctx, event)
+			span.SetStatus(codes.Error, "Access denied")
+			logger.Warn("Access denied for user scope", slog.String("role", claims.Role), slog.String("reason", reason))
+			return BuildDeleteUserErrorResponse(ErrorResult{
+				ErrorMessage: "Access denied: " + reason,
+				ErrorCode:    "Forbidden",
+				StatusCode:   403,
+			}), nil
+		}
+		err = h.Users.Delete(ctx, request.Username)
+	}
+	if errors.Is(err, auth.ErrUserNotFound) {
+		return BuildDeleteUserErrorResponse(ErrorResult{
+			ErrorMessage: fmt.Sprintf("user %s not found", request.Username),
+			ErrorCode:    "NotFound",
+			StatusCode:   404,
+		}), nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to delete user")
+		logger.Error("Failed to delete user", slog.String("username", request.Username), slog.Any("error", err))
+		return BuildDeleteUserErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+	logger.Info("Deleted user", slog.String("username", request.Username))
+	span.SetStatus(codes.Ok, "Success")
+	return api.DeleteUser200JSONResponse{OkResponseJSONResponse: api.OkResponseJSONResponse{Ok: BoolPnc(true)}}, nil
+}
+
+// ChangePassword lets users managed through the API change their own
+// password. It needs no policy verb, but the current password.
+func (h *SecretHandler) ChangePassword(ctx context.Context, request api.ChangePasswordRequestObject) (api.ChangePasswordResponseObject, error) {
+	ctx, span := h.Tracer.Start(ctx, "SecretHandler.ChangePassword")
+	defer span.End()
+	logger := observability.LoggerFromContext(ctx)
+
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Cannot parse request context")
+		logger.Error("Failed to parse request context/claims", slog.Any("error", err))
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: "Cannot parse request context",
+			ErrorCode:    "InternalServerError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event := audit.Event{
+		Action: audit.ActionChangePassword,
+		Actor:  claims.Username,
+		Role:   claims.Role,
+		Name:   claims.Username,
+	}
+
+	if h.Users.FromConfig(claims.Username) {
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: "the user is defined in the config file; its password can only be changed there",
+			ErrorCode:    "Conflict",
+			StatusCode:   409,
+		}), nil
+	}
+
+	user, err := h.Users.Get(ctx, claims.Username)
+	if errors.Is(err, auth.ErrUserNotFound) || (err == nil && user.ID != claims.Subject) {
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: "only users that log in with a password can change it",
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get user")
+		logger.Error("Failed to get user", slog.String("username", claims.Username), slog.Any("error", err))
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	if !auth.CheckPasswordHash(request.Body.CurrentPassword, user.PasswordHash) {
+		event.Reason = "wrong current password"
+		h.Audit.Record(ctx, event)
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: "current password is incorrect",
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+	if err := auth.ValidatePassword(request.Body.NewPassword); err != nil {
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: err.Error(),
+			ErrorCode:    "BadRequest",
+			StatusCode:   400,
+		}), nil
+	}
+
+	user.PasswordHash, err = auth.HashPassword(request.Body.NewPassword)
+	if err == nil {
+		changedAt := time.Now().UTC()
+		user.PasswordChangedAt = &changedAt
+		err = h.Users.Update(ctx, *user)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to change password")
+		logger.Error("Failed to change password", slog.String("username", claims.Username), slog.Any("error", err))
+		return BuildChangePasswordErrorResponse(ErrorResult{
+			ErrorMessage: "Internal Server Error",
+			ErrorCode:    "InternalError",
+			StatusCode:   500,
+		}), nil
+	}
+
+	event.Allowed = true
+	h.Audit.Record(ctx, event)
+	logger.Info("Changed password", slog.String("username", claims.Username))
+	span.SetStatus(codes.Ok, "Success")
+	return api.ChangePassword200JSONResponse{OkResponseJSONResponse: api.OkResponseJSONResponse{Ok: BoolPnc(true)}}, nil
+}
+
+// validateUsername checks that username can be stored as a key of the user
+// Secret. This also keeps out the colons of apikey: and system: names.
+func validateUsername(username string) error {
+	if errs := validation.IsConfigMapKey(username); len(errs) > 0 {
+		return fmt.Errorf("invalid username %q: %s", username, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (h *SecretHandler) validateUser(user cfg.User) error {
+	if !h.roleExists(user.Role) {
+		return fmt.Errorf("unknown role %q", user.Role)
+	}
+	if len(user.AllowedNamespaces) == 0 {
+		return fmt.Errorf("allowedNamespaces are required")
+	}
+	for _, permission := range user.Permissions {
+		if !slices.Contains(knownPermissions, permission) {
+			return fmt.Errorf("unknown permission %q", permission)
+		}
+	}
+	return nil
+}
+
+// userScopeDenied explains why claims may not manage user, or returns an empty
+// string if they may. Callers limited to some verbs cannot manage users at
+// all, since a user has no such limit.
+func (h *SecretHandler) userScopeDenied(claims *auth.Claims, user cfg.User) string {
+	if len(claims.Verbs) > 0 {
+		return "the caller is limited to some verbs and cannot manage users"
+	}
+	if reason := h.roleDenied(claims, user.Role); reason != "" {
+		return reason
+	}
+	if reason := namespacesDenied(claims, user.AllowedNamespaces); reason != "" {
+		return reason
+	}
+	for _, permission := range user.Permissions {
+		if !claims.HasPermission(permission) {
+			return fmt.Sprintf("permission %s is not granted to the caller", permission)
+		}
+	}
+	return h.identityDenied(claims, user)
+}
+
+// managedUserPrefix is put in front of the username of users managed through
+// the API to get their default Kubernetes user, so that naming a user after a
+// cluster user does not make SubjectAccessReviews run as it.
+const managedUserPrefix = "ksm:"
+
+func managedKubernetesUser(username string) string {
+	return managedUserPrefix + username
+}
+
+// identityDenied explains why claims may not manage a user with the Kubernetes
+// identity of user, or returns an empty string if they may. SubjectAccessReviews
+// run as that identity, so only callers whose role grants everything the admin
+// role does, or who already have the identity themselves, may hand it out.
+func (h *SecretHandler) identityDenied(claims *auth.Claims, user cfg.User) string {
+	if policy.RoleWithin(h.cfg.Roles, "admin", claims.Role) {
+		return ""
+	}
+	// Users stored without a Kubernetes user are reviewed as their username.
+	kubernetesUser := cmp.Or(user.KubernetesUser, user.Username)
+	if kubernetesUser != managedKubernetesUser(user.Username) && kubernetesUser != cmp.Or(claims.KubernetesUser, claims.Username) {
+		return fmt.Sprintf("kubernetesUser %s is not the Kubernetes user of the caller", kubernetesUser)
+	}
+	for _, group := range user.KubernetesGroups {
+		if !slices.Contains(claims.KubernetesGroups, group) {
+			return fmt.Sprintf("kubernetesGroup %s is not a Kubernetes group of the caller", group)
+		}
+	}
+	return ""
+}
+
+func (h *SecretHandler) mapUserResponse(user cfg.User) api.UserResponse {
+	response := api.UserResponse{
+		Id:                user.ID,
+		Username:          user.Username,
+		Role:              user.Role,
+		AllowedNamespaces: user.AllowedNamespaces,
+		KubernetesUser:    optionalStr(user.KubernetesUser),
+		FromConfig:        h.Users.FromConfig(user.Username),
+	}
+	if response.AllowedNamespaces == nil {
+		response.AllowedNamespaces = []string{}
+	}
+	if len(user.Permissions) > 0 {
+		response.Permissions = &user.Permissions
+	}
+	if len(user.KubernetesGroups) > 0 {
+		response.KubernetesGroups = &user.KubernetesGroups
+	}
+	return response
+}
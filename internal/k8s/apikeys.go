@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultAPIKeySecret holds the API keys when no name is configured.
+const DefaultAPIKeySecret = "secret-manager-api-keys"
+
+var _ auth.APIKeyStore = &SecretAPIKeyStore{}
+
+// SecretAPIKeyStore keeps API keys in a Secret, one JSON encoded auth.APIKey
+// per key ID, so that every API server replica sees them. A replica may keep
+// accepting a key deleted through another one for recordSyncInterval.
+type SecretAPIKeyStore struct {
+	records *secretRecords[auth.APIKey]
+}
+
+func NewSecretAPIKeyStore(c client.Client, namespace, name string) *SecretAPIKeyStore {
+	if name == "" {
+		name = DefaultAPIKeySecret
+	}
+	return &SecretAPIKeyStore{records: newSecretRecords[auth.APIKey](c, namespace, name)}
+}
+
+// Create adds key to the Secret, creating the Secret if needed.
+func (s *SecretAPIKeyStore) Create(ctx context.Context, key auth.APIKey) error {
+	err := s.records.create(ctx, key.ID, key)
+	if errors.Is(err, errRecordExists) {
+		return fmt.Errorf("api key %s already exists", key.ID)
+	}
+	return err
+}
+
+func (s *SecretAPIKeyStore) Get(ctx context.Context, id string) (*auth.APIKey, error) {
+	key, err := s.records.get(ctx, id)
+	if errors.Is(err, errRecordNotFound) {
+		return nil, auth.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List always reads the Secret, so that listings are not stale.
+func (s *SecretAPIKeyStore) List(ctx context.Context) ([]auth.APIKey, error) {
+	keys, err := s.records.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(keys, func(a, b auth.APIKey) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return keys, nil
+}
+
+func (s *SecretAPIKeyStore) Delete(ctx context.Context, id string) error {
+	err := s.records.delete(ctx, id)
+	if errors.Is(err, errRecordNotFound) {
+		return auth.ErrAPIKeyNotFound
+	}
+	return err
+}
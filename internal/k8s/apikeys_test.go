@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretAPIKeyStore(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	now := time.Now()
+	replicaA := NewSecretAPIKeyStore(cl, "system", "")
+	replicaB := NewSecretAPIKeyStore(cl, "system", "")
+	replicaA.records.now = func() time.Time { return now }
+	replicaB.records.now = func() time.Time { return now }
+
+	_, err := replicaB.Get(ctx, "k1")
+	require.ErrorIs(t, err, auth.ErrAPIKeyNotFound)
+
+	require.NoError(t, replicaA.Create(ctx, auth.APIKey{ID: "k1", Name: "ci", Hash: "h1", Role: "operator", CreatedAt: now}))
+	require.NoError(t, replicaA.Create(ctx, auth.APIKey{ID: "k2", Name: "backup", Hash: "h2", Role: "developer", CreatedAt: now.Add(time.Second)}))
+	require.Error(t, replicaA.Create(ctx, auth.APIKey{ID: "k1"}))
+
+	key, err := replicaA.Get(ctx, "k1")
+	require.NoError(t, err)
+	require.Equal(t, "h1", key.Hash)
+
+	// The other replica sees the key once its copy is refreshed, and listings
+	// are never stale.
+	_, err = replicaB.Get(ctx, "k1")
+	require.ErrorIs(t, err, auth.ErrAPIKeyNotFound)
+	keys, err := replicaB.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, "ci", keys[0].Name)
+
+	require.NoError(t, replicaB.Delete(ctx, "k1"))
+	require.ErrorIs(t, replicaB.Delete(ctx, "k1"), auth.ErrAPIKeyNotFound)
+	now = now.Add(2 * recordSyncInterval)
+	_, err = replicaA.Get(ctx, "k1")
+	require.ErrorIs(t, err, auth.ErrAPIKeyNotFound)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "system", Name: DefaultAPIKeySecret}, secret))
+	require.Len(t, secret.Data, 1)
+	require.Contains(t, secret.Data, "k2")
+}
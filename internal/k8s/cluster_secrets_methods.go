@@ -0,0 +1,133 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (m *K8sDynamicClient) CreateClusterSecretClaim(ctx context.Context, name, claimType string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error {
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.CreateClusterSecretClaim")
+	defer span.End()
+	m.Logger.Debug("K8s: attempting to create ClusterSecretClaim",
+		slog.String("name", name),
+		slog.String("type", claimType))
+
+	spec, err := m.newSecretClaimSpec(span, name, "", claimType, data, encryptedData, generationConfig, rotation, templates, deletionPolicy, secretType, dockerConfig, certificate, source, targets, push)
+	if err != nil {
+		return err
+	}
+	labelsToSet, annotationsToSet := m.newClaimMetadata(ctx, span, labels, annotations)
+
+	claim := &secretsv1alpha1.ClusterSecretClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labelsToSet,
+			Annotations: annotationsToSet,
+		},
+		Spec: spec,
+	}
+	if err := m.Client.Create(ctx, claim); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to create ClusterSecretClaim")
+		m.Logger.Error("K8s: failed to create ClusterSecretClaim", slog.String("name", name), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to create ClusterSecretClaim %s: %w", name, err)
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return nil
+}
+
+func (m *K8sDynamicClient) UpdateClusterSecretClaim(ctx context.Context, name, claimType string, regenerate bool, regenerateKeys *[]string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error {
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.UpdateClusterSecretClaim")
+	defer span.End()
+	m.Logger.Debug("K8s: attempting to update ClusterSecretClaim",
+		slog.String("name", name),
+		slog.String("type", claimType),
+		slog.Bool("regenerate", regenerate))
+
+	existingClaim, err := m.GetClusterSecretClaim(ctx, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to read ClusterSecretClaim before update")
+		m.Logger.Error("K8s: failed to read ClusterSecretClaim before update", slog.String("name", name), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to read ClusterSecretClaim %s before update: %w", name, err)
+	}
+
+	if err := m.updateSecretClaimSpec(span, &existingClaim.Spec, name, "", claimType, regenerate, regenerateKeys, data, encryptedData, generationConfig, rotation, templates, deletionPolicy, secretType, dockerConfig, certificate, source, targets, push); err != nil {
+		return err
+	}
+	m.updateClaimMetadata(ctx, span, existingClaim, labels, annotations)
+
+	if err := m.Client.Update(ctx, existingClaim); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to update ClusterSecretClaim")
+		m.Logger.Error("K8s: failed to update ClusterSecretClaim", slog.String("name", name), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to update ClusterSecretClaim %s: %w", name, err)
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return nil
+}
+
+func (m *K8sDynamicClient) DeleteClusterSecretClaim(ctx context.Context, name string) error {
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.DeleteClusterSecretClaim")
+	defer span.End()
+	m.Logger.Debug("K8s: attempting to delete ClusterSecretClaim",
+		slog.String("name", name))
+
+	claim := &secretsv1alpha1.ClusterSecretClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	if err := m.Client.Delete(ctx, claim); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Failed to delete ClusterSecretClaim")
+			m.Logger.Error("K8s: failed to delete ClusterSecretClaim", slog.String("name", name), slog.String("error", err.Error()))
+			return fmt.Errorf("failed to delete ClusterSecretClaim %s: %w", name, err)
+		}
+		m.Logger.Info("K8s: failed to delete ClusterSecretClaim", slog.String("error", err.Error()))
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return nil
+}
+
+func (m *K8sDynamicClient) GetClusterSecretClaim(ctx context.Context, name string) (*secretsv1alpha1.ClusterSecretClaim, error) {
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.GetClusterSecretClaim")
+	defer span.End()
+	m.Logger.Debug("K8s: attempting to get ClusterSecretClaim",
+		slog.String("name", name))
+
+	claim := &secretsv1alpha1.ClusterSecretClaim{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: name}, claim); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get ClusterSecretClaim")
+		m.Logger.Error("K8s: failed to get ClusterSecretClaim", slog.String("name", name), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get ClusterSecretClaim %s: %w", name, err)
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return claim, nil
+}
+
+func (m *K8sDynamicClient) ListClusterSecretClaim(ctx context.Context) (*secretsv1alpha1.ClusterSecretClaimList, error) {
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.ListClusterSecretClaim")
+	defer span.End()
+	m.Logger.Debug("K8s: attempting to list ClusterSecretClaims")
+
+	claimList := &secretsv1alpha1.ClusterSecretClaimList{}
+	if err := m.Client.List(ctx, claimList); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to list ClusterSecretClaims")
+		m.Logger.Error("K8s: failed to list ClusterSecretClaims", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list ClusterSecretClaims: %w", err)
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return claimList, nil
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCreateClusterSecretClaim_Success(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "shared-token"
+	keys := []string{"token"}
+	encoding := api.Alphanumeric
+	genCfg := &api.GenerationConfig{Length: 32, Encoding: &encoding, DataKeys: &keys}
+	selector := map[string]string{"shared-credentials": "true"}
+	targets := &api.TargetsConfig{NamespaceSelector: &selector}
+
+	err := k.CreateClusterSecretClaim(ctx, name, "AutoGenerated", nil, nil, genCfg, nil, nil, nil, nil, nil, nil, nil, targets, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateClusterSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.ClusterSecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Name: name}, &got); err != nil {
+		t.Fatalf("getting ClusterSecretClaim failed: %v", err)
+	}
+	if got.Spec.Type != "AutoGenerated" {
+		t.Errorf("Spec.Type = %v, want AutoGenerated", got.Spec.Type)
+	}
+	if got.Spec.Targets == nil || got.Spec.Targets.NamespaceSelector == nil {
+		t.Fatalf("Spec.Targets.NamespaceSelector is nil, want %v", selector)
+	}
+	if !reflect.DeepEqual(got.Spec.Targets.NamespaceSelector.MatchLabels, selector) {
+		t.Errorf("NamespaceSelector = %v, want %v", got.Spec.Targets.NamespaceSelector.MatchLabels, selector)
+	}
+}
+
+func TestUpdateClusterSecretClaim_Targets(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "shared-opaque"
+	data := map[string]string{"user": "svc"}
+	namespaces := []string{"team-a"}
+
+	err := k.CreateClusterSecretClaim(ctx, name, "Opaque", &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, &api.TargetsConfig{Namespaces: &namespaces}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("CreateClusterSecretClaim error: %v", err)
+	}
+
+	updated := []string{"team-a", "team-b"}
+	err = k.UpdateClusterSecretClaim(ctx, name, "Opaque", false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &api.TargetsConfig{Namespaces: &updated}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("UpdateClusterSecretClaim error: %v", err)
+	}
+
+	got, err := k.GetClusterSecretClaim(ctx, name)
+	if err != nil {
+		t.Fatalf("GetClusterSecretClaim error: %v", err)
+	}
+	if got.Spec.Targets == nil || !reflect.DeepEqual(got.Spec.Targets.Namespaces, updated) {
+		t.Errorf("Spec.Targets = %v, want namespaces %v", got.Spec.Targets, updated)
+	}
+	if !reflect.DeepEqual(got.Spec.Data, data) {
+		t.Errorf("Spec.Data = %v, want %v", got.Spec.Data, data)
+	}
+}
+
+func TestDeleteClusterSecretClaim_Success(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	namespaces := []string{"team-a"}
+	data := map[string]string{"user": "svc"}
+	if err := k.CreateClusterSecretClaim(ctx, "to-delete", "Opaque", &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, &api.TargetsConfig{Namespaces: &namespaces}, nil, nil, nil); err != nil {
+		t.Fatalf("CreateClusterSecretClaim error: %v", err)
+	}
+
+	if err := k.DeleteClusterSecretClaim(ctx, "to-delete"); err != nil {
+		t.Fatalf("DeleteClusterSecretClaim error: %v", err)
+	}
+	var got secretsv1alpha1.ClusterSecretClaim
+	err := k.Client.Get(ctx, types.NamespacedName{Name: "to-delete"}, &got)
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+
+	list, err := k.ListClusterSecretClaim(ctx)
+	if err != nil {
+		t.Fatalf("ListClusterSecretClaim error: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("len(Items) = %d, want 0", len(list.Items))
+	}
+}
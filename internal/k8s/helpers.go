@@ -0,0 +1,214 @@
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// toRotationConfig converts the API rotation settings into the CRD form.
+// An empty object yields nil, which disables rotation.
+func toRotationConfig(rotation *api.RotationConfig) (*secretsv1alpha1.RotationConfig, error) {
+	if rotation == nil {
+		return nil, nil
+	}
+
+	result := &secretsv1alpha1.RotationConfig{}
+	if rotation.Schedule != nil {
+		result.Schedule = *rotation.Schedule
+	}
+	if rotation.Interval != nil && *rotation.Interval != "" {
+		interval, err := time.ParseDuration(*rotation.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rotation interval %q: %w", *rotation.Interval, err)
+		}
+		result.Interval = &metav1.Duration{Duration: interval}
+	}
+
+	if result.Schedule == "" && result.Interval == nil {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// toDockerConfig converts the API registry credentials into the CRD form.
+func toDockerConfig(docker *api.DockerConfig) *secretsv1alpha1.DockerConfig {
+	if docker == nil {
+		return nil
+	}
+
+	return &secretsv1alpha1.DockerConfig{
+		Registry: docker.Registry,
+		Username: docker.Username,
+	}
+}
+
+// toTargetsConfig converts the API replication targets into the CRD form. Targets
+// without namespaces and selector convert to nil, which stops replication.
+func toTargetsConfig(targets *api.TargetsConfig) *secretsv1alpha1.TargetsConfig {
+	if targets == nil {
+		return nil
+	}
+
+	result := &secretsv1alpha1.TargetsConfig{}
+	if targets.Namespaces != nil {
+		result.Namespaces = *targets.Namespaces
+	}
+	if targets.NamespaceSelector != nil {
+		result.NamespaceSelector = &metav1.LabelSelector{MatchLabels: *targets.NamespaceSelector}
+	}
+	if len(result.Namespaces) == 0 && result.NamespaceSelector == nil {
+		return nil
+	}
+	return result
+}
+
+// toPushConfig converts the API push settings into the CRD form. Settings without
+// a path convert to nil, which stops pushing.
+func toPushConfig(push *api.PushConfig) *secretsv1alpha1.PushConfig {
+	if push == nil || push.Path == "" {
+		return nil
+	}
+
+	result := &secretsv1alpha1.PushConfig{
+		Provider: string(push.Provider),
+		Path:     push.Path,
+	}
+	if push.Address != nil {
+		result.Address = *push.Address
+	}
+	if push.Mount != nil {
+		result.Mount = *push.Mount
+	}
+	if push.CredentialsSecretRef != nil {
+		result.CredentialsSecretRef = *push.CredentialsSecretRef
+	}
+	if push.Keys != nil && len(*push.Keys) > 0 {
+		result.Keys = *push.Keys
+	}
+	if push.ConflictPolicy != nil {
+		result.ConflictPolicy = string(*push.ConflictPolicy)
+	}
+	return result
+}
+
+// toCertificateConfig converts the API certificate settings into the CRD form.
+func toCertificateConfig(certificate *api.CertificateConfig) (*secretsv1alpha1.CertificateConfig, error) {
+	if certificate == nil {
+		return nil, nil
+	}
+
+	result := &secretsv1alpha1.CertificateConfig{}
+	if certificate.CommonName != nil {
+		result.CommonName = *certificate.CommonName
+	}
+	if certificate.DnsNames != nil {
+		result.DNSNames = *certificate.DnsNames
+	}
+	if certificate.IpAddresses != nil {
+		result.IPAddresses = *certificate.IpAddresses
+	}
+	if certificate.KeyAlgorithm != nil {
+		result.KeyAlgorithm = string(*certificate.KeyAlgorithm)
+	}
+	if certificate.KeySize != nil {
+		result.KeySize = *certificate.KeySize
+	}
+	if certificate.IsCA != nil {
+		result.IsCA = *certificate.IsCA
+	}
+	if certificate.IssuerRef != nil {
+		result.IssuerRef = *certificate.IssuerRef
+	}
+	if certificate.Duration != nil && *certificate.Duration != "" {
+		duration, err := time.ParseDuration(*certificate.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate duration %q: %w", *certificate.Duration, err)
+		}
+		result.Duration = &metav1.Duration{Duration: duration}
+	}
+	if certificate.RenewBefore != nil && *certificate.RenewBefore != "" {
+		renewBefore, err := time.ParseDuration(*certificate.RenewBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate renewBefore %q: %w", *certificate.RenewBefore, err)
+		}
+		result.RenewBefore = &metav1.Duration{Duration: renewBefore}
+	}
+	return result, nil
+}
+
+// toSourceConfig converts the API source settings into the CRD form.
+func toSourceConfig(source *api.SourceConfig) (*secretsv1alpha1.SourceConfig, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	result := &secretsv1alpha1.SourceConfig{
+		Provider: string(source.Provider),
+		Path:     source.Path,
+	}
+	if source.Address != nil {
+		result.Address = *source.Address
+	}
+	if source.Mount != nil {
+		result.Mount = *source.Mount
+	}
+	if source.CredentialsSecretRef != nil {
+		result.CredentialsSecretRef = *source.CredentialsSecretRef
+	}
+	if source.Keys != nil && len(*source.Keys) > 0 {
+		result.Keys = *source.Keys
+	}
+	if source.RefreshInterval != nil && *source.RefreshInterval != "" {
+		interval, err := time.ParseDuration(*source.RefreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source refreshInterval %q: %w", *source.RefreshInterval, err)
+		}
+		result.RefreshInterval = &metav1.Duration{Duration: interval}
+	}
+	return result, nil
+}
+
+// toKeyGenerations converts the API per-key generation settings into the CRD form.
+func toKeyGenerations(keys *map[string]api.KeyGeneration) map[string]secretsv1alpha1.KeyGeneration {
+	if keys == nil || len(*keys) == 0 {
+		return nil
+	}
+
+	result := make(map[string]secretsv1alpha1.KeyGeneration, len(*keys))
+	for key, cfg := range *keys {
+		var gen secretsv1alpha1.KeyGeneration
+		if cfg.Format != nil {
+			gen.Format = string(*cfg.Format)
+		}
+		if cfg.Bits != nil {
+			gen.Bits = *cfg.Bits
+		}
+		if cfg.Length != nil {
+			gen.Length = *cfg.Length
+		}
+		if cfg.Charset != nil {
+			gen.Charset = *cfg.Charset
+		}
+		if cfg.MinDigits != nil {
+			gen.MinDigits = *cfg.MinDigits
+		}
+		if cfg.MinSymbols != nil {
+			gen.MinSymbols = *cfg.MinSymbols
+		}
+		if cfg.MinUppercase != nil {
+			gen.MinUppercase = *cfg.MinUppercase
+		}
+		if cfg.ExcludeAmbiguous != nil {
+			gen.ExcludeAmbiguous = *cfg.ExcludeAmbiguous
+		}
+		if cfg.Prefix != nil {
+			gen.Prefix = *cfg.Prefix
+		}
+		result[key] = gen
+	}
+	return result
+}
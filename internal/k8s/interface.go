@@ -17,18 +17,28 @@ import (
 )
 
 type SecretClaimsInterface interface {
-	CreateSecretClaim(ctx context.Context, name, namespace, claimType string, data *map[string]string, generationConfig *api.GenerationConfig, labels *map[string]string, annotations *map[string]string) error
+	CreateSecretClaim(ctx context.Context, name, namespace, claimType string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error
 	GetSecretClaim(ctx context.Context, name, namespace string) (*secretsv1alpha1.SecretClaim, error)
 	GetActualSecret(ctx context.Context, name, namespace string) (*corev1.Secret, error)
 	ListSecretClaim(ctx context.Context, namespace string) (*secretsv1alpha1.SecretClaimList, error)
-	UpdateSecretClaim(ctx context.Context, name, namespace, claimType string, regenerate bool, data *map[string]string, generationConfig *api.GenerationConfig, labels *map[string]string, annotations *map[string]string) error
+	UpdateSecretClaim(ctx context.Context, name, namespace, claimType string, regenerate bool, regenerateKeys *[]string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error
 	DeleteSecretClaim(ctx context.Context, name, namespace string) error
+	CreateClusterSecretClaim(ctx context.Context, name, claimType string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error
+	GetClusterSecretClaim(ctx context.Context, name string) (*secretsv1alpha1.ClusterSecretClaim, error)
+	ListClusterSecretClaim(ctx context.Context) (*secretsv1alpha1.ClusterSecretClaimList, error)
+	UpdateClusterSecretClaim(ctx context.Context, name, claimType string, regenerate bool, regenerateKeys *[]string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error
+	DeleteClusterSecretClaim(ctx context.Context, name string) error
+	GetSealingPublicKey(ctx context.Context, namespace string) (*corev1.ConfigMap, error)
 }
 
 type K8sDynamicClient struct {
 	Client client.Client
 	Logger *slog.Logger
 	Tracer trace.Tracer
+
+	// HashOpaqueData makes SecretClaims keep only hashes of Opaque data. The
+	// values are written straight to the claim's Secret.
+	HashOpaqueData bool
 }
 
 func NewK8sSecretManager(logger *slog.Logger, tp *sdktrace.TracerProvider) (*K8sDynamicClient, error) {
@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultRevocationConfigMap holds the revoked token IDs when no name is
+// configured.
+const DefaultRevocationConfigMap = "secret-manager-revoked-tokens"
+
+// revocationSyncInterval is how long a replica may miss a revocation made by
+// another one.
+const revocationSyncInterval = 10 * time.Second
+
+var _ auth.RevocationStore = &ConfigMapRevocationStore{}
+
+// ConfigMapRevocationStore keeps revoked token IDs in a ConfigMap, mapped to
+// the RFC 3339 expiry of the token, so that every API server replica sees
+// them. Reads are served from a copy that is refreshed every
+// revocationSyncInterval.
+type ConfigMapRevocationStore struct {
+	client client.Client
+	key    client.ObjectKey
+	now    func() time.Time
+
+	mu       sync.Mutex
+	revoked  map[string]time.Time
+	lastSync time.Time
+}
+
+func NewConfigMapRevocationStore(c client.Client, namespace, name string) *ConfigMapRevocationStore {
+	if name == "" {
+		name = DefaultRevocationConfigMap
+	}
+	return &ConfigMapRevocationStore{
+		client:  c,
+		key:     client.ObjectKey{Namespace: namespace, Name: name},
+		now:     time.Now,
+		revoked: make(map[string]time.Time),
+	}
+}
+
+// Revoke adds id to the ConfigMap, creating it if needed, and drops the
+// entries of tokens that have expired. Writes are conditional on the
+// resourceVersion read, so a concurrent revocation of id makes the write fail
+// and the retry see id, and return auth.ErrAlreadyRevoked.
+func (s *ConfigMapRevocationStore) Revoke(ctx context.Context, id string, expiresAt time.Time) error {
+	retriable := func(err error) bool { return k8serrors.IsConflict(err) || k8serrors.IsAlreadyExists(err) }
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		cm := &corev1.ConfigMap{}
+		err := s.client.Get(ctx, s.key, cm)
+		if k8serrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: s.key.Name, Namespace: s.key.Namespace},
+				Data:       map[string]string{id: expiresAt.UTC().Format(time.RFC3339)},
+			}
+			return s.client.Create(ctx, cm)
+		}
+		if err != nil {
+			return err
+		}
+
+		now := s.now()
+		for k, v := range cm.Data {
+			if exp, err := time.Parse(time.RFC3339, v); err != nil || !now.Before(exp) {
+				delete(cm.Data, k)
+			}
+		}
+		if _, ok := cm.Data[id]; ok {
+			return auth.ErrAlreadyRevoked
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[id] = expiresAt.UTC().Format(time.RFC3339)
+		return s.client.Update(ctx, cm)
+	})
+	if err != nil && !errors.Is(err, auth.ErrAlreadyRevoked) {
+		return fmt.Errorf("failed to revoke token %s: %w", id, err)
+	}
+
+	s.mu.Lock()
+	s.revoked[id] = expiresAt
+	s.mu.Unlock()
+	return err
+}
+
+func (s *ConfigMapRevocationStore) IsRevoked(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSync) >= revocationSyncInterval {
+		cm := &corev1.ConfigMap{}
+		err := s.client.Get(ctx, s.key, cm)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to read revoked tokens: %w", err)
+		}
+		revoked := make(map[string]time.Time, len(cm.Data))
+		for k, v := range cm.Data {
+			if exp, err := time.Parse(time.RFC3339, v); err == nil {
+				revoked[k] = exp
+			}
+		}
+		s.revoked = revoked
+		s.lastSync = now
+	}
+
+	exp, ok := s.revoked[id]
+	return ok && now.Before(exp), nil
+}
@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestConfigMapRevocationStore(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	now := time.Now()
+	replicaA := NewConfigMapRevocationStore(cl, "system", "")
+	replicaB := NewConfigMapRevocationStore(cl, "system", "")
+	replicaA.now = func() time.Time { return now }
+	replicaB.now = func() time.Time { return now }
+
+	revoked, err := replicaB.IsRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	require.NoError(t, replicaA.Revoke(ctx, "jti-1", now.Add(time.Hour)))
+	require.NoError(t, replicaA.Revoke(ctx, "jti-old", now.Add(time.Minute)))
+
+	revoked, err = replicaA.IsRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// The other replica sees the revocation once its copy is refreshed.
+	revoked, err = replicaB.IsRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	require.False(t, revoked)
+	now = now.Add(2 * revocationSyncInterval)
+	revoked, err = replicaB.IsRevoked(ctx, "jti-1")
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// Expired entries are dropped on the next revocation.
+	now = now.Add(time.Minute)
+	require.NoError(t, replicaA.Revoke(ctx, "jti-2", now.Add(time.Hour)))
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "system", Name: DefaultRevocationConfigMap}, cm))
+	require.Len(t, cm.Data, 2)
+	require.NotContains(t, cm.Data, "jti-old")
+
+	// Revoking again fails, also on a replica whose copy is stale.
+	require.ErrorIs(t, replicaB.Revoke(ctx, "jti-2", now.Add(time.Hour)), auth.ErrAlreadyRevoked)
+}
+
+func TestConfigMapRevocationStore_ConcurrentRevoke(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	other := NewConfigMapRevocationStore(cl, "system", "")
+	require.NoError(t, other.Revoke(ctx, "jti-0", time.Now().Add(time.Hour)))
+
+	// Another replica revokes the same token between the read and the write.
+	raced := false
+	racing := interceptor.NewClient(cl.(client.WithWatch), interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if !raced {
+				raced = true
+				require.NoError(t, other.Revoke(ctx, "jti-1", time.Now().Add(time.Hour)))
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	})
+	store := NewConfigMapRevocationStore(racing, "system", "")
+	require.ErrorIs(t, store.Revoke(ctx, "jti-1", time.Now().Add(time.Hour)), auth.ErrAlreadyRevoked)
+}
@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// GetSealingPublicKey returns the ConfigMap the controller publishes the active
+// sealing public key to.
+func (m *K8sDynamicClient) GetSealingPublicKey(ctx context.Context, namespace string) (*corev1.ConfigMap, error) {
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.GetSealingPublicKey")
+	defer span.End()
+	m.Logger.Debug("K8s: attempting to get sealing public key",
+		slog.String("namespace", namespace))
+
+	span.SetAttributes(semconv.K8SNamespaceName(namespace))
+
+	cm := &corev1.ConfigMap{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretsv1alpha1.SealingPublicKeyConfigMap}, cm); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get sealing public key")
+		m.Logger.Error("K8s: failed to get sealing public key", slog.String("namespace", namespace), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get sealing public key in namespace %s: %w", namespace, err)
+	}
+	span.SetStatus(codes.Ok, "Success")
+	return cm, nil
+}
@@ -0,0 +1,200 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recordSyncInterval is how long a replica may serve records from its copy
+// after they were changed through another one.
+const recordSyncInterval = 10 * time.Second
+
+// recordMissSyncInterval limits how often a lookup of an unknown key reads the
+// Secret, so that records created through another replica are found quickly
+// without letting unknown keys hammer the API server.
+const recordMissSyncInterval = time.Second
+
+var (
+	errRecordExists   = errors.New("record already exists")
+	errRecordNotFound = errors.New("record not found")
+)
+
+// secretRecords keeps JSON encoded records of type T in the data of a Secret,
+// one per key, so that every API server replica sees them. Reads are served
+// from a copy that is refreshed every recordSyncInterval.
+type secretRecords[T any] struct {
+	client client.Client
+	key    client.ObjectKey
+	now    func() time.Time
+
+	mu       sync.Mutex
+	records  map[string]T
+	lastSync time.Time
+}
+
+func newSecretRecords[T any](c client.Client, namespace, name string) *secretRecords[T] {
+	return &secretRecords[T]{
+		client:  c,
+		key:     client.ObjectKey{Namespace: namespace, Name: name},
+		now:     time.Now,
+		records: make(map[string]T),
+	}
+}
+
+// get returns the record stored under key, or errRecordNotFound.
+func (r *secretRecords[T]) get(ctx context.Context, key string) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var zero T
+	if err := r.syncLocked(ctx, recordSyncInterval); err != nil {
+		return zero, err
+	}
+	record, ok := r.records[key]
+	if !ok {
+		if err := r.syncLocked(ctx, recordMissSyncInterval); err != nil {
+			return zero, err
+		}
+		if record, ok = r.records[key]; !ok {
+			return zero, errRecordNotFound
+		}
+	}
+	return record, nil
+}
+
+// list always reads the Secret, so that listings are not stale.
+func (r *secretRecords[T]) list(ctx context.Context) ([]T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.syncLocked(ctx, 0); err != nil {
+		return nil, err
+	}
+	records := make([]T, 0, len(r.records))
+	for _, record := range r.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// create adds record under key, creating the Secret if needed. It returns
+// errRecordExists if the key is taken.
+func (r *secretRecords[T]) create(ctx context.Context, key string, record T) error {
+	return r.write(ctx, key, record, false)
+}
+
+// update replaces the record under key. It returns errRecordNotFound if there
+// is none.
+func (r *secretRecords[T]) update(ctx context.Context, key string, record T) error {
+	return r.write(ctx, key, record, true)
+}
+
+func (r *secretRecords[T]) write(ctx context.Context, key string, record T, replace bool) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		err := r.client.Get(ctx, r.key, secret)
+		if k8serrors.IsNotFound(err) {
+			if replace {
+				return errRecordNotFound
+			}
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: r.key.Name, Namespace: r.key.Namespace},
+				Type:       corev1.SecretTypeOpaque,
+				Data:       map[string][]byte{key: data},
+			}
+			return r.client.Create(ctx, secret)
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := secret.Data[key]; ok != replace {
+			if replace {
+				return errRecordNotFound
+			}
+			return errRecordExists
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[key] = data
+		return r.client.Update(ctx, secret)
+	})
+	if errors.Is(err, errRecordExists) || errors.Is(err, errRecordNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+
+	r.mu.Lock()
+	r.records[key] = record
+	r.mu.Unlock()
+	return nil
+}
+
+// delete removes the record under key. It returns errRecordNotFound if there
+// is none.
+func (r *secretRecords[T]) delete(ctx context.Context, key string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, r.key, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return errRecordNotFound
+			}
+			return err
+		}
+		if _, ok := secret.Data[key]; !ok {
+			return errRecordNotFound
+		}
+		delete(secret.Data, key)
+		return r.client.Update(ctx, secret)
+	})
+	if errors.Is(err, errRecordNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+
+	r.mu.Lock()
+	delete(r.records, key)
+	r.mu.Unlock()
+	return nil
+}
+
+// syncLocked refreshes the copy of the records when it is older than maxAge.
+// Entries that cannot be decoded are skipped.
+func (r *secretRecords[T]) syncLocked(ctx context.Context, maxAge time.Duration) error {
+	now := r.now()
+	if maxAge > 0 && now.Sub(r.lastSync) < maxAge {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, r.key, secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to read secret %s: %w", r.key, err)
+	}
+	records := make(map[string]T, len(secret.Data))
+	for key, data := range secret.Data {
+		var record T
+		if err := json.Unmarshal(data, &record); err == nil {
+			records[key] = record
+		}
+	}
+	r.records = records
+	r.lastSync = now
+	return nil
+}
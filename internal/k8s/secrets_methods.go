@@ -6,22 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
 	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/datahash"
 	"github.com/mogilyoy/k8s-secret-manager/internal/observability"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (m *K8sDynamicClient) CreateSecretClaim(ctx context.Context, name, namespace, claimType string, data *map[string]string, generationConfig *api.GenerationConfig, labels *map[string]string, annotations *map[string]string) error {
+func (m *K8sDynamicClient) CreateSecretClaim(ctx context.Context, name, namespace, claimType string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error {
 
 	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.CreateSecretClaim")
 	defer span.End()
@@ -31,67 +36,11 @@ func (m *K8sDynamicClient) CreateSecretClaim(ctx context.Context, name, namespac
 		slog.String("type", claimType))
 	span.SetAttributes(semconv.K8SNamespaceName(namespace))
 
-	spec := secretsv1alpha1.SecretClaimSpec{
-		Type: claimType,
-	}
-
-	if data != nil {
-		spec.Data = *data
-	}
-
-	switch claimType {
-	case "AutoGenerated":
-		if int(generationConfig.Length) < 8 || (generationConfig.DataKeys == nil || len(*generationConfig.DataKeys) == 0) || generationConfig.Encoding == nil {
-			span.RecordError(errors.New("invalid generationConfig"))
-			span.SetStatus(codes.Error, "Invalid generationConfig")
-			m.Logger.Error("K8s: invalid generationConfig", slog.String("namespace", namespace), slog.String("name", name), slog.Int("length", int(generationConfig.Length)))
-			return fmt.Errorf("invalid generationConfig")
-		}
-
-		var dataKeys []string
-		if len(*generationConfig.DataKeys) == 1 {
-			jsonKeys := (*generationConfig.DataKeys)[0]
-			if err := json.Unmarshal([]byte(jsonKeys), &dataKeys); err == nil {
-				m.Logger.Debug("Parsed JSON DataKeys", slog.Any("keys", dataKeys))
-			} else {
-				dataKeys = strings.Split(jsonKeys, ",")
-				for i, key := range dataKeys {
-					dataKeys[i] = strings.TrimSpace(key)
-				}
-			}
-		} else {
-			dataKeys = *generationConfig.DataKeys
-		}
-
-		spec.Generation = &secretsv1alpha1.GenerationConfig{
-			Length:           int(generationConfig.Length),
-			Encoding:         string(*generationConfig.Encoding),
-			DataKeys:         dataKeys,
-			ReconcileTrigger: uuid.NewString(),
-		}
-
-		spec.Data = nil
-
-	case "Opaque":
-		spec.Generation = nil
-	}
-
-	labelsToSet := map[string]string{}
-	if labels != nil {
-		labelsToSet = *labels
-	}
-
-	annotationsToSet := map[string]string{}
-	if annotations != nil {
-		annotationsToSet = *annotations
-	}
-
-	traceparent := observability.GetTraceParentHeader(ctx)
-	if traceparent != "" {
-		annotationsToSet[observability.K8sTraceparentAnnotationKey] = traceparent
-		span.SetAttributes(attribute.String("traceparent.propagation", traceparent))
-		m.Logger.Debug("Propagating traceparent to SecretClaim annotations", slog.String("traceparent", traceparent))
+	spec, err := m.newSecretClaimSpec(span, name, namespace, claimType, data, encryptedData, generationConfig, rotation, templates, deletionPolicy, secretType, dockerConfig, certificate, source, targets, push)
+	if err != nil {
+		return err
 	}
+	labelsToSet, annotationsToSet := m.newClaimMetadata(ctx, span, labels, annotations)
 
 	claim := &secretsv1alpha1.SecretClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -102,17 +51,32 @@ func (m *K8sDynamicClient) CreateSecretClaim(ctx context.Context, name, namespac
 		},
 		Spec: spec,
 	}
+	values, err := m.hashOpaqueData(&claim.Spec)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to hash data")
+		return err
+	}
 	if err := m.Client.Create(ctx, claim); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to create SecretClaim")
 		m.Logger.Error("K8s: failed to create SecretClaim", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
 		return fmt.Errorf("failed to create SecretClaim %s in namespace %s: %w", name, namespace, err)
 	}
+	if err := m.writeHashedData(ctx, claim, values); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to write hashed data")
+		// Without its values the claim would wait for them forever.
+		if deleteErr := m.Client.Delete(ctx, claim); client.IgnoreNotFound(deleteErr) != nil {
+			m.Logger.Error("K8s: failed to delete SecretClaim without data", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", deleteErr.Error()))
+		}
+		return err
+	}
 	span.SetStatus(codes.Ok, "Success")
 	return nil
 }
 
-func (m *K8sDynamicClient) UpdateSecretClaim(ctx context.Context, name, namespace, claimType string, regenerate bool, data *map[string]string, generationConfig *api.GenerationConfig, labels *map[string]string, annotations *map[string]string) error {
+func (m *K8sDynamicClient) UpdateSecretClaim(ctx context.Context, name, namespace, claimType string, regenerate bool, regenerateKeys *[]string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig, labels *map[string]string, annotations *map[string]string) error {
 	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.UpdateSecretClaim")
 	defer span.End()
 	m.Logger.Debug("K8s: attempting to update SecretClaims",
@@ -130,71 +94,16 @@ func (m *K8sDynamicClient) UpdateSecretClaim(ctx context.Context, name, namespac
 		return fmt.Errorf("failed to read SecretClaim %s before update: %w", name, err)
 	}
 
-	if claimType != "" {
-		existingClaim.Spec.Type = claimType
-	}
-	if data != nil {
-		existingClaim.Spec.Data = *data
-	}
-	if labels != nil {
-		existingClaim.ObjectMeta.Labels = *labels
+	previousClaim := existingClaim.DeepCopy()
+	if err := m.updateSecretClaimSpec(span, &existingClaim.Spec, name, namespace, claimType, regenerate, regenerateKeys, data, encryptedData, generationConfig, rotation, templates, deletionPolicy, secretType, dockerConfig, certificate, source, targets, push); err != nil {
+		return err
 	}
-	if annotations != nil {
-		existingClaim.ObjectMeta.Annotations = *annotations
-	}
-	traceparent := observability.GetTraceParentHeader(ctx)
-	if traceparent != "" {
-		existingClaim.Annotations[observability.K8sTraceparentAnnotationKey] = traceparent
-		span.SetAttributes(attribute.String("traceparent.propagation", traceparent))
-		m.Logger.Debug("Propagating traceparent to SecretClaim annotations", slog.String("traceparent", traceparent))
-	}
-
-	switch existingClaim.Spec.Type {
-	case "AutoGenerated":
-		if generationConfig != nil {
-			if int(generationConfig.Length) < 8 || (generationConfig.DataKeys == nil || len(*generationConfig.DataKeys) == 0) || generationConfig.Encoding == nil {
-				span.RecordError(errors.New("invalid generationConfig"))
-				span.SetStatus(codes.Error, "Invalid generationConfig")
-				m.Logger.Error("K8s: invalid generationConfig", slog.String("namespace", namespace), slog.String("name", name), slog.Int("length", int(generationConfig.Length)))
-				return fmt.Errorf("invalid generationConfig")
-			}
-
-			var dataKeys []string
-			if len(*generationConfig.DataKeys) == 1 {
-				jsonKeys := (*generationConfig.DataKeys)[0]
-				if err := json.Unmarshal([]byte(jsonKeys), &dataKeys); err == nil {
-					m.Logger.Debug("Parsed JSON DataKeys", slog.Any("keys", dataKeys))
-				} else {
-					dataKeys = strings.Split(jsonKeys, ",")
-					for i, key := range dataKeys {
-						dataKeys[i] = strings.TrimSpace(key)
-					}
-				}
-			} else {
-				dataKeys = *generationConfig.DataKeys
-			}
-
-			existingClaim.Spec.Generation = &secretsv1alpha1.GenerationConfig{
-				Length:   int(generationConfig.Length),
-				Encoding: string(*generationConfig.Encoding),
-				DataKeys: dataKeys,
-			}
-		}
-
-		if existingClaim.Spec.Generation == nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "generationConfig must be provided")
-			m.Logger.Error("K8s: generationConfig must be provided when switching to AutoGenerated secret type if no existing configuration is present", slog.String("namespace", namespace), slog.String("name", name))
-			return fmt.Errorf("generationConfig must be provided when switching to AutoGenerated secret type if no existing configuration is present")
-		}
-
-		if regenerate {
-			existingClaim.Spec.Generation.ReconcileTrigger = uuid.NewString()
-		}
-		existingClaim.Spec.Data = nil
-
-	case "Opaque":
-		existingClaim.Spec.Generation = nil
+	m.updateClaimMetadata(ctx, span, existingClaim, labels, annotations)
+	values, err := m.hashOpaqueData(&existingClaim.Spec)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to hash data")
+		return err
 	}
 
 	m.Logger.Debug("K8s: updating SecretClaims",
@@ -210,6 +119,17 @@ func (m *K8sDynamicClient) UpdateSecretClaim(ctx context.Context, name, namespac
 		m.Logger.Error("K8s: failed to update SecretClaim", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
 		return fmt.Errorf("failed to update SecretClaim %s in namespace %s: %w", name, namespace, err)
 	}
+	if err := m.writeHashedData(ctx, existingClaim, values); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to write hashed data")
+		// The new hashes would never match the Secret, so the claim goes back
+		// to the spec its Secret was written for.
+		previousClaim.ResourceVersion = existingClaim.ResourceVersion
+		if rollbackErr := m.Client.Update(ctx, previousClaim); rollbackErr != nil {
+			m.Logger.Error("K8s: failed to roll back SecretClaim without data", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", rollbackErr.Error()))
+		}
+		return err
+	}
 	span.SetStatus(codes.Ok, "Success")
 	return nil
 }
@@ -327,3 +247,445 @@ func (m *K8sDynamicClient) ListSecretClaim(ctx context.Context, namespace string
 	span.SetStatus(codes.Ok, "Success")
 	return secretClaimList, nil
 }
+
+// newSecretClaimSpec builds the spec of a new SecretClaim or ClusterSecretClaim.
+// name and namespace are only used for logging.
+func (m *K8sDynamicClient) newSecretClaimSpec(span trace.Span, name, namespace, claimType string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig) (secretsv1alpha1.SecretClaimSpec, error) {
+	spec := secretsv1alpha1.SecretClaimSpec{
+		Type: claimType,
+	}
+
+	if data != nil {
+		spec.Data = *data
+	}
+	if encryptedData != nil && len(*encryptedData) > 0 {
+		spec.EncryptedData = *encryptedData
+	}
+	if templates != nil && len(*templates) > 0 {
+		spec.Templates = *templates
+	}
+	if deletionPolicy != nil {
+		spec.DeletionPolicy = string(*deletionPolicy)
+	}
+	if secretType != nil {
+		spec.SecretType = string(*secretType)
+	}
+	spec.DockerConfig = toDockerConfig(dockerConfig)
+	spec.Targets = toTargetsConfig(targets)
+	spec.Push = toPushConfig(push)
+
+	switch claimType {
+	case "AutoGenerated":
+		if int(generationConfig.Length) < 8 || (generationConfig.DataKeys == nil || len(*generationConfig.DataKeys) == 0) || generationConfig.Encoding == nil {
+			span.RecordError(errors.New("invalid generationConfig"))
+			span.SetStatus(codes.Error, "Invalid generationConfig")
+			m.Logger.Error("K8s: invalid generationConfig", slog.String("namespace", namespace), slog.String("name", name), slog.Int("length", int(generationConfig.Length)))
+			return spec, fmt.Errorf("invalid generationConfig")
+		}
+
+		var dataKeys []string
+		if len(*generationConfig.DataKeys) == 1 {
+			jsonKeys := (*generationConfig.DataKeys)[0]
+			if err := json.Unmarshal([]byte(jsonKeys), &dataKeys); err == nil {
+				m.Logger.Debug("Parsed JSON DataKeys", slog.Any("keys", dataKeys))
+			} else {
+				dataKeys = strings.Split(jsonKeys, ",")
+				for i, key := range dataKeys {
+					dataKeys[i] = strings.TrimSpace(key)
+				}
+			}
+		} else {
+			dataKeys = *generationConfig.DataKeys
+		}
+
+		spec.Generation = &secretsv1alpha1.GenerationConfig{
+			Length:           int(generationConfig.Length),
+			Encoding:         string(*generationConfig.Encoding),
+			DataKeys:         dataKeys,
+			ReconcileTrigger: uuid.NewString(),
+			Keys:             toKeyGenerations(generationConfig.Keys),
+		}
+
+		rotationConfig, err := toRotationConfig(rotation)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Invalid rotation config")
+			m.Logger.Error("K8s: invalid rotation config", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
+			return spec, err
+		}
+		spec.Rotation = rotationConfig
+
+		spec.Data = nil
+		spec.EncryptedData = nil
+		spec.DataHashes = nil
+
+	case "Certificate":
+		certificateConfig, err := toCertificateConfig(certificate)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Invalid certificate config")
+			m.Logger.Error("K8s: invalid certificate config", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
+			return spec, err
+		}
+		spec.Certificate = certificateConfig
+		spec.Generation = nil
+		spec.Data = nil
+		spec.EncryptedData = nil
+		spec.DataHashes = nil
+
+	case "External":
+		sourceConfig, err := toSourceConfig(source)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Invalid source config")
+			m.Logger.Error("K8s: invalid source config", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
+			return spec, err
+		}
+		spec.Source = sourceConfig
+		spec.Generation = nil
+		spec.Data = nil
+		spec.EncryptedData = nil
+		spec.DataHashes = nil
+
+	case "Opaque":
+		spec.Generation = nil
+	}
+	return spec, nil
+}
+
+// newClaimMetadata returns the labels and annotations of a new claim, with the
+// request traceparent so the controller continues the trace.
+func (m *K8sDynamicClient) newClaimMetadata(ctx context.Context, span trace.Span, labels *map[string]string, annotations *map[string]string) (map[string]string, map[string]string) {
+	labelsToSet := map[string]string{}
+	if labels != nil {
+		labelsToSet = *labels
+	}
+
+	annotationsToSet := map[string]string{}
+	if annotations != nil {
+		annotationsToSet = *annotations
+	}
+
+	traceparent := observability.GetTraceParentHeader(ctx)
+	if traceparent != "" {
+		annotationsToSet[observability.K8sTraceparentAnnotationKey] = traceparent
+		span.SetAttributes(attribute.String("traceparent.propagation", traceparent))
+		m.Logger.Debug("Propagating traceparent to SecretClaim annotations", slog.String("traceparent", traceparent))
+	}
+	return labelsToSet, annotationsToSet
+}
+
+// updateSecretClaimSpec applies the request fields to the spec of an existing
+// SecretClaim or ClusterSecretClaim. Nil fields are left unchanged.
+func (m *K8sDynamicClient) updateSecretClaimSpec(span trace.Span, spec *secretsv1alpha1.SecretClaimSpec, name, namespace, claimType string, regenerate bool, regenerateKeys *[]string, data *map[string]string, encryptedData *map[string]string, generationConfig *api.GenerationConfig, rotation *api.RotationConfig, templates *map[string]string, deletionPolicy *api.DeletionPolicy, secretType *api.SecretType, dockerConfig *api.DockerConfig, certificate *api.CertificateConfig, source *api.SourceConfig, targets *api.TargetsConfig, push *api.PushConfig) error {
+	previousType := spec.Type
+	if claimType != "" {
+		spec.Type = claimType
+	}
+	if data != nil {
+		// Data replaces every plain value, including the ones kept as hashes.
+		spec.Data = *data
+		spec.DataHashes = nil
+	}
+	if encryptedData != nil {
+		spec.EncryptedData = *encryptedData
+		if len(*encryptedData) == 0 {
+			spec.EncryptedData = nil
+		}
+	}
+	if templates != nil {
+		spec.Templates = *templates
+		if len(*templates) == 0 {
+			spec.Templates = nil
+		}
+	}
+	if deletionPolicy != nil {
+		spec.DeletionPolicy = string(*deletionPolicy)
+	}
+	if dockerConfig != nil {
+		spec.DockerConfig = toDockerConfig(dockerConfig)
+	}
+	if secretType != nil {
+		spec.SecretType = string(*secretType)
+		if *secretType != api.SecretTypeKubernetesIoDockerconfigjson {
+			spec.DockerConfig = nil
+		}
+	}
+	if targets != nil {
+		spec.Targets = toTargetsConfig(targets)
+	}
+	if push != nil {
+		spec.Push = toPushConfig(push)
+	}
+	switch spec.Type {
+	case "AutoGenerated":
+		if generationConfig != nil {
+			if int(generationConfig.Length) < 8 || (generationConfig.DataKeys == nil || len(*generationConfig.DataKeys) == 0) || generationConfig.Encoding == nil {
+				span.RecordError(errors.New("invalid generationConfig"))
+				span.SetStatus(codes.Error, "Invalid generationConfig")
+				m.Logger.Error("K8s: invalid generationConfig", slog.String("namespace", namespace), slog.String("name", name), slog.Int("length", int(generationConfig.Length)))
+				return fmt.Errorf("invalid generationConfig")
+			}
+
+			var dataKeys []string
+			if len(*generationConfig.DataKeys) == 1 {
+				jsonKeys := (*generationConfig.DataKeys)[0]
+				if err := json.Unmarshal([]byte(jsonKeys), &dataKeys); err == nil {
+					m.Logger.Debug("Parsed JSON DataKeys", slog.Any("keys", dataKeys))
+				} else {
+					dataKeys = strings.Split(jsonKeys, ",")
+					for i, key := range dataKeys {
+						dataKeys[i] = strings.TrimSpace(key)
+					}
+				}
+			} else {
+				dataKeys = *generationConfig.DataKeys
+			}
+
+			newGeneration := &secretsv1alpha1.GenerationConfig{
+				Length:   int(generationConfig.Length),
+				Encoding: string(*generationConfig.Encoding),
+				DataKeys: dataKeys,
+				Keys:     toKeyGenerations(generationConfig.Keys),
+			}
+			// Триггеры переносим, иначе контроллер не отличит смену конфига от запроса на регенерацию
+			if spec.Generation != nil {
+				newGeneration.ReconcileTrigger = spec.Generation.ReconcileTrigger
+				for key, trigger := range spec.Generation.KeyTriggers {
+					if slices.Contains(dataKeys, key) {
+						if newGeneration.KeyTriggers == nil {
+							newGeneration.KeyTriggers = map[string]string{}
+						}
+						newGeneration.KeyTriggers[key] = trigger
+					}
+				}
+				// Without keys in the request the formats of the remaining keys are kept
+				if generationConfig.Keys == nil {
+					for key, cfg := range spec.Generation.Keys {
+						if slices.Contains(dataKeys, key) {
+							if newGeneration.Keys == nil {
+								newGeneration.Keys = map[string]secretsv1alpha1.KeyGeneration{}
+							}
+							newGeneration.Keys[key] = cfg
+						}
+					}
+				}
+			}
+			spec.Generation = newGeneration
+		}
+
+		if spec.Generation == nil {
+			err := fmt.Errorf("generationConfig must be provided when switching to AutoGenerated secret type if no existing configuration is present")
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "generationConfig must be provided")
+			m.Logger.Error("K8s: generationConfig must be provided when switching to AutoGenerated secret type if no existing configuration is present", slog.String("namespace", namespace), slog.String("name", name))
+			return err
+		}
+
+		// Значения бывшего Opaque секрета не должны выдаваться за сгенерированные
+		if regenerate || previousType != "AutoGenerated" {
+			spec.Generation.ReconcileTrigger = uuid.NewString()
+		}
+
+		if regenerateKeys != nil {
+			for _, key := range *regenerateKeys {
+				if !slices.Contains(spec.Generation.DataKeys, key) {
+					err := k8serrors.NewBadRequest(fmt.Sprintf("key %s is not one of the generated data keys", key))
+					span.RecordError(err)
+					span.SetStatus(codes.Error, "Unknown key to regenerate")
+					m.Logger.Error("K8s: unknown key to regenerate", slog.String("namespace", namespace), slog.String("name", name), slog.String("key", key))
+					return err
+				}
+				if spec.Generation.KeyTriggers == nil {
+					spec.Generation.KeyTriggers = map[string]string{}
+				}
+				spec.Generation.KeyTriggers[key] = uuid.NewString()
+			}
+		}
+
+		if rotation != nil {
+			rotationConfig, err := toRotationConfig(rotation)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "Invalid rotation config")
+				m.Logger.Error("K8s: invalid rotation config", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
+				return err
+			}
+			spec.Rotation = rotationConfig
+		}
+		spec.Data = nil
+		spec.EncryptedData = nil
+		spec.DataHashes = nil
+		spec.Certificate = nil
+		spec.Source = nil
+
+	case "Certificate":
+		if certificate != nil {
+			certificateConfig, err := toCertificateConfig(certificate)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "Invalid certificate config")
+				m.Logger.Error("K8s: invalid certificate config", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
+				return err
+			}
+			spec.Certificate = certificateConfig
+		}
+		if spec.Certificate == nil {
+			span.SetStatus(codes.Error, "certificate must be provided")
+			m.Logger.Error("K8s: certificate must be provided when switching to Certificate secret type", slog.String("namespace", namespace), slog.String("name", name))
+			return k8serrors.NewBadRequest("certificate must be provided when switching to Certificate secret type")
+		}
+		spec.Generation = nil
+		spec.Rotation = nil
+		spec.Data = nil
+		spec.EncryptedData = nil
+		spec.DataHashes = nil
+		spec.Source = nil
+
+	case "External":
+		if source != nil {
+			sourceConfig, err := toSourceConfig(source)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "Invalid source config")
+				m.Logger.Error("K8s: invalid source config", slog.String("namespace", namespace), slog.String("name", name), slog.String("error", err.Error()))
+				return err
+			}
+			spec.Source = sourceConfig
+		}
+		if spec.Source == nil {
+			span.SetStatus(codes.Error, "source must be provided")
+			m.Logger.Error("K8s: source must be provided when switching to External secret type", slog.String("namespace", namespace), slog.String("name", name))
+			return k8serrors.NewBadRequest("source must be provided when switching to External secret type")
+		}
+		spec.Generation = nil
+		spec.Rotation = nil
+		spec.Data = nil
+		spec.EncryptedData = nil
+		spec.DataHashes = nil
+		spec.Certificate = nil
+
+	case "Opaque":
+		spec.Generation = nil
+		spec.Rotation = nil
+		spec.Certificate = nil
+		spec.Source = nil
+	}
+	return nil
+}
+
+// updateClaimMetadata replaces the labels and annotations of obj if set and
+// propagates the request traceparent.
+func (m *K8sDynamicClient) updateClaimMetadata(ctx context.Context, span trace.Span, obj client.Object, labels *map[string]string, annotations *map[string]string) {
+	if labels != nil {
+		obj.SetLabels(*labels)
+	}
+	if annotations != nil {
+		obj.SetAnnotations(*annotations)
+	}
+	traceparent := observability.GetTraceParentHeader(ctx)
+	if traceparent != "" {
+		claimAnnotations := obj.GetAnnotations()
+		if claimAnnotations == nil {
+			claimAnnotations = map[string]string{}
+		}
+		claimAnnotations[observability.K8sTraceparentAnnotationKey] = traceparent
+		obj.SetAnnotations(claimAnnotations)
+		span.SetAttributes(attribute.String("traceparent.propagation", traceparent))
+		m.Logger.Debug("Propagating traceparent to SecretClaim annotations", slog.String("traceparent", traceparent))
+	}
+}
+
+// hashOpaqueData moves the Data of an Opaque claim into DataHashes when
+// HashOpaqueData is set and returns the values to write to the Secret.
+func (m *K8sDynamicClient) hashOpaqueData(spec *secretsv1alpha1.SecretClaimSpec) (map[string]string, error) {
+	if !m.HashOpaqueData || spec.Type != "Opaque" || len(spec.Data) == 0 {
+		return nil, nil
+	}
+
+	if spec.DataHashes == nil {
+		spec.DataHashes = make(map[string]string, len(spec.Data))
+	}
+	for key, value := range spec.Data {
+		hash, err := datahash.Sum([]byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash data key %s: %w", key, err)
+		}
+		spec.DataHashes[key] = hash
+	}
+	values := spec.Data
+	spec.Data = nil
+	return values, nil
+}
+
+// writeHashedData writes the values kept as hashes in claim to its Secret,
+// creating an Opaque Secret owned by the claim if there is none yet. The
+// controller fixes the Secret type and renders templates and typed keys once it
+// sees the values.
+func (m *K8sDynamicClient) writeHashedData(ctx context.Context, claim *secretsv1alpha1.SecretClaim, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	ctx, span := m.Tracer.Start(ctx, "K8sDynamicClient.writeHashedData")
+	defer span.End()
+	span.SetAttributes(semconv.K8SNamespaceName(claim.Namespace), attribute.Int("hashed_keys", len(values)))
+
+	secret := &corev1.Secret{}
+	err := m.Client.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, secret)
+	switch {
+	case k8serrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      claim.Name,
+				Namespace: claim.Namespace,
+				Labels:    claim.Labels,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: make(map[string][]byte, len(values)),
+		}
+		if err := controllerutil.SetControllerReference(claim, secret, m.Client.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner of Secret %s: %w", claim.Name, err)
+		}
+		for key, value := range values {
+			secret.Data[key] = []byte(value)
+		}
+		if err := m.Client.Create(ctx, secret); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Failed to create Secret")
+			m.Logger.Error("K8s: failed to create Secret with hashed data", slog.String("namespace", claim.Namespace), slog.String("name", claim.Name), slog.String("error", err.Error()))
+			return fmt.Errorf("failed to write data to Secret %s in namespace %s: %w", claim.Name, claim.Namespace, err)
+		}
+
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get Secret")
+		m.Logger.Error("K8s: failed to get Secret for hashed data", slog.String("namespace", claim.Namespace), slog.String("name", claim.Name), slog.String("error", err.Error()))
+		return fmt.Errorf("failed to get Secret %s in namespace %s: %w", claim.Name, claim.Namespace, err)
+
+	default:
+		if !metav1.IsControlledBy(secret, claim) {
+			span.SetStatus(codes.Error, "Secret is not managed by the claim")
+			return k8serrors.NewConflict(corev1.Resource("secrets"), claim.Name,
+				fmt.Errorf("secret %s already exists and is not managed by this SecretClaim", claim.Name))
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte, len(values))
+		}
+		for key, value := range values {
+			secret.Data[key] = []byte(value)
+		}
+		if err := m.Client.Update(ctx, secret); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Failed to update Secret")
+			m.Logger.Error("K8s: failed to update Secret with hashed data", slog.String("namespace", claim.Namespace), slog.String("name", claim.Name), slog.String("error", err.Error()))
+			return fmt.Errorf("failed to write data to Secret %s in namespace %s: %w", claim.Name, claim.Namespace, err)
+		}
+	}
+
+	m.Logger.Debug("K8s: wrote hashed data to Secret",
+		slog.String("namespace", claim.Namespace),
+		slog.String("name", claim.Name),
+		slog.Int("keys", len(values)))
+	span.SetStatus(codes.Ok, "Success")
+	return nil
+}
@@ -7,12 +7,15 @@ import (
 	"log/slog"
 	"reflect"
 	"testing"
+	"time"
 
 	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
 	"github.com/mogilyoy/k8s-secret-manager/internal/api"
+	"github.com/mogilyoy/k8s-secret-manager/internal/datahash"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -63,7 +66,7 @@ func TestCreateSecretClaim_AutoGenerated_Success(t *testing.T) {
 	labels := map[string]string{"app": "testing"}
 	annotations := map[string]string{"custom": "annotation"}
 
-	err := k.CreateSecretClaim(ctx, name, ns, claimType, &data, genCfg, &labels, &annotations)
+	err := k.CreateSecretClaim(ctx, name, ns, claimType, &data, nil, genCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, &labels, &annotations)
 	if err != nil {
 		t.Fatalf("CreateSecretClaim error: %v", err)
 	}
@@ -109,7 +112,7 @@ func TestCreateSecretClaim_Opaque_Success(t *testing.T) {
 
 	data := map[string]string{"foo": "bar"}
 
-	err := k.CreateSecretClaim(ctx, name, ns, claimType, &data, nil, nil, nil)
+	err := k.CreateSecretClaim(ctx, name, ns, claimType, &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("CreateSecretClaim error: %v", err)
 	}
@@ -129,6 +132,164 @@ func TestCreateSecretClaim_Opaque_Success(t *testing.T) {
 	}
 }
 
+func TestCreateSecretClaim_EncryptedData(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	ns := "default"
+	encrypted := map[string]string{"password": "c2VhbGVk"}
+
+	if err := k.CreateSecretClaim(ctx, "sealed", ns, "Opaque", nil, &encrypted, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("CreateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: "sealed"}, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Spec.EncryptedData, encrypted) {
+		t.Errorf("Spec.EncryptedData = %v, want %v", got.Spec.EncryptedData, encrypted)
+	}
+
+	if err := k.UpdateSecretClaim(ctx, "sealed", ns, "", false, nil, nil, &map[string]string{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("UpdateSecretClaim error: %v", err)
+	}
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: "sealed"}, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if got.Spec.EncryptedData != nil {
+		t.Errorf("Spec.EncryptedData = %v, want nil after clearing", got.Spec.EncryptedData)
+	}
+}
+
+func TestCreateSecretClaim_HashOpaqueData(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+	k.HashOpaqueData = true
+
+	ns := "default"
+	key := types.NamespacedName{Namespace: ns, Name: "hashed"}
+	data := map[string]string{"username": "app", "password": "s3cret"}
+
+	if err := k.CreateSecretClaim(ctx, "hashed", ns, "Opaque", &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("CreateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, key, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if got.Spec.Data != nil {
+		t.Errorf("Spec.Data = %v, want nil", got.Spec.Data)
+	}
+	for name, value := range data {
+		if !datahash.Verify(got.Spec.DataHashes[name], []byte(value)) {
+			t.Errorf("Spec.DataHashes[%s] = %q does not match the value", name, got.Spec.DataHashes[name])
+		}
+	}
+
+	var secret corev1.Secret
+	if err := k.Client.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("getting Secret failed: %v", err)
+	}
+	if !metav1.IsControlledBy(&secret, &got) {
+		t.Errorf("Secret is not controlled by the claim: %v", secret.OwnerReferences)
+	}
+	if string(secret.Data["password"]) != "s3cret" || string(secret.Data["username"]) != "app" {
+		t.Errorf("Secret.Data = %v, want the claim data", secret.Data)
+	}
+
+	secret.Data["dsn"] = []byte("rendered")
+	if err := k.Client.Update(ctx, &secret); err != nil {
+		t.Fatalf("updating Secret failed: %v", err)
+	}
+	update := map[string]string{"password": "n3w"}
+	if err := k.UpdateSecretClaim(ctx, "hashed", ns, "", false, nil, &update, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("UpdateSecretClaim error: %v", err)
+	}
+	if err := k.Client.Get(ctx, key, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if len(got.Spec.DataHashes) != 1 || !datahash.Verify(got.Spec.DataHashes["password"], []byte("n3w")) {
+		t.Errorf("Spec.DataHashes = %v, want only the new password", got.Spec.DataHashes)
+	}
+	if err := k.Client.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("getting Secret failed: %v", err)
+	}
+	if string(secret.Data["password"]) != "n3w" || string(secret.Data["dsn"]) != "rendered" {
+		t.Errorf("Secret.Data = %v, want the new password next to the other keys", secret.Data)
+	}
+}
+
+func TestCreateSecretClaim_HashOpaqueDataSecretNotOwned(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+	k.HashOpaqueData = true
+
+	ns := "default"
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "taken", Namespace: ns}}
+	if err := k.Client.Create(ctx, foreign); err != nil {
+		t.Fatalf("creating Secret failed: %v", err)
+	}
+
+	data := map[string]string{"password": "s3cret"}
+	err := k.CreateSecretClaim(ctx, "taken", ns, "Opaque", &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	if !k8serrors.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: "taken"}, &got); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the claim to be removed, got %v", err)
+	}
+}
+
+// clientWithSecretUpdateError fails updates of Secrets.
+type clientWithSecretUpdateError struct {
+	client.Client
+}
+
+func (c *clientWithSecretUpdateError) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		return fmt.Errorf("forced update error")
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestUpdateSecretClaim_HashOpaqueDataWriteError(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+	k.HashOpaqueData = true
+
+	ns := "default"
+	key := types.NamespacedName{Namespace: ns, Name: "hashed"}
+	data := map[string]string{"password": "s3cret"}
+	if err := k.CreateSecretClaim(ctx, "hashed", ns, "Opaque", &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("CreateSecretClaim error: %v", err)
+	}
+
+	k.Client = &clientWithSecretUpdateError{Client: k.Client}
+	update := map[string]string{"password": "n3w"}
+	if err := k.UpdateSecretClaim(ctx, "hashed", ns, "", false, nil, &update, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err == nil {
+		t.Fatal("expected the failed Secret write to be reported")
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, key, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if !datahash.Verify(got.Spec.DataHashes["password"], []byte("s3cret")) {
+		t.Errorf("Spec.DataHashes = %v, want the hashes of the values in the Secret", got.Spec.DataHashes)
+	}
+	var secret corev1.Secret
+	if err := k.Client.Get(ctx, key, &secret); err != nil {
+		t.Fatalf("getting Secret failed: %v", err)
+	}
+	if string(secret.Data["password"]) != "s3cret" {
+		t.Errorf("Secret.Data = %v, want the old password", secret.Data)
+	}
+}
+
 func TestCreateSecretClaim_InvalidGenerationConfig(t *testing.T) {
 	ctx := context.Background()
 	k := newTestK8sClient(t)
@@ -143,7 +304,7 @@ func TestCreateSecretClaim_InvalidGenerationConfig(t *testing.T) {
 		DataKeys: nil,
 	}
 
-	err := k.CreateSecretClaim(ctx, name, ns, claimType, nil, genCfg, nil, nil)
+	err := k.CreateSecretClaim(ctx, name, ns, claimType, nil, nil, genCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err == nil {
 		t.Fatalf("expected error due to invalid generationConfig, got nil")
 	}
@@ -155,6 +316,229 @@ func TestCreateSecretClaim_InvalidGenerationConfig(t *testing.T) {
 	}
 }
 
+func TestCreateSecretClaim_AutoGenerated_WithRotation(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "test-claim-rotation"
+	ns := "default"
+
+	encoding := api.Alphanumeric
+	keys := []string{"password"}
+	genCfg := &api.GenerationConfig{
+		Length:   16,
+		Encoding: &encoding,
+		DataKeys: &keys,
+	}
+	interval := "2160h"
+	rotation := &api.RotationConfig{Interval: &interval}
+
+	if err := k.CreateSecretClaim(ctx, name, ns, "AutoGenerated", nil, nil, genCfg, rotation, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("CreateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if got.Spec.Rotation == nil || got.Spec.Rotation.Interval == nil {
+		t.Fatalf("Spec.Rotation.Interval is nil, want 2160h")
+	}
+	if got.Spec.Rotation.Interval.Duration != 90*24*time.Hour {
+		t.Errorf("Rotation.Interval = %v, want %v", got.Spec.Rotation.Interval.Duration, 90*24*time.Hour)
+	}
+
+	badInterval := "ninety days"
+	err := k.CreateSecretClaim(ctx, "test-claim-bad-rotation", ns, "AutoGenerated", nil, nil, genCfg, &api.RotationConfig{Interval: &badInterval}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	if err == nil {
+		t.Errorf("expected error for invalid rotation interval, got nil")
+	}
+}
+
+func TestCreateSecretClaim_External_Success(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "test-claim-external"
+	ns := "default"
+
+	keys := map[string]string{"DB_PASSWORD": "password"}
+	address := "https://vault.example.com"
+	credentials := "vault-token"
+	interval := "15m"
+	source := &api.SourceConfig{
+		Provider:             api.SourceConfigProviderVault,
+		Path:                 "apps/db",
+		Address:              &address,
+		CredentialsSecretRef: &credentials,
+		Keys:                 &keys,
+		RefreshInterval:      &interval,
+	}
+
+	if err := k.CreateSecretClaim(ctx, name, ns, "External", nil, nil, nil, nil, nil, nil, nil, nil, nil, source, nil, nil, nil, nil); err != nil {
+		t.Fatalf("CreateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	cfg := got.Spec.Source
+	if cfg == nil {
+		t.Fatalf("Spec.Source is nil")
+	}
+	if cfg.Provider != "vault" || cfg.Path != "apps/db" || cfg.Address != "https://vault.example.com" || cfg.CredentialsSecretRef != "vault-token" {
+		t.Errorf("unexpected source spec: %+v", cfg)
+	}
+	if cfg.Keys["DB_PASSWORD"] != "password" {
+		t.Errorf("Keys = %v, want DB_PASSWORD=password", cfg.Keys)
+	}
+	if cfg.RefreshInterval == nil || cfg.RefreshInterval.Duration != 15*time.Minute {
+		t.Errorf("RefreshInterval = %v, want 15m", cfg.RefreshInterval)
+	}
+
+	badInterval := "soon"
+	source.RefreshInterval = &badInterval
+	err := k.CreateSecretClaim(ctx, "test-claim-external-bad", ns, "External", nil, nil, nil, nil, nil, nil, nil, nil, nil, source, nil, nil, nil, nil)
+	if err == nil {
+		t.Errorf("expected error for invalid refresh interval, got nil")
+	}
+}
+
+func TestCreateSecretClaim_Push(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "test-claim-push"
+	ns := "default"
+
+	dataKeys := []string{"password"}
+	encoding := api.Alphanumeric
+	genCfg := &api.GenerationConfig{Length: 16, Encoding: &encoding, DataKeys: &dataKeys}
+	address := "https://vault.example.com"
+	keys := map[string]string{"password": "db_password"}
+	policy := api.Overwrite
+	push := &api.PushConfig{
+		Provider:       api.PushConfigProviderVault,
+		Path:           "apps/db",
+		Address:        &address,
+		Keys:           &keys,
+		ConflictPolicy: &policy,
+	}
+
+	if err := k.CreateSecretClaim(ctx, name, ns, "AutoGenerated", nil, nil, genCfg, nil, nil, nil, nil, nil, nil, nil, nil, push, nil, nil); err != nil {
+		t.Fatalf("CreateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	cfg := got.Spec.Push
+	if cfg == nil {
+		t.Fatalf("Spec.Push is nil")
+	}
+	if cfg.Provider != "vault" || cfg.Path != "apps/db" || cfg.Address != "https://vault.example.com" || cfg.ConflictPolicy != "Overwrite" {
+		t.Errorf("unexpected push spec: %+v", cfg)
+	}
+	if cfg.Keys["password"] != "db_password" {
+		t.Errorf("Keys = %v, want password=db_password", cfg.Keys)
+	}
+
+	if err := k.UpdateSecretClaim(ctx, name, ns, "", false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &api.PushConfig{}, nil, nil); err != nil {
+		t.Fatalf("UpdateSecretClaim error: %v", err)
+	}
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &got); err != nil {
+		t.Fatalf("getting SecretClaim failed: %v", err)
+	}
+	if got.Spec.Push != nil {
+		t.Errorf("Spec.Push = %+v, want nil after an empty push", got.Spec.Push)
+	}
+}
+
+func TestUpdateSecretClaim_DisableRotation(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "update-claim-rotation"
+	ns := "default"
+
+	original := &secretsv1alpha1.SecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: secretsv1alpha1.SecretClaimSpec{
+			Type: "AutoGenerated",
+			Generation: &secretsv1alpha1.GenerationConfig{
+				Length:   16,
+				DataKeys: []string{"password"},
+			},
+			Rotation: &secretsv1alpha1.RotationConfig{Schedule: "0 3 1 * *"},
+		},
+	}
+	if err := k.Client.Create(ctx, original); err != nil {
+		t.Fatalf("setup: failed to create original SecretClaim: %v", err)
+	}
+
+	if err := k.UpdateSecretClaim(ctx, name, ns, "", false, nil, nil, nil, nil, &api.RotationConfig{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("UpdateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &got); err != nil {
+		t.Fatalf("getting updated SecretClaim failed: %v", err)
+	}
+	if got.Spec.Rotation != nil {
+		t.Errorf("Spec.Rotation = %v, want nil after passing an empty rotation", got.Spec.Rotation)
+	}
+}
+
+func TestUpdateSecretClaim_RegenerateKeys(t *testing.T) {
+	ctx := context.Background()
+	k := newTestK8sClient(t)
+
+	name := "update-claim-regen-key"
+	ns := "default"
+
+	original := &secretsv1alpha1.SecretClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+		Spec: secretsv1alpha1.SecretClaimSpec{
+			Type: "AutoGenerated",
+			Generation: &secretsv1alpha1.GenerationConfig{
+				Length:           16,
+				DataKeys:         []string{"password", "token"},
+				ReconcileTrigger: "initial",
+			},
+		},
+	}
+	if err := k.Client.Create(ctx, original); err != nil {
+		t.Fatalf("setup: failed to create original SecretClaim: %v", err)
+	}
+
+	keys := []string{"token"}
+	if err := k.UpdateSecretClaim(ctx, name, ns, "AutoGenerated", false, &keys, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatalf("UpdateSecretClaim error: %v", err)
+	}
+
+	var got secretsv1alpha1.SecretClaim
+	if err := k.Client.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &got); err != nil {
+		t.Fatalf("getting updated SecretClaim failed: %v", err)
+	}
+	if got.Spec.Generation.ReconcileTrigger != "initial" {
+		t.Errorf("ReconcileTrigger = %q, want it unchanged", got.Spec.Generation.ReconcileTrigger)
+	}
+	if got.Spec.Generation.KeyTriggers["token"] == "" {
+		t.Errorf("expected a key trigger for token, got %v", got.Spec.Generation.KeyTriggers)
+	}
+	if _, ok := got.Spec.Generation.KeyTriggers["password"]; ok {
+		t.Errorf("password must not get a key trigger")
+	}
+
+	unknown := []string{"missing"}
+	err := k.UpdateSecretClaim(ctx, name, ns, "AutoGenerated", false, &unknown, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	if !k8serrors.IsBadRequest(err) {
+		t.Errorf("expected BadRequest for unknown key, got %v", err)
+	}
+}
+
 func TestUpdateSecretClaim_AutoGenerated_Success(t *testing.T) {
 	ctx := context.Background()
 	k := newTestK8sClient(t)
@@ -187,7 +571,7 @@ func TestUpdateSecretClaim_AutoGenerated_Success(t *testing.T) {
 	labels := map[string]string{"app": "updated"}
 	annotations := map[string]string{"updated": "true"}
 
-	err := k.UpdateSecretClaim(ctx, name, ns, claimType, true, &data, genCfg, &labels, &annotations)
+	err := k.UpdateSecretClaim(ctx, name, ns, claimType, true, nil, &data, nil, genCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, &labels, &annotations)
 	if err != nil {
 		t.Fatalf("UpdateSecretClaim error: %v", err)
 	}
@@ -245,7 +629,7 @@ func TestUpdateSecretClaim_Opaque_Success(t *testing.T) {
 	data := map[string]string{"foo": "baz"}
 	claimType := "Opaque"
 
-	err := k.UpdateSecretClaim(ctx, name, ns, claimType, false, &data, nil, nil, nil)
+	err := k.UpdateSecretClaim(ctx, name, ns, claimType, false, nil, &data, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("UpdateSecretClaim error: %v", err)
 	}
@@ -294,7 +678,7 @@ func TestUpdateSecretClaim_InvalidGenerationConfig(t *testing.T) {
 		Length: 4,
 	}
 
-	err := k.UpdateSecretClaim(ctx, name, ns, claimType, false, nil, genCfg, nil, nil)
+	err := k.UpdateSecretClaim(ctx, name, ns, claimType, false, nil, nil, nil, genCfg, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err == nil {
 		t.Fatalf("expected error due to invalid generationConfig, got nil")
 	}
@@ -304,7 +688,7 @@ func TestUpdateSecretClaim_SecretClaimNotFound(t *testing.T) {
 	ctx := context.Background()
 	k := newTestK8sClient(t)
 
-	err := k.UpdateSecretClaim(ctx, "nonexistent", "default", "Opaque", false, nil, nil, nil, nil)
+	err := k.UpdateSecretClaim(ctx, "nonexistent", "default", "Opaque", false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err == nil {
 		t.Fatalf("expected error for missing SecretClaim, got nil")
 	}
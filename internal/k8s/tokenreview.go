@@ -0,0 +1,155 @@
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultTokenReviewCacheTTL is used when no cache TTL is configured.
+const DefaultTokenReviewCacheTTL = time.Minute
+
+// maxTokenReviewCacheEntries bounds the result cache; expired entries are
+// dropped once it is reached.
+const maxTokenReviewCacheEntries = 4096
+
+const serviceAccountUserPrefix = "system:serviceaccount:"
+
+var _ auth.TokenVerifier = &TokenReviewVerifier{}
+
+// TokenReviewVerifier authenticates Kubernetes ServiceAccount tokens with
+// TokenReviews and maps the ServiceAccount to a role through the configured
+// bindings.
+type TokenReviewVerifier struct {
+	client client.Client
+	config cfg.ServiceAccountsConfig
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedTokenReview
+}
+
+type cachedTokenReview struct {
+	claims  auth.Claims
+	expires time.Time
+}
+
+func NewTokenReviewVerifier(c client.Client, config cfg.ServiceAccountsConfig) *TokenReviewVerifier {
+	ttl := config.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultTokenReviewCacheTTL
+	}
+	return &TokenReviewVerifier{
+		client: c,
+		config: config,
+		ttl:    ttl,
+		now:    time.Now,
+		cache:  make(map[[sha256.Size]byte]cachedTokenReview),
+	}
+}
+
+// Handles reports whether token looks like a ServiceAccount token: a legacy
+// token issued by kubernetes/serviceaccount or a bound token carrying the
+// kubernetes.io claim. The signature is not checked.
+func (v *TokenReviewVerifier) Handles(token string) bool {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return false
+	}
+	_, bound := claims["kubernetes.io"]
+	return bound || claims["iss"] == "kubernetes/serviceaccount"
+}
+
+// Verify reviews token and returns the claims of the binding of its
+// ServiceAccount. Results are cached for the cache TTL, but never past the
+// expiry of the token.
+func (v *TokenReviewVerifier) Verify(ctx context.Context, token string) (*auth.Claims, error) {
+	key := sha256.Sum256([]byte(token))
+	now := v.now()
+	v.mu.Lock()
+	cached, ok := v.cache[key]
+	v.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		claims := cached.claims
+		return &claims, nil
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: v.config.Audiences},
+	}
+	if err := v.client.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("TokenReview failed: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("%w: %s", auth.ErrUnauthorizedToken, review.Status.Error)
+	}
+	if len(v.config.Audiences) > 0 && len(review.Status.Audiences) == 0 {
+		return nil, fmt.Errorf("%w: token is not issued for the configured audiences", auth.ErrUnauthorizedToken)
+	}
+
+	user := review.Status.User
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(user.Username, serviceAccountUserPrefix), ":")
+	if !strings.HasPrefix(user.Username, serviceAccountUserPrefix) || !ok {
+		return nil, fmt.Errorf("%w: %s is not a ServiceAccount", auth.ErrUnauthorizedToken, user.Username)
+	}
+	binding := v.binding(namespace, name)
+	if binding == nil {
+		return nil, fmt.Errorf("%w: no binding for ServiceAccount %s/%s", auth.ErrUnauthorizedToken, namespace, name)
+	}
+	allowed := binding.AllowedNamespaces
+	if len(allowed) == 0 {
+		allowed = []string{namespace}
+	}
+	claims := auth.Claims{
+		Username:          user.Username,
+		Role:              binding.Role,
+		AllowedNamespaces: allowed,
+		Verbs:             binding.Verbs,
+		KubernetesUser:    user.Username,
+		KubernetesGroups:  user.Groups,
+	}
+
+	expires := now.Add(v.ttl)
+	tokenClaims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &tokenClaims); err == nil && tokenClaims.ExpiresAt != nil && tokenClaims.ExpiresAt.Before(expires) {
+		expires = tokenClaims.ExpiresAt.Time
+	}
+	v.mu.Lock()
+	if len(v.cache) >= maxTokenReviewCacheEntries {
+		for k, c := range v.cache {
+			if !now.Before(c.expires) {
+				delete(v.cache, k)
+			}
+		}
+	}
+	v.cache[key] = cachedTokenReview{claims: claims, expires: expires}
+	v.mu.Unlock()
+
+	return &claims, nil
+}
+
+func (v *TokenReviewVerifier) binding(namespace, name string) *cfg.ServiceAccountBinding {
+	for i, b := range v.config.Bindings {
+		if ok, _ := path.Match(b.Namespace, namespace); !ok {
+			continue
+		}
+		if b.Name != "" {
+			if ok, _ := path.Match(b.Name, name); !ok {
+				continue
+			}
+		}
+		return &v.config.Bindings[i]
+	}
+	return nil
+}
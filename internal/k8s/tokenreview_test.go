@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func serviceAccountToken(t *testing.T, namespace, name string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":           "https://kubernetes.default.svc.cluster.local",
+		"sub":           "system:serviceaccount:" + namespace + ":" + name,
+		"exp":           time.Now().Add(time.Hour).Unix(),
+		"kubernetes.io": map[string]interface{}{"namespace": namespace},
+	}).SignedString([]byte("cluster"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestTokenReviewVerifier(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, authenticationv1.AddToScheme(scheme))
+	reviews := 0
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authenticationv1.TokenReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			reviews++
+			claims := jwt.MapClaims{}
+			if _, _, err := jwt.NewParser().ParseUnverified(review.Spec.Token, claims); err != nil {
+				review.Status.Error = "invalid token"
+				return nil
+			}
+			review.Status.Authenticated = true
+			review.Status.Audiences = review.Spec.Audiences
+			review.Status.User = authenticationv1.UserInfo{
+				Username: claims["sub"].(string),
+				Groups:   []string{"system:serviceaccounts"},
+			}
+			return nil
+		},
+	}).Build()
+
+	v := NewTokenReviewVerifier(cl, cfg.ServiceAccountsConfig{
+		Enabled:   true,
+		Audiences: []string{"secret-manager"},
+		Bindings: []cfg.ServiceAccountBinding{
+			{Namespace: "ci", Name: "deployer", Role: "operator", AllowedNamespaces: []string{"staging", "production"}, Verbs: []string{"get", "update"}},
+			{Namespace: "team-*", Role: "developer"},
+		},
+	})
+	ctx := context.Background()
+
+	deployer := serviceAccountToken(t, "ci", "deployer")
+	require.True(t, v.Handles(deployer))
+	require.False(t, v.Handles("not-a-jwt"))
+
+	claims, err := v.Verify(ctx, deployer)
+	require.NoError(t, err)
+	require.Equal(t, "system:serviceaccount:ci:deployer", claims.Username)
+	require.Equal(t, "operator", claims.Role)
+	require.Equal(t, []string{"staging", "production"}, claims.AllowedNamespaces)
+	require.Equal(t, []string{"get", "update"}, claims.Verbs)
+	require.Equal(t, claims.Username, claims.KubernetesUser)
+
+	// Reviews are cached.
+	_, err = v.Verify(ctx, deployer)
+	require.NoError(t, err)
+	require.Equal(t, 1, reviews)
+
+	// Without allowed namespaces a binding allows the ServiceAccount's own.
+	claims, err = v.Verify(ctx, serviceAccountToken(t, "team-a", "default"))
+	require.NoError(t, err)
+	require.Equal(t, "developer", claims.Role)
+	require.Equal(t, []string{"team-a"}, claims.AllowedNamespaces)
+
+	_, err = v.Verify(ctx, serviceAccountToken(t, "ci", "other"))
+	require.True(t, errors.Is(err, auth.ErrUnauthorizedToken), "expected an unbound ServiceAccount to be rejected, got %v", err)
+	_, err = v.Verify(ctx, "garbage")
+	require.True(t, errors.Is(err, auth.ErrUnauthorizedToken), "expected an unauthenticated token to be rejected, got %v", err)
+}
@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultUserSecret holds the users managed through the API when no name is
+// configured.
+const DefaultUserSecret = "secret-manager-users"
+
+var _ auth.UserStore = &SecretUserStore{}
+
+// SecretUserStore keeps users in a Secret, one JSON encoded cfg.User with its
+// bcrypt hash per username, so that every API server replica sees them.
+// Changes made through another replica apply after recordSyncInterval.
+type SecretUserStore struct {
+	records *secretRecords[cfg.User]
+}
+
+func NewSecretUserStore(c client.Client, namespace, name string) *SecretUserStore {
+	if name == "" {
+		name = DefaultUserSecret
+	}
+	return &SecretUserStore{records: newSecretRecords[cfg.User](c, namespace, name)}
+}
+
+func (s *SecretUserStore) Get(ctx context.Context, username string) (*cfg.User, error) {
+	user, err := s.records.get(ctx, username)
+	if errors.Is(err, errRecordNotFound) {
+		return nil, auth.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// List always reads the Secret, so that listings are not stale.
+func (s *SecretUserStore) List(ctx context.Context) ([]cfg.User, error) {
+	users, err := s.records.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(users, func(a, b cfg.User) int { return strings.Compare(a.Username, b.Username) })
+	return users, nil
+}
+
+// Create adds user to the Secret, creating the Secret if needed.
+func (s *SecretUserStore) Create(ctx context.Context, user cfg.User) error {
+	err := s.records.create(ctx, user.Username, user)
+	if errors.Is(err, errRecordExists) {
+		return auth.ErrUserExists
+	}
+	return err
+}
+
+func (s *SecretUserStore) Update(ctx context.Context, user cfg.User) error {
+	err := s.records.update(ctx, user.Username, user)
+	if errors.Is(err, errRecordNotFound) {
+		return auth.ErrUserNotFound
+	}
+	return err
+}
+
+func (s *SecretUserStore) Delete(ctx context.Context, username string) error {
+	err := s.records.delete(ctx, username)
+	if errors.Is(err, errRecordNotFound) {
+		return auth.ErrUserNotFound
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretUserStore(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	now := time.Now()
+	replicaA := NewSecretUserStore(cl, "system", "")
+	replicaB := NewSecretUserStore(cl, "system", "")
+	replicaA.records.now = func() time.Time { return now }
+	replicaB.records.now = func() time.Time { return now }
+
+	_, err := replicaB.Get(ctx, "jane")
+	require.ErrorIs(t, err, auth.ErrUserNotFound)
+	require.ErrorIs(t, replicaA.Update(ctx, cfg.User{Username: "jane"}), auth.ErrUserNotFound)
+
+	require.NoError(t, replicaA.Create(ctx, cfg.User{ID: "1", Username: "jane", PasswordHash: "h1", Role: "developer"}))
+	require.NoError(t, replicaA.Create(ctx, cfg.User{ID: "2", Username: "bob", PasswordHash: "h2", Role: "operator"}))
+	require.ErrorIs(t, replicaA.Create(ctx, cfg.User{ID: "3", Username: "jane"}), auth.ErrUserExists)
+	user, err := replicaA.Get(ctx, "jane")
+	require.NoError(t, err)
+	require.Equal(t, "developer", user.Role)
+
+	// A replica that just missed a user looks again once a second, so users
+	// created through another replica can log in right away.
+	_, err = replicaB.Get(ctx, "jane")
+	require.ErrorIs(t, err, auth.ErrUserNotFound)
+	now = now.Add(recordMissSyncInterval)
+	user, err = replicaB.Get(ctx, "jane")
+	require.NoError(t, err)
+	require.Equal(t, "h1", user.PasswordHash)
+
+	// Changes reach other replicas once their copy is refreshed.
+	user.PasswordHash = "h3"
+	require.NoError(t, replicaB.Update(ctx, *user))
+	user, err = replicaA.Get(ctx, "jane")
+	require.NoError(t, err)
+	require.Equal(t, "h1", user.PasswordHash)
+	now = now.Add(recordSyncInterval)
+	user, err = replicaA.Get(ctx, "jane")
+	require.NoError(t, err)
+	require.Equal(t, "h3", user.PasswordHash)
+
+	users, err := replicaB.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "bob", users[0].Username)
+
+	require.NoError(t, replicaA.Delete(ctx, "bob"))
+	require.ErrorIs(t, replicaA.Delete(ctx, "bob"), auth.ErrUserNotFound)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "system", Name: DefaultUserSecret}, secret))
+	require.Len(t, secret.Data, 1)
+	require.Contains(t, string(secret.Data["jane"]), `"pwd":"h3"`)
+}
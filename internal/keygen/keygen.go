@@ -0,0 +1,252 @@
+// Package keygen generates the values of AutoGenerated claims: passwords following
+// a PasswordPolicy, SSH key pairs, raw random bytes and JWK signing keys.
+//
+// Key pair formats return the private key for the data key itself and the public
+// key for the companion key named <key>.pub.
+package keygen
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	FormatPassword    = "password"
+	FormatSSHEd25519  = "ssh-ed25519"
+	FormatSSHRSA      = "ssh-rsa"
+	FormatRandomBytes = "random-bytes"
+	FormatJWKRSA      = "jwk-rsa"
+	FormatJWKEC       = "jwk-ec"
+	FormatJWKEd25519  = "jwk-ed25519"
+
+	// PublicKeySuffix is appended to a data key to name its public key.
+	PublicKeySuffix = ".pub"
+
+	// DefaultRSABits is used for ssh-rsa and jwk-rsa keys without Bits.
+	DefaultRSABits = 3072
+)
+
+// Formats lists the supported generation formats.
+var Formats = []string{
+	FormatPassword,
+	FormatSSHEd25519,
+	FormatSSHRSA,
+	FormatRandomBytes,
+	FormatJWKRSA,
+	FormatJWKEC,
+	FormatJWKEd25519,
+}
+
+// IsKeyPair reports whether format also produces a public key.
+func IsKeyPair(format string) bool {
+	return format != "" && format != FormatPassword && format != FormatRandomBytes
+}
+
+// IsSSH reports whether format produces an OpenSSH key pair.
+func IsSSH(format string) bool {
+	return format == FormatSSHEd25519 || format == FormatSSHRSA
+}
+
+// PublicKey returns the name of the data key holding the public key of key.
+func PublicKey(key string) string {
+	return key + PublicKeySuffix
+}
+
+// Validate checks format and the RSA key size bits.
+func Validate(format string, bits int) error {
+	if format != "" && !slices.Contains(Formats, format) {
+		return fmt.Errorf("unsupported key format %q, use one of %s", format, strings.Join(Formats, ", "))
+	}
+	if bits == 0 {
+		return nil
+	}
+	if format != FormatSSHRSA && format != FormatJWKRSA {
+		return fmt.Errorf("bits is only supported for %s and %s keys", FormatSSHRSA, FormatJWKRSA)
+	}
+	if bits != 2048 && bits != 3072 && bits != 4096 {
+		return fmt.Errorf("unsupported RSA key size %d, use 2048, 3072 or 4096", bits)
+	}
+	return nil
+}
+
+// Generate creates a value in format. length is the number of random bytes for
+// random-bytes, bits the RSA key size. public is nil unless IsKeyPair(format).
+// Passwords are built by Password.
+func Generate(format string, bits, length int) (private, public []byte, err error) {
+	if err := Validate(format, bits); err != nil {
+		return nil, nil, err
+	}
+	if bits == 0 {
+		bits = DefaultRSABits
+	}
+
+	switch format {
+	case FormatRandomBytes:
+		private = make([]byte, length)
+		if _, err := rand.Read(private); err != nil {
+			return nil, nil, err
+		}
+		return private, nil, nil
+	case FormatSSHEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sshKeyPair(key)
+	case FormatSSHRSA:
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sshKeyPair(key)
+	case FormatJWKRSA:
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, nil, err
+		}
+		return jwkPair(key)
+	case FormatJWKEC:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return jwkPair(key)
+	case FormatJWKEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return jwkPair(key)
+	}
+	return nil, nil, fmt.Errorf("format %q is not generated by keygen", format)
+}
+
+// sshKeyPair encodes key in the OpenSSH private key format and its public key
+// as an authorized_keys line.
+func sshKeyPair(key crypto.Signer) ([]byte, []byte, error) {
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode ssh private key: %w", err)
+	}
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode ssh public key: %w", err)
+	}
+	return pem.EncodeToMemory(block), ssh.MarshalAuthorizedKey(pub), nil
+}
+
+// JWK is a JSON Web Key (RFC 7517) for the RSA, EC P-256 and Ed25519 keys
+// generated here. Private members are empty in public keys.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	Dp  string `json:"dp,omitempty"`
+	Dq  string `json:"dq,omitempty"`
+	Qi  string `json:"qi,omitempty"`
+}
+
+// NewJWK returns the private JWK of key; Public strips it to the public key.
+// The key ID is the RFC 7638 thumbprint.
+func NewJWK(key crypto.Signer) (JWK, error) {
+	var jwk JWK
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		k.Precompute()
+		jwk = JWK{
+			Kty: "RSA", Alg: "RS256",
+			N:  b64(k.N.Bytes()),
+			E:  b64(big.NewInt(int64(k.E)).Bytes()),
+			D:  b64(k.D.Bytes()),
+			P:  b64(k.Primes[0].Bytes()),
+			Q:  b64(k.Primes[1].Bytes()),
+			Dp: b64(k.Precomputed.Dp.Bytes()),
+			Dq: b64(k.Precomputed.Dq.Bytes()),
+			Qi: b64(k.Precomputed.Qinv.Bytes()),
+		}
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return JWK{}, fmt.Errorf("unsupported curve %s", k.Curve.Params().Name)
+		}
+		jwk = JWK{
+			Kty: "EC", Alg: "ES256", Crv: "P-256",
+			X: b64(k.X.FillBytes(make([]byte, 32))),
+			Y: b64(k.Y.FillBytes(make([]byte, 32))),
+			D: b64(k.D.FillBytes(make([]byte, 32))),
+		}
+	case ed25519.PrivateKey:
+		jwk = JWK{
+			Kty: "OKP", Alg: "EdDSA", Crv: "Ed25519",
+			X: b64(k.Public().(ed25519.PublicKey)),
+			D: b64(k.Seed()),
+		}
+	default:
+		return JWK{}, fmt.Errorf("unsupported key type %T", key)
+	}
+	jwk.Use = "sig"
+	jwk.Kid = jwk.thumbprint()
+	return jwk, nil
+}
+
+// Public returns the public members of the key.
+func (j JWK) Public() JWK {
+	return JWK{Kty: j.Kty, Kid: j.Kid, Use: j.Use, Alg: j.Alg, Crv: j.Crv, N: j.N, E: j.E, X: j.X, Y: j.Y}
+}
+
+// thumbprint computes the RFC 7638 thumbprint over the required public members.
+func (j JWK) thumbprint() string {
+	var members string
+	switch j.Kty {
+	case "RSA":
+		members = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, j.E, j.N)
+	case "EC":
+		members = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, j.Crv, j.X, j.Y)
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":"OKP","x":%q}`, j.Crv, j.X)
+	}
+	sum := sha256.Sum256([]byte(members))
+	return b64(sum[:])
+}
+
+func jwkPair(key crypto.Signer) ([]byte, []byte, error) {
+	jwk, err := NewJWK(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	private, err := json.Marshal(jwk)
+	if err != nil {
+		return nil, nil, err
+	}
+	public, err := json.Marshal(jwk.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+	return private, public, nil
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,169 @@
+package keygen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	EncodingDigits       = "digits"
+	EncodingAlphanumeric = "alphanumeric"
+	EncodingSymbols      = "symbols"
+
+	CharsetDigits    = "0123456789"
+	CharsetLowercase = "abcdefghijklmnopqrstuvwxyz"
+	CharsetUppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	CharsetSymbols   = "!@#$%^&*()-_=+[]{}|;:,.<>?/"
+
+	// AmbiguousCharacters are dropped from the charset by ExcludeAmbiguous.
+	AmbiguousCharacters = "0O1lI|"
+)
+
+// Charset returns the characters passwords with encoding are built from.
+func Charset(encoding string) string {
+	switch strings.ToLower(encoding) {
+	case EncodingDigits:
+		return CharsetDigits
+	case EncodingSymbols:
+		return CharsetDigits + CharsetLowercase + CharsetUppercase + CharsetSymbols
+	default:
+		return CharsetDigits + CharsetLowercase + CharsetUppercase
+	}
+}
+
+// PasswordPolicy describes a generated password. Length counts the random
+// characters; Prefix is prepended to them as is.
+type PasswordPolicy struct {
+	Length           int
+	Charset          string
+	MinDigits        int
+	MinSymbols       int
+	MinUppercase     int
+	ExcludeAmbiguous bool
+	Prefix           string
+}
+
+// HasRules reports whether p sets anything besides Length, which only applies
+// to passwords.
+func (p PasswordPolicy) HasRules() bool {
+	return p.Charset != "" || p.MinDigits != 0 || p.MinSymbols != 0 || p.MinUppercase != 0 || p.ExcludeAmbiguous || p.Prefix != ""
+}
+
+// Validate checks that a password satisfying p can be built.
+func (p PasswordPolicy) Validate() error {
+	if p.MinDigits < 0 || p.MinSymbols < 0 || p.MinUppercase < 0 {
+		return errors.New("minimum character counts must not be negative")
+	}
+	if p.MinDigits+p.MinSymbols+p.MinUppercase > p.Length {
+		return fmt.Errorf("minimum character counts exceed the length %d", p.Length)
+	}
+
+	charset := p.charset()
+	if len(charset) == 0 {
+		return errors.New("charset is empty")
+	}
+	for _, class := range p.classes(charset) {
+		if class.min > 0 && len(class.pool) == 0 {
+			return fmt.Errorf("charset has no %s characters", class.name)
+		}
+	}
+	return nil
+}
+
+// Password generates a password satisfying p.
+func Password(p PasswordPolicy) (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	charset := p.charset()
+	result := make([]rune, 0, p.Length)
+	for _, class := range p.classes(charset) {
+		for range class.min {
+			r, err := pick(class.pool)
+			if err != nil {
+				return "", err
+			}
+			result = append(result, r)
+		}
+	}
+	for len(result) < p.Length {
+		r, err := pick(charset)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, r)
+	}
+
+	// Required characters were added first, shuffle them into random positions.
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		result[i], result[j.Int64()] = result[j.Int64()], result[i]
+	}
+
+	return p.Prefix + string(result), nil
+}
+
+type charClass struct {
+	name string
+	min  int
+	pool []rune
+}
+
+func (p PasswordPolicy) classes(charset []rune) []charClass {
+	return []charClass{
+		{name: "digit", min: p.MinDigits, pool: filter(charset, func(r rune) bool { return strings.ContainsRune(CharsetDigits, r) })},
+		{name: "uppercase", min: p.MinUppercase, pool: filter(charset, func(r rune) bool { return strings.ContainsRune(CharsetUppercase, r) })},
+		{name: "symbol", min: p.MinSymbols, pool: filter(charset, isSymbol)},
+	}
+}
+
+// charset returns the distinct characters of the policy's charset.
+func (p PasswordPolicy) charset() []rune {
+	source := p.Charset
+	if source == "" {
+		source = Charset("")
+	}
+
+	seen := make(map[rune]struct{}, len(source))
+	var result []rune
+	for _, r := range source {
+		if _, ok := seen[r]; ok {
+			continue
+		}
+		if p.ExcludeAmbiguous && strings.ContainsRune(AmbiguousCharacters, r) {
+			continue
+		}
+		seen[r] = struct{}{}
+		result = append(result, r)
+	}
+	return result
+}
+
+func isSymbol(r rune) bool {
+	return !strings.ContainsRune(CharsetDigits+CharsetLowercase+CharsetUppercase, r)
+}
+
+func filter(charset []rune, keep func(rune) bool) []rune {
+	var result []rune
+	for _, r := range charset {
+		if keep(r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func pick(pool []rune) (rune, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(pool))))
+	if err != nil {
+		return 0, err
+	}
+	return pool[n.Int64()], nil
+}
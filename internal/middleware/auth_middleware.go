@@ -9,20 +9,39 @@ import (
 	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
 )
 
-func JWTAuthMiddleware(jwtSecret string) func(next http.Handler) http.Handler {
+// APIKeyHeader carries an API key instead of the Authorization header.
+const APIKeyHeader = "X-API-Key"
+
+// JWTAuthMiddleware accepts tokens issued by /user/auth, tokens of the
+// verifiers that handle them, such as OIDC ID tokens and ServiceAccount
+// tokens, and, when apiKeys is not nil, API keys sent in the X-API-Key header
+// or as bearer tokens. Tokens whose ID is in revocations are rejected.
+func JWTAuthMiddleware(keys *auth.KeySet, revocations auth.RevocationStore, apiKeys auth.APIKeyStore, verifiers ...auth.TokenVerifier) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := r.Header.Get("Authorization")
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+				token = "Bearer " + apiKey
+			}
 			if token == "" || !strings.HasPrefix(token, "Bearer ") {
 				sendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Missing or invalid Authorization header.")
 				return
 			}
-			if jwtSecret == "" {
-				sendErrorResponse(w, http.StatusInternalServerError, "InternalError", "JWT Secret is empty")
+			if keys == nil {
+				sendErrorResponse(w, http.StatusInternalServerError, "InternalError", "JWT keys are not configured")
 				return
 			}
 
-			claims, err := GetClaimsFromToken(token, jwtSecret)
+			var claims *auth.Claims
+			var err error
+			rawToken := strings.TrimPrefix(token, "Bearer ")
+			if verifier := verifierFor(verifiers, rawToken); verifier != nil {
+				claims, err = verifier.Verify(r.Context(), rawToken)
+			} else if apiKeys != nil && auth.IsAPIKey(rawToken) {
+				claims, err = auth.AuthenticateAPIKey(r.Context(), apiKeys, rawToken)
+			} else {
+				claims, err = GetClaimsFromToken(token, keys)
+			}
 
 			if err != nil {
 				if errors.Is(err, auth.ErrUnauthorizedToken) {
@@ -34,8 +53,29 @@ func JWTAuthMiddleware(jwtSecret string) func(next http.Handler) http.Handler {
 				return
 			}
 
+			if claims.ID != "" {
+				revoked, err := revocations.IsRevoked(r.Context(), claims.ID)
+				if err != nil {
+					sendErrorResponse(w, http.StatusInternalServerError, "InternalError", "Server error during authentication.")
+					return
+				}
+				if revoked {
+					sendErrorResponse(w, http.StatusUnauthorized, "Unauthorized", "Token has been revoked.")
+					return
+				}
+			}
+
 			ctx := context.WithValue(r.Context(), auth.ClaimsContextKey, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+func verifierFor(verifiers []auth.TokenVerifier, token string) auth.TokenVerifier {
+	for _, v := range verifiers {
+		if v.Handles(token) {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+// Package policy decides which REST API calls a user may make. Roles are
+// lists of rules granting verbs on resources, optionally limited to namespace
+// glob patterns and to claims matching a label selector. Decisions can also be
+// delegated to the cluster with SubjectAccessReviews.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	VerbList   = "list"
+	VerbGet    = "get"
+	VerbReveal = "reveal"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbRotate = "rotate"
+	VerbDelete = "delete"
+)
+
+const (
+	ResourceSecrets        = "secrets"
+	ResourceClusterSecrets = "clustersecrets"
+	ResourceAPIKeys        = "apikeys"
+	ResourceUsers          = "users"
+)
+
+// Wildcard matches every verb or resource in a rule.
+const Wildcard = "*"
+
+var (
+	verbs     = []string{VerbList, VerbGet, VerbReveal, VerbCreate, VerbUpdate, VerbRotate, VerbDelete}
+	resources = []string{ResourceSecrets, ResourceClusterSecrets, ResourceAPIKeys, ResourceUsers}
+)
+
+// IsVerb reports whether verb is a verb rules can grant.
+func IsVerb(verb string) bool {
+	return slices.Contains(verbs, verb)
+}
+
+// clusterScoped reports whether resource has no namespace, so that the allowed
+// namespaces of the user do not apply.
+func clusterScoped(resource string) bool {
+	return resource == ResourceClusterSecrets || resource == ResourceAPIKeys || resource == ResourceUsers
+}
+
+// DefaultRoles reproduce the fixed admin/operator/developer split: admins manage
+// every resource, operators manage SecretClaims and developers only read them.
+// Revealing values is granted per user with the secrets:reveal permission.
+var DefaultRoles = []cfg.Role{
+	{Name: "admin", Rules: []cfg.PolicyRule{{
+		Resources: []string{Wildcard},
+		Verbs:     []string{VerbList, VerbGet, VerbCreate, VerbUpdate, VerbRotate, VerbDelete},
+	}}},
+	{Name: "operator", Rules: []cfg.PolicyRule{{
+		Resources: []string{ResourceSecrets},
+		Verbs:     []string{VerbList, VerbGet, VerbCreate, VerbUpdate, VerbRotate, VerbDelete},
+	}}},
+	{Name: "developer", Rules: []cfg.PolicyRule{{
+		Resources: []string{ResourceSecrets},
+		Verbs:     []string{VerbList, VerbGet},
+	}}},
+}
+
+// RoleWithin reports whether role grants nothing that within does not, so
+// that a user with within can hand role out, to an API key or another user,
+// without gaining anything. roles are the configured roles on top of
+// DefaultRoles. Namespace patterns and label selectors are compared literally,
+// so some roles that are within another one are reported as not.
+func RoleWithin(roles []cfg.Role, role, within string) bool {
+	defined := make(map[string][]cfg.PolicyRule)
+	for _, r := range append(slices.Clone(DefaultRoles), roles...) {
+		defined[r.Name] = r.Rules
+	}
+	inner, ok := defined[role]
+	if !ok {
+		return false
+	}
+	outer, ok := defined[within]
+	if !ok {
+		return false
+	}
+	if role == within {
+		return true
+	}
+	for _, r := range inner {
+		for _, verb := range expand(r.Verbs, verbs) {
+			for _, resource := range expand(r.Resources, resources) {
+				granted := slices.ContainsFunc(outer, func(o cfg.PolicyRule) bool {
+					return matches(o.Verbs, verb) && matches(o.Resources, resource) &&
+						(clusterScoped(resource) || namespacesWithin(r.Namespaces, o.Namespaces)) &&
+						(o.LabelSelector == "" || o.LabelSelector == r.LabelSelector)
+				})
+				if !granted {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// expand replaces the wildcard in values with all.
+func expand(values, all []string) []string {
+	if slices.Contains(values, Wildcard) {
+		return all
+	}
+	return values
+}
+
+// namespacesWithin reports whether every namespace matched by the patterns of
+// inner is matched by one of outer.
+func namespacesWithin(inner, outer []string) bool {
+	if len(outer) == 0 {
+		return true
+	}
+	if len(inner) == 0 {
+		return false
+	}
+	for _, pattern := range inner {
+		literal := !strings.ContainsAny(pattern, `*?[\`)
+		if !slices.ContainsFunc(outer, func(o string) bool {
+			matched, _ := path.Match(o, pattern)
+			return o == pattern || (literal && matched)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Attributes describe a single API call.
+type Attributes struct {
+	Verb     string
+	Resource string
+	// Namespace is empty for cluster-scoped resources.
+	Namespace string
+	// Labels are the labels of the claim the call acts on.
+	Labels map[string]string
+}
+
+// Authorizer decides whether the user of claims may make an API call.
+type Authorizer interface {
+	// Authorize reports whether the call described by attrs is allowed.
+	Authorize(ctx context.Context, claims *auth.Claims, attrs Attributes) bool
+	// AllowsNamespace reports whether verb on resource could be allowed in
+	// namespace, leaving label selectors aside. Handlers check it before reading
+	// the claim and call Authorize with the claim labels afterwards.
+	AllowsNamespace(ctx context.Context, claims *auth.Claims, verb, resource, namespace string) bool
+}
+
+type rule struct {
+	verbs      []string
+	resources  []string
+	namespaces []string
+	selector   labels.Selector
+}
+
+// RoleAuthorizer evaluates Attributes against the configured roles.
+type RoleAuthorizer struct {
+	roles map[string][]rule
+}
+
+// NewRoleAuthorizer compiles roles on top of DefaultRoles. It fails on unknown
+// verbs or resources, bad namespace patterns and bad label selectors.
+func NewRoleAuthorizer(roles []cfg.Role) (*RoleAuthorizer, error) {
+	a := &RoleAuthorizer{roles: make(map[string][]rule)}
+	for _, role := range append(slices.Clone(DefaultRoles), roles...) {
+		if role.Name == "" {
+			return nil, fmt.Errorf("role without a name")
+		}
+		compiled := make([]rule, 0, len(role.Rules))
+		for i, r := range role.Rules {
+			c, err := compileRule(r)
+			if err != nil {
+				return nil, fmt.Errorf("role %s rule %d: %w", role.Name, i, err)
+			}
+			compiled = append(compiled, c)
+		}
+		a.roles[role.Name] = compiled
+	}
+	return a, nil
+}
+
+func compileRule(r cfg.PolicyRule) (rule, error) {
+	if len(r.Verbs) == 0 || len(r.Resources) == 0 {
+		return rule{}, fmt.Errorf("verbs and resources are required")
+	}
+	for _, verb := range r.Verbs {
+		if verb != Wildcard && !slices.Contains(verbs, verb) {
+			return rule{}, fmt.Errorf("unknown verb %q", verb)
+		}
+	}
+	for _, resource := range r.Resources {
+		if resource != Wildcard && !slices.Contains(resources, resource) {
+			return rule{}, fmt.Errorf("unknown resource %q", resource)
+		}
+	}
+	for _, pattern := range r.Namespaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return rule{}, fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+	selector := labels.Everything()
+	if r.LabelSelector != "" {
+		parsed, err := labels.Parse(r.LabelSelector)
+		if err != nil {
+			return rule{}, fmt.Errorf("invalid label selector %q: %w", r.LabelSelector, err)
+		}
+		selector = parsed
+	}
+	return rule{
+		verbs:      r.Verbs,
+		resources:  r.Resources,
+		namespaces: r.Namespaces,
+		selector:   selector,
+	}, nil
+}
+
+// Authorize reports whether a rule of the user's role allows the call.
+// Namespaced calls also need the namespace in the user's allowed namespaces,
+// and API keys need the verb among their verbs.
+func (a *RoleAuthorizer) Authorize(_ context.Context, claims *auth.Claims, attrs Attributes) bool {
+	return a.authorize(claims, attrs, true)
+}
+
+// AllowsNamespace reports whether some rule could allow verb on resource in
+// namespace.
+func (a *RoleAuthorizer) AllowsNamespace(_ context.Context, claims *auth.Claims, verb, resource, namespace string) bool {
+	return a.authorize(claims, Attributes{Verb: verb, Resource: resource, Namespace: namespace}, false)
+}
+
+func (a *RoleAuthorizer) authorize(claims *auth.Claims, attrs Attributes, matchLabels bool) bool {
+	if !clusterScoped(attrs.Resource) && !auth.IsNamespaceAllowed(attrs.Namespace, claims.AllowedNamespaces) {
+		return false
+	}
+	verb := attrs.Verb
+	if !claims.AllowsVerb(verb) {
+		return false
+	}
+	// The secrets:reveal permission predates roles and still grants reveal
+	// wherever the role grants get.
+	if verb == VerbReveal && claims.HasPermission(auth.PermissionRevealSecrets) && a.authorize(claims, withVerb(attrs, VerbGet), matchLabels) {
+		return true
+	}
+	for _, r := range a.roles[claims.Role] {
+		if !matches(r.verbs, verb) || !matches(r.resources, attrs.Resource) {
+			continue
+		}
+		if !clusterScoped(attrs.Resource) && !namespaceMatches(r.namespaces, attrs.Namespace) {
+			continue
+		}
+		if matchLabels && !r.selector.Matches(labels.Set(attrs.Labels)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func withVerb(attrs Attributes, verb string) Attributes {
+	attrs.Verb = verb
+	return attrs
+}
+
+func matches(values []string, value string) bool {
+	return slices.Contains(values, Wildcard) || slices.Contains(values, value)
+}
+
+func namespaceMatches(patterns []string, namespace string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns an Authorizer that allows a call only if every authorizer does.
+func All(authorizers ...Authorizer) Authorizer {
+	return allOf(authorizers)
+}
+
+type allOf []Authorizer
+
+func (a allOf) Authorize(ctx context.Context, claims *auth.Claims, attrs Attributes) bool {
+	for _, authorizer := range a {
+		if !authorizer.Authorize(ctx, claims, attrs) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allOf) AllowsNamespace(ctx context.Context, claims *auth.Claims, verb, resource, namespace string) bool {
+	for _, authorizer := range a {
+		if !authorizer.AllowsNamespace(ctx, claims, verb, resource, namespace) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+)
+
+func TestAuthorize_DefaultRoles(t *testing.T) {
+	a, err := NewRoleAuthorizer(nil)
+	if err != nil {
+		t.Fatalf("NewRoleAuthorizer: %v", err)
+	}
+
+	admin := &auth.Claims{Role: "admin", AllowedNamespaces: []string{"*"}}
+	operator := &auth.Claims{Role: "operator", AllowedNamespaces: []string{"team-a"}}
+	developer := &auth.Claims{Role: "developer", AllowedNamespaces: []string{"team-a"}}
+	unknown := &auth.Claims{Role: "editor", AllowedNamespaces: []string{"*"}}
+	apiKey := &auth.Claims{Role: "operator", AllowedNamespaces: []string{"team-a"}, Verbs: []string{VerbGet, VerbRotate}}
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		attrs  Attributes
+		want   bool
+	}{
+		{"admin creates cluster secrets", admin, Attributes{Verb: VerbCreate, Resource: ResourceClusterSecrets}, true},
+		{"admin needs permission to reveal", admin, Attributes{Verb: VerbReveal, Resource: ResourceSecrets, Namespace: "x"}, false},
+		{"operator deletes in own namespace", operator, Attributes{Verb: VerbDelete, Resource: ResourceSecrets, Namespace: "team-a"}, true},
+		{"operator outside allowed namespaces", operator, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-b"}, false},
+		{"operator on cluster secrets", operator, Attributes{Verb: VerbList, Resource: ResourceClusterSecrets}, false},
+		{"developer reads", developer, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-a"}, true},
+		{"developer cannot update", developer, Attributes{Verb: VerbUpdate, Resource: ResourceSecrets, Namespace: "team-a"}, false},
+		{"developer cannot rotate", developer, Attributes{Verb: VerbRotate, Resource: ResourceSecrets, Namespace: "team-a"}, false},
+		{"unknown role", unknown, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-a"}, false},
+		{"admin manages api keys", admin, Attributes{Verb: VerbDelete, Resource: ResourceAPIKeys}, true},
+		{"operator cannot manage api keys", operator, Attributes{Verb: VerbCreate, Resource: ResourceAPIKeys}, false},
+		{"admin manages users", admin, Attributes{Verb: VerbUpdate, Resource: ResourceUsers}, true},
+		{"developer cannot list users", developer, Attributes{Verb: VerbList, Resource: ResourceUsers}, false},
+		{"api key within its verbs", apiKey, Attributes{Verb: VerbRotate, Resource: ResourceSecrets, Namespace: "team-a"}, true},
+		{"api key outside its verbs", apiKey, Attributes{Verb: VerbDelete, Resource: ResourceSecrets, Namespace: "team-a"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Authorize(context.Background(), tt.claims, tt.attrs); got != tt.want {
+				t.Errorf("Authorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize_RevealPermission(t *testing.T) {
+	a, err := NewRoleAuthorizer(nil)
+	if err != nil {
+		t.Fatalf("NewRoleAuthorizer: %v", err)
+	}
+
+	developer := &auth.Claims{Role: "developer", AllowedNamespaces: []string{"team-a"}, Permissions: []string{auth.PermissionRevealSecrets}}
+	if !a.Authorize(context.Background(), developer, Attributes{Verb: VerbReveal, Resource: ResourceSecrets, Namespace: "team-a"}) {
+		t.Error("secrets:reveal should grant reveal where the role grants get")
+	}
+	if a.Authorize(context.Background(), developer, Attributes{Verb: VerbReveal, Resource: ResourceClusterSecrets}) {
+		t.Error("secrets:reveal should not grant reveal where the role does not grant get")
+	}
+}
+
+func TestAuthorize_ConfiguredRoles(t *testing.T) {
+	a, err := NewRoleAuthorizer([]cfg.Role{
+		{Name: "rotator", Rules: []cfg.PolicyRule{{
+			Resources:     []string{ResourceSecrets},
+			Verbs:         []string{VerbList, VerbGet, VerbRotate},
+			Namespaces:    []string{"team-*"},
+			LabelSelector: "rotation=managed",
+		}}},
+		{Name: "auditor", Rules: []cfg.PolicyRule{{
+			Resources: []string{Wildcard},
+			Verbs:     []string{VerbList, VerbGet},
+		}}},
+		{Name: "developer", Rules: []cfg.PolicyRule{{
+			Resources: []string{ResourceSecrets},
+			Verbs:     []string{Wildcard},
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("NewRoleAuthorizer: %v", err)
+	}
+
+	rotator := &auth.Claims{Role: "rotator", AllowedNamespaces: []string{"*"}}
+	auditor := &auth.Claims{Role: "auditor", AllowedNamespaces: []string{"*"}}
+	developer := &auth.Claims{Role: "developer", AllowedNamespaces: []string{"dev"}}
+	managed := map[string]string{"rotation": "managed"}
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		attrs  Attributes
+		want   bool
+	}{
+		{"rotator rotates matching claim", rotator, Attributes{Verb: VerbRotate, Resource: ResourceSecrets, Namespace: "team-a", Labels: managed}, true},
+		{"rotator outside namespace pattern", rotator, Attributes{Verb: VerbRotate, Resource: ResourceSecrets, Namespace: "prod", Labels: managed}, false},
+		{"rotator on unlabelled claim", rotator, Attributes{Verb: VerbRotate, Resource: ResourceSecrets, Namespace: "team-a"}, false},
+		{"rotator cannot update", rotator, Attributes{Verb: VerbUpdate, Resource: ResourceSecrets, Namespace: "team-a", Labels: managed}, false},
+		{"auditor lists cluster secrets", auditor, Attributes{Verb: VerbList, Resource: ResourceClusterSecrets}, true},
+		{"auditor cannot reveal", auditor, Attributes{Verb: VerbReveal, Resource: ResourceSecrets, Namespace: "team-a"}, false},
+		{"configured developer replaces built-in", developer, Attributes{Verb: VerbDelete, Resource: ResourceSecrets, Namespace: "dev"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.Authorize(context.Background(), tt.claims, tt.attrs); got != tt.want {
+				t.Errorf("Authorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !a.AllowsNamespace(context.Background(), rotator, VerbRotate, ResourceSecrets, "team-a") {
+		t.Error("AllowsNamespace should leave label selectors aside")
+	}
+}
+
+func TestRoleWithin(t *testing.T) {
+	roles := []cfg.Role{
+		{Name: "team-operator", Rules: []cfg.PolicyRule{{
+			Resources:  []string{ResourceSecrets},
+			Verbs:      []string{VerbList, VerbGet, VerbCreate, VerbUpdate, VerbRotate, VerbDelete},
+			Namespaces: []string{"team-*"},
+		}}},
+		{Name: "team-revealer", Rules: []cfg.PolicyRule{{
+			Resources:  []string{ResourceSecrets},
+			Verbs:      []string{Wildcard},
+			Namespaces: []string{"team-*"},
+		}}},
+		{Name: "team-a-reader", Rules: []cfg.PolicyRule{{
+			Resources:  []string{ResourceSecrets},
+			Verbs:      []string{VerbList, VerbGet},
+			Namespaces: []string{"team-a"},
+		}}},
+		{Name: "managed-rotator", Rules: []cfg.PolicyRule{{
+			Resources:     []string{ResourceSecrets},
+			Verbs:         []string{VerbRotate},
+			LabelSelector: "rotation=managed",
+		}}},
+		{Name: "key-manager", Rules: []cfg.PolicyRule{
+			{Resources: []string{ResourceAPIKeys}, Verbs: []string{VerbCreate}},
+			{Resources: []string{ResourceAPIKeys}, Verbs: []string{VerbList}},
+		}},
+	}
+
+	tests := []struct {
+		role, within string
+		want         bool
+	}{
+		{"developer", "operator", true},
+		{"operator", "operator", true},
+		{"operator", "developer", false},
+		{"admin", "operator", false},
+		{"operator", "admin", true},
+		{"team-a-reader", "team-operator", true},
+		{"team-operator", "team-a-reader", false},
+		{"team-operator", "operator", true},
+		{"operator", "team-operator", false},
+		{"team-revealer", "operator", false},
+		{"managed-rotator", "operator", true},
+		{"operator", "managed-rotator", false},
+		{"key-manager", "admin", true},
+		{"key-manager", "operator", false},
+		{"developer", "unknown", false},
+		{"unknown", "admin", false},
+	}
+	for _, tt := range tests {
+		if got := RoleWithin(roles, tt.role, tt.within); got != tt.want {
+			t.Errorf("RoleWithin(%s, %s) = %v, want %v", tt.role, tt.within, got, tt.want)
+		}
+	}
+}
+
+func TestNewRoleAuthorizer_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule cfg.PolicyRule
+	}{
+		{"unknown verb", cfg.PolicyRule{Resources: []string{ResourceSecrets}, Verbs: []string{"patch"}}},
+		{"unknown resource", cfg.PolicyRule{Resources: []string{"configmaps"}, Verbs: []string{VerbGet}}},
+		{"no verbs", cfg.PolicyRule{Resources: []string{ResourceSecrets}}},
+		{"bad namespace pattern", cfg.PolicyRule{Resources: []string{ResourceSecrets}, Verbs: []string{VerbGet}, Namespaces: []string{"team-["}}},
+		{"bad label selector", cfg.PolicyRule{Resources: []string{ResourceSecrets}, Verbs: []string{VerbGet}, LabelSelector: "a=b=c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRoleAuthorizer([]cfg.Role{{Name: "broken", Rules: []cfg.PolicyRule{tt.rule}}}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
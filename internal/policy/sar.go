@@ -0,0 +1,177 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultReviewCacheTTL is used when no cache TTL is configured.
+const DefaultReviewCacheTTL = 30 * time.Second
+
+// maxReviewCacheEntries bounds the decision cache; expired entries are dropped
+// once it is reached.
+const maxReviewCacheEntries = 4096
+
+// reviewResources maps API resources to the Kubernetes resources the cluster
+// RBAC grants verbs on. Verbs are passed through, so reveal and rotate are
+// custom verbs on secretclaims. apikeys and users are not real resources;
+// ClusterRoles grant them by name in the secretclaims API group.
+var reviewResources = map[string]string{
+	ResourceSecrets:        "secretclaims",
+	ResourceClusterSecrets: "clustersecretclaims",
+	ResourceAPIKeys:        "apikeys",
+	ResourceUsers:          "users",
+}
+
+// SubjectAccessReviewAuthorizer asks the cluster whether the Kubernetes user
+// of the caller may perform the verb on secretclaims. Claim labels are not
+// part of the review.
+type SubjectAccessReviewAuthorizer struct {
+	client client.Client
+	ttl    time.Duration
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu    sync.Mutex
+	cache map[reviewKey]reviewDecision
+}
+
+type reviewKey struct {
+	user      string
+	groups    string
+	verb      string
+	resource  string
+	namespace string
+}
+
+type reviewDecision struct {
+	allowed bool
+	expires time.Time
+}
+
+// NewSubjectAccessReviewAuthorizer returns an authorizer that caches decisions
+// for ttl, or DefaultReviewCacheTTL when ttl is zero.
+func NewSubjectAccessReviewAuthorizer(c client.Client, ttl time.Duration, logger *slog.Logger) *SubjectAccessReviewAuthorizer {
+	if ttl <= 0 {
+		ttl = DefaultReviewCacheTTL
+	}
+	return &SubjectAccessReviewAuthorizer{
+		client: c,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+		cache:  make(map[reviewKey]reviewDecision),
+	}
+}
+
+// Authorize reviews the call; a failed review denies it.
+func (a *SubjectAccessReviewAuthorizer) Authorize(ctx context.Context, claims *auth.Claims, attrs Attributes) bool {
+	return a.AllowsNamespace(ctx, claims, attrs.Verb, attrs.Resource, attrs.Namespace)
+}
+
+// AllowsNamespace reviews verb on resource in namespace. The namespace "*"
+// stands for all namespaces. Claims that carry allowed namespaces, such as
+// those of API keys and ServiceAccount bindings, are limited to them before the
+// review.
+func (a *SubjectAccessReviewAuthorizer) AllowsNamespace(ctx context.Context, claims *auth.Claims, verb, resource, namespace string) bool {
+	if !claims.AllowsVerb(verb) {
+		return false
+	}
+	if len(claims.AllowedNamespaces) > 0 && !clusterScoped(resource) && !auth.IsNamespaceAllowed(namespace, claims.AllowedNamespaces) {
+		return false
+	}
+	if namespace == "*" || clusterScoped(resource) {
+		namespace = ""
+	}
+	groups := slices.Clone(claims.KubernetesGroups)
+	slices.Sort(groups)
+	key := reviewKey{
+		user:      kubernetesUser(claims),
+		groups:    strings.Join(groups, "\n"),
+		verb:      verb,
+		resource:  reviewResources[resource],
+		namespace: namespace,
+	}
+	if key.user == "" || key.resource == "" {
+		return false
+	}
+
+	now := a.now()
+	a.mu.Lock()
+	decision, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok && now.Before(decision.expires) {
+		return decision.allowed
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   key.user,
+			Groups: groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     secretsv1alpha1.GroupVersion.Group,
+				Resource:  key.resource,
+			},
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		// Errors are not cached so that the next call retries.
+		a.logger.Error("SubjectAccessReview failed", slog.String("user", key.user), slog.String("verb", verb), slog.String("resource", key.resource), slog.String("namespace", namespace), slog.Any("error", err))
+		return false
+	}
+	allowed := review.Status.Allowed && !review.Status.Denied
+
+	a.mu.Lock()
+	if len(a.cache) >= maxReviewCacheEntries {
+		for k, d := range a.cache {
+			if !now.Before(d.expires) {
+				delete(a.cache, k)
+			}
+		}
+	}
+	a.cache[key] = reviewDecision{allowed: allowed, expires: now.Add(a.ttl)}
+	a.mu.Unlock()
+
+	if !allowed {
+		a.logger.Debug("SubjectAccessReview denied", slog.String("user", key.user), slog.String("verb", verb), slog.String("resource", key.resource), slog.String("namespace", namespace), slog.String("reason", review.Status.Reason))
+	}
+	return allowed
+}
+
+func kubernetesUser(claims *auth.Claims) string {
+	if claims.KubernetesUser != "" {
+		return claims.KubernetesUser
+	}
+	return claims.Username
+}
+
+// FromConfig builds the Authorizer for the configured authorization mode.
+func FromConfig(config cfg.AuthorizationConfig, roles []cfg.Role, c client.Client, logger *slog.Logger) (Authorizer, error) {
+	switch config.Mode {
+	case "", cfg.AuthorizationModeRoles:
+		return NewRoleAuthorizer(roles)
+	case cfg.AuthorizationModeKubernetes:
+		return NewSubjectAccessReviewAuthorizer(c, config.CacheTTL, logger), nil
+	case cfg.AuthorizationModeBoth:
+		roleAuthorizer, err := NewRoleAuthorizer(roles)
+		if err != nil {
+			return nil, err
+		}
+		return All(roleAuthorizer, NewSubjectAccessReviewAuthorizer(c, config.CacheTTL, logger)), nil
+	default:
+		return nil, fmt.Errorf("unknown authorization mode %q", config.Mode)
+	}
+}
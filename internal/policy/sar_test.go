@@ -0,0 +1,182 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/auth"
+	"github.com/mogilyoy/k8s-secret-manager/internal/cfg"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newReviewClient returns a fake client that answers SubjectAccessReviews with
+// allow and records every review it gets.
+func newReviewClient(t *testing.T, allow func(spec authorizationv1.SubjectAccessReviewSpec) bool, reviews *[]authorizationv1.SubjectAccessReviewSpec) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := authorizationv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			*reviews = append(*reviews, review.Spec)
+			review.Status.Allowed = allow(review.Spec)
+			return nil
+		},
+	}).Build()
+}
+
+func TestSubjectAccessReviewAuthorizer(t *testing.T) {
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	c := newReviewClient(t, func(spec authorizationv1.SubjectAccessReviewSpec) bool {
+		attrs := spec.ResourceAttributes
+		return spec.User == "alice@example.com" && attrs.Namespace == "team-a" && attrs.Verb == VerbGet
+	}, &reviews)
+	a := NewSubjectAccessReviewAuthorizer(c, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	claims := &auth.Claims{Username: "alice", KubernetesUser: "alice@example.com", KubernetesGroups: []string{"team-a", "devs"}}
+	ctx := context.Background()
+
+	if !a.Authorize(ctx, claims, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-a"}) {
+		t.Error("expected get in team-a to be allowed")
+	}
+	if a.Authorize(ctx, claims, Attributes{Verb: VerbDelete, Resource: ResourceSecrets, Namespace: "team-a"}) {
+		t.Error("expected delete in team-a to be denied")
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("expected 2 reviews, got %d", len(reviews))
+	}
+	got := reviews[0]
+	if got.ResourceAttributes.Group != "secrets.myapp.io" || got.ResourceAttributes.Resource != "secretclaims" {
+		t.Errorf("unexpected resource attributes: %+v", got.ResourceAttributes)
+	}
+	if len(got.Groups) != 2 || got.Groups[0] != "devs" {
+		t.Errorf("expected sorted groups, got %v", got.Groups)
+	}
+
+	// Cached decisions do not reach the cluster again, allowed or denied.
+	a.Authorize(ctx, claims, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-a"})
+	a.AllowsNamespace(ctx, claims, VerbDelete, ResourceSecrets, "team-a")
+	if len(reviews) != 2 {
+		t.Errorf("expected cached decisions, got %d reviews", len(reviews))
+	}
+
+	// Different groups are a different tuple.
+	other := &auth.Claims{Username: "alice", KubernetesUser: "alice@example.com"}
+	a.Authorize(ctx, other, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-a"})
+	if len(reviews) != 3 {
+		t.Errorf("expected a review for other groups, got %d reviews", len(reviews))
+	}
+
+	// Expired decisions are reviewed again.
+	now := time.Now()
+	a.now = func() time.Time { return now.Add(2 * time.Minute) }
+	a.Authorize(ctx, claims, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "team-a"})
+	if len(reviews) != 4 {
+		t.Errorf("expected a new review after the TTL, got %d reviews", len(reviews))
+	}
+}
+
+func TestSubjectAccessReviewAuthorizer_ClusterScoped(t *testing.T) {
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	c := newReviewClient(t, func(spec authorizationv1.SubjectAccessReviewSpec) bool {
+		return spec.ResourceAttributes.Namespace == ""
+	}, &reviews)
+	a := NewSubjectAccessReviewAuthorizer(c, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	claims := &auth.Claims{Username: "bob"}
+
+	if !a.Authorize(context.Background(), claims, Attributes{Verb: VerbList, Resource: ResourceClusterSecrets}) {
+		t.Error("expected cluster-wide list to be allowed")
+	}
+	if !a.AllowsNamespace(context.Background(), claims, VerbCreate, ResourceSecrets, "*") {
+		t.Error("expected * to be reviewed as all namespaces")
+	}
+	if reviews[0].User != "bob" || reviews[0].ResourceAttributes.Resource != "clustersecretclaims" {
+		t.Errorf("unexpected review: %+v", reviews[0])
+	}
+}
+
+func TestSubjectAccessReviewAuthorizer_AllowedNamespaces(t *testing.T) {
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	c := newReviewClient(t, func(spec authorizationv1.SubjectAccessReviewSpec) bool { return true }, &reviews)
+	a := NewSubjectAccessReviewAuthorizer(c, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+	// The claims of an API key limited to staging, for a user the cluster
+	// allows everything.
+	claims := &auth.Claims{Username: "alice", AllowedNamespaces: []string{"staging"}}
+
+	if !a.Authorize(ctx, claims, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "staging"}) {
+		t.Error("expected get in staging to be allowed")
+	}
+	if a.Authorize(ctx, claims, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "prod"}) {
+		t.Error("expected get outside the allowed namespaces to be denied")
+	}
+	if a.AllowsNamespace(ctx, claims, VerbCreate, ResourceSecrets, "*") {
+		t.Error("expected all namespaces to be denied")
+	}
+	if !a.Authorize(ctx, claims, Attributes{Verb: VerbList, Resource: ResourceClusterSecrets}) {
+		t.Error("expected cluster-scoped resources not to be limited by namespaces")
+	}
+	if len(reviews) != 2 {
+		t.Errorf("expected denied namespaces not to be reviewed, got %d reviews", len(reviews))
+	}
+}
+
+func TestSubjectAccessReviewAuthorizer_ErrorDenies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := authorizationv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	calls := 0
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			calls++
+			return fmt.Errorf("connection refused")
+		},
+	}).Build()
+	a := NewSubjectAccessReviewAuthorizer(c, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	claims := &auth.Claims{Username: "bob"}
+	attrs := Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "default"}
+
+	if a.Authorize(context.Background(), claims, attrs) || a.Authorize(context.Background(), claims, attrs) {
+		t.Error("expected a failed review to deny")
+	}
+	if calls != 2 {
+		t.Errorf("expected failed reviews not to be cached, got %d calls", calls)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	c := newReviewClient(t, func(authorizationv1.SubjectAccessReviewSpec) bool { return true }, &reviews)
+
+	if _, err := FromConfig(cfg.AuthorizationConfig{Mode: "ldap"}, nil, c, logger); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+
+	both, err := FromConfig(cfg.AuthorizationConfig{Mode: cfg.AuthorizationModeBoth}, nil, c, logger)
+	if err != nil {
+		t.Fatalf("FromConfig: %v", err)
+	}
+	developer := &auth.Claims{Username: "dev", Role: "developer", AllowedNamespaces: []string{"*"}}
+	if both.Authorize(context.Background(), developer, Attributes{Verb: VerbDelete, Resource: ResourceSecrets, Namespace: "default"}) {
+		t.Error("expected the roles to deny even though the cluster allows")
+	}
+	if !both.Authorize(context.Background(), developer, Attributes{Verb: VerbGet, Resource: ResourceSecrets, Namespace: "default"}) {
+		t.Error("expected get to be allowed by both")
+	}
+}
@@ -0,0 +1,220 @@
+// Package sealing implements the envelope encryption of claim values to a key
+// pair held by the controller. A value is encrypted with AES-256-GCM under a key
+// derived with X25519 from a fresh ephemeral key pair and the controller's public
+// key, so only the holder of the private key can open it. Sealed values are bound
+// to the claim and the data key they were sealed for and cannot be copied into
+// another claim.
+package sealing
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	// version is the first byte of a sealed value.
+	version = 1
+
+	keyIDSize = 8
+	nonceSize = 12
+	// headerSize is the size of version, key ID, ephemeral public key and nonce.
+	headerSize = 1 + keyIDSize + 32 + nonceSize
+
+	info = "k8s-secret-manager sealing v1"
+)
+
+// ErrUnknownKey is returned when a value is sealed to a key not in the Keyring.
+var ErrUnknownKey = errors.New("value is sealed with an unknown key")
+
+// GenerateKey returns a new X25519 key pair.
+func GenerateKey() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// KeyID identifies a public key by the hex encoded prefix of its SHA-256 hash.
+func KeyID(pub *ecdh.PublicKey) string {
+	return hex.EncodeToString(keyID(pub))
+}
+
+func keyID(pub *ecdh.PublicKey) []byte {
+	sum := sha256.Sum256(pub.Bytes())
+	return sum[:keyIDSize]
+}
+
+// Scope names the claim a value is sealed for. ClusterSecretClaims have an empty
+// namespace.
+func Scope(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Seal encrypts plaintext to pub for the data key of the claim in scope and
+// returns the base64 encoded sealed value.
+func Seal(pub *ecdh.PublicKey, scope, key string, plaintext []byte) (string, error) {
+	ephemeral, err := GenerateKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+	shared, err := ephemeral.ECDH(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive shared key: %w", err)
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, version)
+	header = append(header, keyID(pub)...)
+	header = append(header, ephemeral.PublicKey().Bytes()...)
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	header = append(header, nonce...)
+
+	aead, err := newAEAD(shared, ephemeral.PublicKey(), pub)
+	if err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(header, nonce, plaintext, additionalData(scope, key))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Keyring holds the private keys sealed values are opened with by KeyID.
+type Keyring map[string]*ecdh.PrivateKey
+
+// Add stores priv under the ID of its public key.
+func (k Keyring) Add(priv *ecdh.PrivateKey) {
+	k[KeyID(priv.PublicKey())] = priv
+}
+
+// Open decrypts a value sealed for the data key of the claim in scope.
+func (k Keyring) Open(scope, key, value string) ([]byte, error) {
+	raw, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := k[hex.EncodeToString(raw[1:1+keyIDSize])]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(raw[1+keyIDSize : 1+keyIDSize+32])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+	shared, err := priv.ECDH(ephemeral)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared key: %w", err)
+	}
+	aead, err := newAEAD(shared, ephemeral, priv.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	nonce := raw[headerSize-nonceSize : headerSize]
+	plaintext, err := aead.Open(nil, nonce, raw[headerSize:], additionalData(scope, key))
+	if err != nil {
+		return nil, fmt.Errorf("value was not sealed for %s with this key", scope)
+	}
+	return plaintext, nil
+}
+
+// Check reports whether value has the form of a sealed value. It does not need
+// the private key and cannot tell whether the value opens.
+func Check(value string) error {
+	_, err := decode(value)
+	return err
+}
+
+func decode(value string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("sealed value is not base64: %w", err)
+	}
+	if len(raw) < headerSize+16 {
+		return nil, fmt.Errorf("sealed value is too short")
+	}
+	if raw[0] != version {
+		return nil, fmt.Errorf("unsupported sealed value version %d", raw[0])
+	}
+	return raw, nil
+}
+
+// newAEAD derives the AES-256-GCM key of a value from the shared secret and both
+// public keys.
+func newAEAD(shared []byte, ephemeral, recipient *ecdh.PublicKey) (cipher.AEAD, error) {
+	salt := append(bytes.Clone(ephemeral.Bytes()), recipient.Bytes()...)
+	key, err := hkdf.Key(sha256.New, shared, salt, info, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive value key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func additionalData(scope, key string) []byte {
+	return []byte(scope + "\x00" + key)
+}
+
+// MarshalPublicKey encodes pub as a PKIX "PUBLIC KEY" PEM block.
+func MarshalPublicKey(pub *ecdh.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// ParsePublicKey decodes a public key encoded by MarshalPublicKey.
+func ParsePublicKey(data []byte) (*ecdh.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("no PUBLIC KEY PEM block found")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := parsed.(*ecdh.PublicKey)
+	if !ok || pub.Curve() != ecdh.X25519() {
+		return nil, fmt.Errorf("public key is not an X25519 key")
+	}
+	return pub, nil
+}
+
+// MarshalPrivateKey encodes priv as a PKCS #8 "PRIVATE KEY" PEM block.
+func MarshalPrivateKey(priv *ecdh.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// ParsePrivateKey decodes a private key encoded by MarshalPrivateKey.
+func ParsePrivateKey(data []byte) (*ecdh.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("no PRIVATE KEY PEM block found")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := parsed.(*ecdh.PrivateKey)
+	if !ok || priv.Curve() != ecdh.X25519() {
+		return nil, fmt.Errorf("private key is not an X25519 key")
+	}
+	return priv, nil
+}
@@ -0,0 +1,95 @@
+package sealing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+	keyring := Keyring{}
+	keyring.Add(priv)
+
+	scope := Scope("team-a", "db")
+	sealed, err := Seal(priv.PublicKey(), scope, "password", []byte("s3cret"))
+	if err != nil {
+		t.Fatalf("Seal error: %v", err)
+	}
+	if err := Check(sealed); err != nil {
+		t.Errorf("Check error: %v", err)
+	}
+
+	opened, err := keyring.Open(scope, "password", sealed)
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	if string(opened) != "s3cret" {
+		t.Errorf("opened = %q, want s3cret", opened)
+	}
+
+	again, _ := Seal(priv.PublicKey(), scope, "password", []byte("s3cret"))
+	if again == sealed {
+		t.Errorf("sealing the same value twice returned the same ciphertext")
+	}
+
+	if _, err := keyring.Open(Scope("team-b", "db"), "password", sealed); err == nil {
+		t.Errorf("expected an error opening a value copied to another namespace")
+	}
+	if _, err := keyring.Open(Scope("", "db"), "password", sealed); err == nil {
+		t.Errorf("expected an error opening a value copied to a cluster claim")
+	}
+	if _, err := keyring.Open(scope, "username", sealed); err == nil {
+		t.Errorf("expected an error opening a value copied to another key")
+	}
+
+	other, _ := GenerateKey()
+	if _, err := (Keyring{KeyID(other.PublicKey()): other}).Open(scope, "password", sealed); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, value := range []string{"", "plaintext", "c2hvcnQ=", "AgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"} {
+		if err := Check(value); err == nil {
+			t.Errorf("Check(%q): expected an error", value)
+		}
+	}
+}
+
+func TestMarshalKeys(t *testing.T) {
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+
+	privPEM, err := MarshalPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey error: %v", err)
+	}
+	parsedPriv, err := ParsePrivateKey(privPEM)
+	if err != nil {
+		t.Fatalf("ParsePrivateKey error: %v", err)
+	}
+	if !parsedPriv.Equal(priv) {
+		t.Errorf("parsed private key differs")
+	}
+
+	pubPEM, err := MarshalPublicKey(priv.PublicKey())
+	if err != nil {
+		t.Fatalf("MarshalPublicKey error: %v", err)
+	}
+	parsedPub, err := ParsePublicKey(pubPEM)
+	if err != nil {
+		t.Fatalf("ParsePublicKey error: %v", err)
+	}
+	if KeyID(parsedPub) != KeyID(priv.PublicKey()) || len(KeyID(parsedPub)) != 16 {
+		t.Errorf("KeyID = %s, want %s", KeyID(parsedPub), KeyID(priv.PublicKey()))
+	}
+
+	if _, err := ParsePublicKey(privPEM); err == nil {
+		t.Errorf("expected an error parsing a private key as public key")
+	}
+}
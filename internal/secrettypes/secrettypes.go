@@ -0,0 +1,131 @@
+// Package secrettypes validates and renders the Kubernetes Secret types a SecretClaim can target.
+//
+// Opaque is the default. The other types require well-known keys, e.g. tls.crt and
+// tls.key for kubernetes.io/tls; for kubernetes.io/dockerconfigjson the
+// .dockerconfigjson key is rendered from the registry credentials.
+package secrettypes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mogilyoy/k8s-secret-manager/internal/keygen"
+)
+
+// PasswordKey is the data key the docker registry password is read from.
+const PasswordKey = "password"
+
+// Supported lists the Secret types a claim can target.
+var Supported = []string{
+	string(corev1.SecretTypeOpaque),
+	string(corev1.SecretTypeTLS),
+	string(corev1.SecretTypeDockerConfigJson),
+	string(corev1.SecretTypeBasicAuth),
+	string(corev1.SecretTypeSSHAuth),
+}
+
+// DockerConfig holds the registry credentials rendered into .dockerconfigjson.
+type DockerConfig struct {
+	Registry string
+	Username string
+}
+
+// SecretType maps an empty claim value to Opaque.
+func SecretType(secretType string) corev1.SecretType {
+	if secretType == "" {
+		return corev1.SecretTypeOpaque
+	}
+	return corev1.SecretType(secretType)
+}
+
+// RequiredKeys returns the data keys a Secret of secretType must contain.
+func RequiredKeys(secretType string) []string {
+	switch SecretType(secretType) {
+	case corev1.SecretTypeTLS:
+		return []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}
+	case corev1.SecretTypeBasicAuth:
+		return []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey}
+	case corev1.SecretTypeSSHAuth:
+		return []string{corev1.SSHAuthPrivateKey}
+	}
+	return nil
+}
+
+// Generatable reports whether an AutoGenerated claim can produce a Secret of
+// secretType. formats maps data keys to their generation format: tls Secrets need
+// an issued certificate, ssh-auth Secrets an ssh-privatekey generated as SSH key.
+func Generatable(secretType string, formats map[string]string) bool {
+	switch SecretType(secretType) {
+	case corev1.SecretTypeTLS:
+		return false
+	case corev1.SecretTypeSSHAuth:
+		return keygen.IsSSH(formats[corev1.SSHAuthPrivateKey])
+	}
+	return true
+}
+
+// Validate checks that a Secret of secretType can be built from a claim providing
+// keys (data, generated and templated keys) and, for dockerconfigjson, docker.
+func Validate(secretType string, keys []string, docker *DockerConfig) error {
+	if !slices.Contains(Supported, string(SecretType(secretType))) {
+		return fmt.Errorf("unsupported secret type: %s", secretType)
+	}
+
+	for _, key := range RequiredKeys(secretType) {
+		if !slices.Contains(keys, key) {
+			return fmt.Errorf("secret type %s requires key %s", secretType, key)
+		}
+	}
+
+	if SecretType(secretType) != corev1.SecretTypeDockerConfigJson {
+		if docker != nil {
+			return fmt.Errorf("dockerConfig is only allowed for secret type %s", corev1.SecretTypeDockerConfigJson)
+		}
+		return nil
+	}
+
+	if docker == nil {
+		return fmt.Errorf("secret type %s requires dockerConfig", secretType)
+	}
+	if docker.Registry == "" || docker.Username == "" {
+		return fmt.Errorf("dockerConfig requires registry and username")
+	}
+	if !slices.Contains(keys, PasswordKey) {
+		return fmt.Errorf("dockerConfig requires a %s data key", PasswordKey)
+	}
+	if slices.Contains(keys, corev1.DockerConfigJsonKey) {
+		return fmt.Errorf("key %s is rendered from dockerConfig and cannot be set directly", corev1.DockerConfigJsonKey)
+	}
+	return nil
+}
+
+// DockerConfigJSON renders the .dockerconfigjson value for docker. The password
+// is taken from the PasswordKey entry of data.
+func DockerConfigJSON(docker DockerConfig, data map[string][]byte) ([]byte, error) {
+	password := string(data[PasswordKey])
+	if password == "" {
+		return nil, fmt.Errorf("no password for docker registry %s", docker.Registry)
+	}
+
+	type authEntry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	config := struct {
+		Auths map[string]authEntry `json:"auths"`
+	}{
+		Auths: map[string]authEntry{
+			docker.Registry: {
+				Username: docker.Username,
+				Password: password,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(docker.Username + ":" + password)),
+			},
+		},
+	}
+	return json.Marshal(config)
+}
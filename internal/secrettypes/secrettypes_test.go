@@ -0,0 +1,105 @@
+package secrettypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate_RequiredKeys(t *testing.T) {
+	cases := []struct {
+		name       string
+		secretType string
+		keys       []string
+		wantErr    string
+	}{
+		{name: "opaque default", secretType: "", keys: nil},
+		{name: "tls ok", secretType: "kubernetes.io/tls", keys: []string{"tls.crt", "tls.key", "ca.crt"}},
+		{name: "tls missing key", secretType: "kubernetes.io/tls", keys: []string{"tls.crt"}, wantErr: "requires key tls.key"},
+		{name: "basic-auth ok", secretType: "kubernetes.io/basic-auth", keys: []string{"username", "password"}},
+		{name: "basic-auth missing username", secretType: "kubernetes.io/basic-auth", keys: []string{"password"}, wantErr: "requires key username"},
+		{name: "ssh-auth missing key", secretType: "kubernetes.io/ssh-auth", keys: []string{"id_rsa"}, wantErr: "requires key ssh-privatekey"},
+		{name: "unsupported", secretType: "kubernetes.io/service-account-token", wantErr: "unsupported secret type"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.secretType, tc.keys, nil)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate_DockerConfig(t *testing.T) {
+	docker := &DockerConfig{Registry: "registry.example.com", Username: "ci"}
+
+	if err := Validate("kubernetes.io/dockerconfigjson", nil, nil); err == nil {
+		t.Error("expected an error without dockerConfig")
+	}
+	if err := Validate("kubernetes.io/dockerconfigjson", nil, docker); err == nil {
+		t.Error("expected an error without any password")
+	}
+	if err := Validate("kubernetes.io/dockerconfigjson", []string{"password"}, docker); err != nil {
+		t.Errorf("password data key should be accepted: %v", err)
+	}
+	if err := Validate("kubernetes.io/dockerconfigjson", []string{"password", ".dockerconfigjson"}, docker); err == nil {
+		t.Error("expected an error when .dockerconfigjson is set directly")
+	}
+	if err := Validate("Opaque", nil, docker); err == nil {
+		t.Error("expected an error for dockerConfig on an Opaque secret")
+	}
+}
+
+func TestDockerConfigJSON(t *testing.T) {
+	docker := DockerConfig{Registry: "registry.example.com", Username: "ci"}
+
+	raw, err := DockerConfigJSON(docker, map[string][]byte{"password": []byte("s3cret")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var parsed struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	entry, ok := parsed.Auths["registry.example.com"]
+	if !ok {
+		t.Fatalf("registry entry missing: %s", raw)
+	}
+	if entry.Username != "ci" || entry.Password != "s3cret" || entry.Auth != "Y2k6czNjcmV0" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if _, err := DockerConfigJSON(docker, nil); err == nil {
+		t.Error("expected an error without a password")
+	}
+}
+
+func TestGeneratable(t *testing.T) {
+	if !Generatable("kubernetes.io/basic-auth", nil) {
+		t.Error("basic-auth secrets should be generatable")
+	}
+	if Generatable("kubernetes.io/tls", map[string]string{"tls.key": "ssh-ed25519"}) {
+		t.Error("tls secrets should not be generatable")
+	}
+	if Generatable("kubernetes.io/ssh-auth", nil) {
+		t.Error("ssh-auth secrets need an ssh-privatekey key format")
+	}
+	if !Generatable("kubernetes.io/ssh-auth", map[string]string{"ssh-privatekey": "ssh-ed25519"}) {
+		t.Error("ssh-auth secrets with an SSH ssh-privatekey should be generatable")
+	}
+}
@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads secrets from files below Root, typically a volume mounted
+// into the operator pod. A path naming a directory yields one key per regular
+// file (the layout of a mounted Secret or ConfigMap); a path naming a file must
+// hold a JSON object.
+type FileProvider struct {
+	Root string
+}
+
+func (p *FileProvider) Fetch(_ context.Context, path string) (*Secret, error) {
+	if p.Root == "" {
+		return nil, errors.New("file source is disabled: no source root configured")
+	}
+	full := filepath.Join(p.Root, filepath.Clean("/"+path))
+
+	info, err := os.Stat(full)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	var data map[string]string
+	if info.IsDir() {
+		data, err = readDir(full)
+	} else {
+		data, err = readJSONFile(full)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Secret{Data: data, Version: Checksum(data)}, nil
+}
+
+func readDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source directory: %w", err)
+	}
+	data := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		// Mounted volumes keep their payload in hidden ..data directories.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		full := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(full)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		content, err := os.ReadFile(full)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source file %s: %w", entry.Name(), err)
+		}
+		data[entry.Name()] = string(content)
+	}
+	return data, nil
+}
+
+func readJSONFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+	var object map[string]any
+	if err := json.Unmarshal(content, &object); err != nil {
+		return nil, fmt.Errorf("source file is not a JSON object: %w", err)
+	}
+	return stringValues(object)
+}
@@ -0,0 +1,100 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HTTPProvider reads secrets from an HTTP endpoint answering GET requests with a
+// flat JSON object and writes them with PUT requests carrying the same object.
+// The path is the full URL; Token, when set, is sent as a bearer token.
+//
+// Only URLs below one of AllowedURLs are requested, redirects included, so that
+// claims cannot make the operator reach arbitrary addresses such as
+// cluster-internal services. The provider is disabled when AllowedURLs is empty.
+type HTTPProvider struct {
+	Client      *http.Client
+	Token       string
+	AllowedURLs []string
+}
+
+func (p *HTTPProvider) Fetch(ctx context.Context, url string) (*Secret, error) {
+	if err := p.checkURL(url); err != nil {
+		return nil, err
+	}
+	resp, body, err := get(ctx, p.client(), url, p.header())
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, fmt.Errorf("source response is not a JSON object: %w", err)
+	}
+	data, err := stringValues(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Secret{Data: data, Version: responseVersion(resp, data)}, nil
+}
+
+// Push writes data with a PUT request. The write is conditional: If-Match carries
+// the version last fetched, If-None-Match: * is sent for a new secret. Servers
+// answer 412 Precondition Failed when the condition does not hold.
+func (p *HTTPProvider) Push(ctx context.Context, url string, data map[string]string, version string) (string, error) {
+	if err := p.checkURL(url); err != nil {
+		return "", err
+	}
+	header := p.header()
+	if version == "" {
+		header.Set("If-None-Match", "*")
+	} else {
+		header.Set("If-Match", `"`+version+`"`)
+	}
+	resp, _, err := send(ctx, p.client(), http.MethodPut, url, header, data)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed || resp.StatusCode == http.StatusConflict:
+		return "", ErrConflict
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("push responded with status %d", resp.StatusCode)
+	}
+	return responseVersion(resp, data), nil
+}
+
+// checkURL returns an error unless rawURL is below one of AllowedURLs.
+func (p *HTTPProvider) checkURL(rawURL string) error {
+	if len(p.AllowedURLs) == 0 {
+		return errors.New("http source is disabled: no allowed urls configured")
+	}
+	return checkURL(rawURL, p.AllowedURLs)
+}
+
+// client returns the client of the provider with redirects limited to the
+// allowed URLs.
+func (p *HTTPProvider) client() *http.Client {
+	return limitRedirects(p.Client, p.checkURL)
+}
+
+func (p *HTTPProvider) header() http.Header {
+	header := http.Header{}
+	if p.Token != "" {
+		header.Set("Authorization", "Bearer "+p.Token)
+	}
+	return header
+}
+
+// responseVersion returns the ETag of the response or, without one, a checksum of data.
+func responseVersion(resp *http.Response, data map[string]string) string {
+	if etag := strings.Trim(strings.TrimPrefix(resp.Header.Get("ETag"), "W/"), `"`); etag != "" {
+		return etag
+	}
+	return Checksum(data)
+}
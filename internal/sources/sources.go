@@ -0,0 +1,284 @@
+// Package sources reads the data of External claims from stores outside the
+// cluster and pushes the data of other claims to them.
+//
+// Three providers are available: a file or directory mounted into the operator
+// pod, below the directory of the claim's namespace, a generic HTTP endpoint
+// returning a JSON object and the KV v2 engine of HashiCorp Vault (or any
+// server implementing the same API). Every fetch returns
+// the values together with a version so the controller can tell when they change.
+// The HTTP and Vault providers can also write secrets; writes are conditional on
+// the version read before, so a concurrent change is reported as ErrConflict.
+package sources
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ProviderFile  = "file"
+	ProviderHTTP  = "http"
+	ProviderVault = "vault"
+
+	// DefaultVaultMount is the mount path of the KV v2 engine in a fresh Vault.
+	DefaultVaultMount = "secret"
+	// DefaultRefreshInterval is used when the claim does not set refreshInterval.
+	DefaultRefreshInterval = time.Hour
+
+	// maxResponseSize bounds the body read from HTTP and Vault sources.
+	maxResponseSize = 1 << 20
+)
+
+// Supported lists the providers a claim can use.
+var Supported = []string{ProviderFile, ProviderHTTP, ProviderVault}
+
+// PushSupported lists the providers a claim can push to.
+var PushSupported = []string{ProviderHTTP, ProviderVault}
+
+// ErrNotFound is returned when the source has no secret at the requested path.
+var ErrNotFound = errors.New("secret not found in source")
+
+// ErrConflict is returned by Push when the store holds another version than the
+// one the write was based on.
+var ErrConflict = errors.New("secret was changed in the store")
+
+// Secret is the data read from a source.
+type Secret struct {
+	Data map[string]string
+	// Version identifies the fetched data: the KV version for Vault, the ETag
+	// for HTTP when present and a checksum of the data otherwise.
+	Version string
+}
+
+// SecretSourceProvider reads a secret from an external store. The meaning of
+// path depends on the provider: a file path relative to the root, a URL or a
+// Vault KV path.
+type SecretSourceProvider interface {
+	Fetch(ctx context.Context, path string) (*Secret, error)
+}
+
+// SecretPushProvider writes secrets to an external store.
+type SecretPushProvider interface {
+	SecretSourceProvider
+	// Push replaces the secret at path with data and returns the version written.
+	// version is the version last fetched, empty when the secret does not exist;
+	// the write fails with ErrConflict when the store holds another one.
+	Push(ctx context.Context, path string, data map[string]string, version string) (string, error)
+}
+
+// Config selects and configures a provider.
+type Config struct {
+	Provider string
+	// Address is the base URL of the Vault server.
+	Address string
+	// Mount is the Vault KV v2 mount, DefaultVaultMount when empty.
+	Mount string
+	// Token authenticates HTTP (bearer) and Vault (X-Vault-Token) requests.
+	Token string
+	// FileRoot is the directory holding one directory per namespace that the
+	// file paths of claims in the namespace are resolved against.
+	FileRoot string
+	// Namespace is the namespace of the claim.
+	Namespace string
+	// HTTPAllowedURLs are the base URLs http sources may be read from and
+	// pushed to.
+	HTTPAllowedURLs []string
+	// VaultAllowedAddresses are the Vault server addresses vault sources may
+	// be read from and pushed to.
+	VaultAllowedAddresses []string
+	// Client is used for HTTP and Vault requests, http.DefaultClient when nil.
+	Client *http.Client
+}
+
+// New returns the provider described by cfg.
+func New(cfg Config) (SecretSourceProvider, error) {
+	switch cfg.Provider {
+	case ProviderFile:
+		if cfg.FileRoot == "" {
+			return &FileProvider{}, nil
+		}
+		// Claims only read the files of their own namespace.
+		if cfg.Namespace == "" {
+			return nil, errors.New("file source requires a namespace")
+		}
+		return &FileProvider{Root: filepath.Join(cfg.FileRoot, cfg.Namespace)}, nil
+	case ProviderHTTP:
+		return &HTTPProvider{Client: cfg.Client, Token: cfg.Token, AllowedURLs: cfg.HTTPAllowedURLs}, nil
+	case ProviderVault:
+		if cfg.Address == "" {
+			return nil, errors.New("vault source requires an address")
+		}
+		return &VaultProvider{Address: cfg.Address, Mount: cfg.Mount, Token: cfg.Token, Client: cfg.Client, AllowedAddresses: cfg.VaultAllowedAddresses}, nil
+	}
+	return nil, fmt.Errorf("unsupported source provider %q", cfg.Provider)
+}
+
+// NewPusher returns the writable provider described by cfg.
+func NewPusher(cfg Config) (SecretPushProvider, error) {
+	switch cfg.Provider {
+	case ProviderHTTP:
+		return &HTTPProvider{Client: cfg.Client, Token: cfg.Token, AllowedURLs: cfg.HTTPAllowedURLs}, nil
+	case ProviderVault:
+		if cfg.Address == "" {
+			return nil, errors.New("vault push requires an address")
+		}
+		return &VaultProvider{Address: cfg.Address, Mount: cfg.Mount, Token: cfg.Token, Client: cfg.Client, AllowedAddresses: cfg.VaultAllowedAddresses}, nil
+	}
+	return nil, fmt.Errorf("provider %q does not support push", cfg.Provider)
+}
+
+// Checksum returns a stable digest of data, used as version by sources without one.
+func Checksum(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(data[k]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// stringValues converts a decoded JSON object to Secret data. Strings are kept
+// as is, every other value is stored as its JSON encoding.
+func stringValues(object map[string]any) (map[string]string, error) {
+	data := make(map[string]string, len(object))
+	for k, v := range object {
+		if s, ok := v.(string); ok {
+			data[k] = s
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value of key %s: %w", k, err)
+		}
+		data[k] = string(encoded)
+	}
+	return data, nil
+}
+
+// checkURL returns an error unless rawURL is below one of allowed: same scheme
+// and host, and a path under the allowed one.
+func checkURL(rawURL string, allowed []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid source url: %w", err)
+	}
+	if u.User != nil || slices.Contains(strings.Split(u.Path, "/"), "..") {
+		return fmt.Errorf("source url %s is not allowed", u.Redacted())
+	}
+	for _, a := range allowed {
+		base, err := url.Parse(a)
+		if err != nil {
+			continue
+		}
+		prefix := strings.TrimSuffix(base.Path, "/") + "/"
+		if strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host) &&
+			(u.Path+"/" == prefix || strings.HasPrefix(u.Path, prefix)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("source url %s is not below an allowed url", u.Redacted())
+}
+
+// limitRedirects returns a copy of client that only follows redirects to URLs
+// check accepts.
+func limitRedirects(client *http.Client, check func(string) error) *http.Client {
+	c := *httpClient(client)
+	checkRedirect := c.CheckRedirect
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := check(req.URL.String()); err != nil {
+			return err
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &c
+}
+
+func httpClient(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
+
+// get performs a GET request and returns the response with its body read.
+func get(ctx context.Context, client *http.Client, url string, header http.Header) (*http.Response, []byte, error) {
+	resp, body, err := send(ctx, client, http.MethodGet, url, header, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil, ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, nil, fmt.Errorf("source responded with status %d", resp.StatusCode)
+	}
+	return resp, body, nil
+}
+
+// send performs a request with an optional JSON payload and returns the
+// response with its body read, whatever the status code.
+func send(ctx context.Context, client *http.Client, method, url string, header http.Header, payload any) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid source url: %w", err)
+	}
+	for k, values := range header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient(client).Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("source request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read source response: %w", err)
+	}
+	if len(body) > maxResponseSize {
+		return nil, nil, fmt.Errorf("source response exceeds %d bytes", maxResponseSize)
+	}
+	return resp, body, nil
+}
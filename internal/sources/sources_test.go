@@ -0,0 +1,401 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFileProvider_Directory(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "db")
+	if err := os.MkdirAll(filepath.Join(dir, "..data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "username"), []byte("app"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "password"), []byte("s3cret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &FileProvider{Root: root}
+	got, err := p.Fetch(context.Background(), "db")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"username": "app", "password": "s3cret"}
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("Data = %v, want %v", got.Data, want)
+	}
+	if got.Version != Checksum(want) {
+		t.Errorf("Version = %q, want checksum %q", got.Version, Checksum(want))
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "password"), []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	again, err := p.Fetch(context.Background(), "db")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Version == got.Version {
+		t.Errorf("expected a new version after the file changed")
+	}
+}
+
+func TestFileProvider_JSONFileAndConfinement(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "api.json"), []byte(`{"token":"abc","port":5432}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(filepath.Dir(root), "outside.json")
+	if err := os.WriteFile(outside, []byte(`{"leak":"yes"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Remove(outside) })
+
+	p := &FileProvider{Root: root}
+	got, err := p.Fetch(context.Background(), "api.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"token": "abc", "port": "5432"}
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("Data = %v, want %v", got.Data, want)
+	}
+
+	if _, err := p.Fetch(context.Background(), "../outside.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a path outside the root, got %v", err)
+	}
+	if _, err := (&FileProvider{}).Fetch(context.Background(), "api.json"); err == nil {
+		t.Errorf("expected an error without a root")
+	}
+}
+
+func TestNew_FileNamespaces(t *testing.T) {
+	root := t.TempDir()
+	for _, ns := range []string{"team-a", "team-b"} {
+		if err := os.MkdirAll(filepath.Join(root, ns), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, ns, "api.json"), []byte(`{"token":"`+ns+`"}`), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := New(Config{Provider: ProviderFile, FileRoot: root, Namespace: "team-a"})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	got, err := p.Fetch(context.Background(), "api.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Data["token"] != "team-a" {
+		t.Errorf("Data = %v, want the file of team-a", got.Data)
+	}
+	if _, err := p.Fetch(context.Background(), "../team-b/api.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for the file of another namespace, got %v", err)
+	}
+	if _, err := New(Config{Provider: ProviderFile, FileRoot: root}); err == nil {
+		t.Errorf("expected an error without a namespace")
+	}
+}
+
+func TestHTTPProvider_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/creds":
+			w.Header().Set("ETag", `W/"v7"`)
+			_, _ = w.Write([]byte(`{"user":"svc","config":{"tls":true}}`))
+		case "/list":
+			_, _ = w.Write([]byte(`["not","an","object"]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p := &HTTPProvider{Client: srv.Client(), Token: "t0ken", AllowedURLs: []string{srv.URL}}
+	got, err := p.Fetch(context.Background(), srv.URL+"/creds")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"user": "svc", "config": `{"tls":true}`}
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("Data = %v, want %v", got.Data, want)
+	}
+	if got.Version != "v7" {
+		t.Errorf("Version = %q, want v7", got.Version)
+	}
+
+	if _, err := p.Fetch(context.Background(), srv.URL+"/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := p.Fetch(context.Background(), srv.URL+"/list"); err == nil {
+		t.Errorf("expected an error for a non-object body")
+	}
+	if _, err := (&HTTPProvider{Client: srv.Client(), AllowedURLs: []string{srv.URL}}).Fetch(context.Background(), srv.URL+"/creds"); err == nil {
+		t.Errorf("expected an error without a token")
+	}
+}
+
+func TestHTTPProvider_AllowedURLs(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/secrets/redirect" {
+			http.Redirect(w, r, "/internal/creds", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user":"svc"}`))
+	}))
+	defer srv.Close()
+
+	p := &HTTPProvider{Client: srv.Client(), AllowedURLs: []string{srv.URL + "/secrets/"}}
+	if _, err := p.Fetch(context.Background(), srv.URL+"/secrets/creds"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, url := range []string{
+		srv.URL + "/internal/creds",
+		srv.URL + "/secretsx",
+		srv.URL + "/secrets/../internal/creds",
+		srv.URL + "/secrets/%2e%2e/internal/creds",
+		strings.Replace(srv.URL, "http://", "http://user:pass@", 1) + "/secrets/creds",
+		"http://169.254.169.254/latest/meta-data/",
+	} {
+		if _, err := p.Fetch(context.Background(), url); err == nil {
+			t.Errorf("expected %s to be rejected", url)
+		}
+		if _, err := p.Push(context.Background(), url, map[string]string{"user": "svc"}, ""); err == nil {
+			t.Errorf("expected a push to %s to be rejected", url)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected only the allowed url to be requested, got %d requests", requests)
+	}
+
+	if _, err := p.Fetch(context.Background(), srv.URL+"/secrets/redirect"); err == nil {
+		t.Errorf("expected a redirect outside the allowed urls to be rejected")
+	}
+	if _, err := (&HTTPProvider{Client: srv.Client()}).Fetch(context.Background(), srv.URL+"/secrets/creds"); err == nil {
+		t.Errorf("expected http sources to be disabled without allowed urls")
+	}
+}
+
+func TestVaultProvider_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/data/apps/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"pw","port":5432},"metadata":{"version":3,"deletion_time":"","destroyed":false}}}`))
+		case "/v1/kv/data/apps/deleted":
+			_, _ = w.Write([]byte(`{"data":{"data":null,"metadata":{"version":2,"deletion_time":"2026-01-01T00:00:00Z","destroyed":false}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	p, err := New(Config{Provider: ProviderVault, Address: srv.URL + "/", Mount: "kv", Token: "root", Client: srv.Client(), VaultAllowedAddresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	got, err := p.Fetch(context.Background(), "apps/db")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"password": "pw", "port": "5432"}
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("Data = %v, want %v", got.Data, want)
+	}
+	if got.Version != "3" {
+		t.Errorf("Version = %q, want 3", got.Version)
+	}
+
+	for _, path := range []string{"apps/deleted", "apps/missing"} {
+		if _, err := p.Fetch(context.Background(), path); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", path, err)
+		}
+	}
+	if _, err := New(Config{Provider: ProviderVault}); err == nil {
+		t.Errorf("expected an error without an address")
+	}
+	if _, err := New(Config{Provider: "s3"}); err == nil {
+		t.Errorf("expected an error for an unsupported provider")
+	}
+}
+
+func TestVaultProvider_AllowedAddresses(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/v1/secret/data/redirect" {
+			http.Redirect(w, r, "/internal/creds", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"user":"svc"},"metadata":{"version":1}}}`))
+	}))
+	defer srv.Close()
+
+	fetch := func(address, path string, allowed ...string) error {
+		p, err := New(Config{Provider: ProviderVault, Address: address, Client: srv.Client(), VaultAllowedAddresses: allowed})
+		if err != nil {
+			t.Fatalf("New error: %v", err)
+		}
+		_, err = p.Fetch(context.Background(), path)
+		return err
+	}
+	if err := fetch(srv.URL, "apps/db", srv.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, address := range []string{"http://169.254.169.254", strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)} {
+		if err := fetch(address, "apps/db", srv.URL); err == nil {
+			t.Errorf("expected address %s to be rejected", address)
+		}
+	}
+	if err := fetch(srv.URL+"/vault", "../../../internal/creds", srv.URL+"/vault"); err == nil {
+		t.Errorf("expected a path leaving the allowed address to be rejected")
+	}
+	if requests != 1 {
+		t.Errorf("expected only the allowed address to be requested, got %d requests", requests)
+	}
+
+	if err := fetch(srv.URL, "redirect", srv.URL+"/v1/"); err == nil {
+		t.Errorf("expected a redirect outside the allowed addresses to be rejected")
+	}
+	if err := fetch(srv.URL, "apps/db"); err == nil {
+		t.Errorf("expected vault sources to be disabled without allowed addresses")
+	}
+}
+
+func TestHTTPProvider_Push(t *testing.T) {
+	stored := map[string]string{}
+	etag, writes := "", 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if (etag == "" && r.Header.Get("If-None-Match") != "*") || (etag != "" && r.Header.Get("If-Match") != `"`+etag+`"`) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		writes++
+		etag = "v" + strconv.Itoa(writes)
+		w.Header().Set("ETag", `"`+etag+`"`)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	p, err := NewPusher(Config{Provider: ProviderHTTP, Client: srv.Client(), HTTPAllowedURLs: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("NewPusher error: %v", err)
+	}
+	version, err := p.Push(context.Background(), srv.URL+"/creds", map[string]string{"user": "svc"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "v1" || stored["user"] != "svc" {
+		t.Errorf("version = %q, stored = %v", version, stored)
+	}
+
+	if _, err := p.Push(context.Background(), srv.URL+"/creds", map[string]string{"user": "other"}, ""); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict for a create over an existing secret, got %v", err)
+	}
+	if _, err := p.Push(context.Background(), srv.URL+"/creds", map[string]string{"user": "other"}, "v0"); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict for a stale version, got %v", err)
+	}
+	if _, err := p.Push(context.Background(), srv.URL+"/creds", map[string]string{"user": "other"}, version); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if stored["user"] != "other" {
+		t.Errorf("stored = %v, want user=other", stored)
+	}
+}
+
+func TestVaultProvider_Push(t *testing.T) {
+	version := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/apps/db" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var payload struct {
+			Options struct {
+				CAS int `json:"cas"`
+			} `json:"options"`
+			Data map[string]string `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if payload.Options.CAS != version {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["check-and-set parameter did not match the current version"]}`))
+			return
+		}
+		version++
+		_, _ = w.Write([]byte(`{"data":{"version":` + strconv.Itoa(version) + `}}`))
+	}))
+	defer srv.Close()
+
+	p, err := NewPusher(Config{Provider: ProviderVault, Address: srv.URL, Client: srv.Client(), VaultAllowedAddresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("NewPusher error: %v", err)
+	}
+	if _, err := p.Push(context.Background(), "apps/db", map[string]string{"password": "pw"}, ""); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict for a create over an existing secret, got %v", err)
+	}
+	got, err := p.Push(context.Background(), "apps/db", map[string]string{"password": "pw"}, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "2" {
+		t.Errorf("version = %q, want 2", got)
+	}
+	if _, err := p.Push(context.Background(), "apps/db", map[string]string{"password": "pw"}, "latest"); err == nil {
+		t.Errorf("expected an error for a non-numeric version")
+	}
+
+	for _, cfg := range []Config{
+		{Provider: ProviderVault, Address: "http://169.254.169.254", Client: srv.Client(), VaultAllowedAddresses: []string{srv.URL}},
+		{Provider: ProviderVault, Address: srv.URL, Client: srv.Client()},
+	} {
+		other, err := NewPusher(cfg)
+		if err != nil {
+			t.Fatalf("NewPusher error: %v", err)
+		}
+		if _, err := other.Push(context.Background(), "apps/db", map[string]string{"password": "pw"}, "2"); err == nil {
+			t.Errorf("expected a push to %s with allowed addresses %v to be rejected", cfg.Address, cfg.VaultAllowedAddresses)
+		}
+	}
+	if version != 2 {
+		t.Errorf("expected rejected pushes not to reach the store")
+	}
+	if _, err := NewPusher(Config{Provider: ProviderFile}); err == nil {
+		t.Errorf("expected an error for a provider without push support")
+	}
+}
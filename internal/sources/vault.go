@@ -0,0 +1,137 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// VaultProvider reads the latest version of a secret from a KV v2 engine through
+// the Vault HTTP API (GET /v1/<mount>/data/<path>) and writes new versions with
+// check-and-set (POST to the same path).
+//
+// Only addresses below one of AllowedAddresses are requested, redirects
+// included, so that claims cannot make the operator send its Vault token or
+// pushed secrets to arbitrary addresses. The provider is disabled when
+// AllowedAddresses is empty.
+type VaultProvider struct {
+	Address          string
+	Mount            string
+	Token            string
+	Client           *http.Client
+	AllowedAddresses []string
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data     map[string]any `json:"data"`
+		Metadata struct {
+			Version   int    `json:"version"`
+			Destroyed bool   `json:"destroyed"`
+			Deleted   string `json:"deletion_time"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+type vaultWriteResponse struct {
+	Data struct {
+		Version int `json:"version"`
+	} `json:"data"`
+}
+
+func (p *VaultProvider) Fetch(ctx context.Context, path string) (*Secret, error) {
+	url := p.url(path)
+	if err := p.checkURL(url); err != nil {
+		return nil, err
+	}
+	_, body, err := get(ctx, p.client(), url, p.header())
+	if err != nil {
+		return nil, err
+	}
+
+	var kv vaultKVResponse
+	if err := json.Unmarshal(body, &kv); err != nil {
+		return nil, fmt.Errorf("invalid vault response: %w", err)
+	}
+	// Deleted and destroyed versions are returned with null data.
+	if kv.Data.Data == nil || kv.Data.Metadata.Destroyed || kv.Data.Metadata.Deleted != "" {
+		return nil, ErrNotFound
+	}
+	data, err := stringValues(kv.Data.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &Secret{Data: data, Version: strconv.Itoa(kv.Data.Metadata.Version)}, nil
+}
+
+// Push writes data as a new version of the secret. The check-and-set version is
+// the one last fetched, 0 for a secret that does not exist yet, so Vault rejects
+// the write when another version was written in between.
+func (p *VaultProvider) Push(ctx context.Context, path string, data map[string]string, version string) (string, error) {
+	cas := 0
+	if version != "" {
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return "", fmt.Errorf("invalid vault version %q: %w", version, err)
+		}
+		cas = v
+	}
+	payload := map[string]any{
+		"options": map[string]int{"cas": cas},
+		"data":    data,
+	}
+	url := p.url(path)
+	if err := p.checkURL(url); err != nil {
+		return "", err
+	}
+	resp, body, err := send(ctx, p.client(), http.MethodPost, url, p.header(), payload)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case resp.StatusCode == http.StatusBadRequest && strings.Contains(string(body), "check-and-set"):
+		return "", ErrConflict
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+
+	var written vaultWriteResponse
+	if err := json.Unmarshal(body, &written); err != nil {
+		return "", fmt.Errorf("invalid vault response: %w", err)
+	}
+	return strconv.Itoa(written.Data.Version), nil
+}
+
+// checkURL returns an error unless rawURL is below one of AllowedAddresses.
+func (p *VaultProvider) checkURL(rawURL string) error {
+	if len(p.AllowedAddresses) == 0 {
+		return errors.New("vault source is disabled: no allowed addresses configured")
+	}
+	return checkURL(rawURL, p.AllowedAddresses)
+}
+
+// client returns the client of the provider with redirects limited to the
+// allowed addresses.
+func (p *VaultProvider) client() *http.Client {
+	return limitRedirects(p.Client, p.checkURL)
+}
+
+func (p *VaultProvider) url(path string) string {
+	mount := strings.Trim(p.Mount, "/")
+	if mount == "" {
+		mount = DefaultVaultMount
+	}
+	return strings.TrimRight(p.Address, "/") + "/v1/" + mount + "/data/" + strings.TrimLeft(path, "/")
+}
+
+func (p *VaultProvider) header() http.Header {
+	header := http.Header{}
+	if p.Token != "" {
+		header.Set("X-Vault-Token", p.Token)
+	}
+	return header
+}
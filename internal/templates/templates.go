@@ -0,0 +1,126 @@
+// Package templates renders the SecretClaim `templates` map into extra Secret keys.
+//
+// Every template is a Go text/template executed against the claim's data values
+// (generated keys for AutoGenerated, Data keys for Opaque), e.g.
+//
+//	postgres://app:{{ .password | urlquery }}@db:5432/app
+//	{{ htpasswd "admin" .password }}
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// FuncMap returns the helper functions available inside templates.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"base64":   encodeBase64,
+		"bcrypt":   bcryptHash,
+		"htpasswd": htpasswd,
+	}
+}
+
+func encodeBase64(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+func bcryptHash(value string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(value), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// htpasswd returns a "user:hash" line in the bcrypt format understood by nginx and Apache.
+func htpasswd(user, password string) (string, error) {
+	hashed, err := bcryptHash(password)
+	if err != nil {
+		return "", err
+	}
+	return user + ":" + hashed, nil
+}
+
+func parse(key, text string) (*template.Template, error) {
+	tmpl, err := template.New(key).Option("missingkey=error").Funcs(FuncMap()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template for key %s: %w", key, err)
+	}
+	return tmpl, nil
+}
+
+// Validate checks that every template parses and does not overwrite one of the reserved keys.
+func Validate(templates map[string]string, reservedKeys []string) error {
+	for _, key := range reservedKeys {
+		if _, ok := templates[key]; ok {
+			return fmt.Errorf("template key %s collides with a data key", key)
+		}
+	}
+	for _, key := range sortedKeys(templates) {
+		if key == "" {
+			return fmt.Errorf("template key must not be empty")
+		}
+		if _, err := parse(key, templates[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render executes the templates against values and returns the rendered keys.
+func Render(templates map[string]string, values map[string][]byte) (map[string][]byte, error) {
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	input := make(map[string]string, len(values))
+	for k, v := range values {
+		input[k] = string(v)
+	}
+
+	rendered := make(map[string][]byte, len(templates))
+	for _, key := range sortedKeys(templates) {
+		tmpl, err := parse(key, templates[key])
+		if err != nil {
+			return nil, err
+		}
+
+		var out strings.Builder
+		if err := tmpl.Execute(&out, input); err != nil {
+			return nil, fmt.Errorf("failed to render template for key %s: %w", key, err)
+		}
+		rendered[key] = []byte(out.String())
+	}
+	return rendered, nil
+}
+
+// Checksum identifies a set of templates so the controller can tell when they changed.
+// An empty set yields an empty checksum.
+func Checksum(templates map[string]string) string {
+	if len(templates) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, key := range sortedKeys(templates) {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(key), key, len(templates[key]), templates[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
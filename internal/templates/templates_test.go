@@ -0,0 +1,87 @@
+package templates
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRender_ConnectionString(t *testing.T) {
+	tmpls := map[string]string{
+		"dsn": "postgres://app:{{ .password | urlquery }}@db:5432/app",
+	}
+	values := map[string][]byte{"password": []byte("p@ss word")}
+
+	rendered, err := Render(tmpls, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(rendered["dsn"]); got != "postgres://app:p%40ss+word@db:5432/app" {
+		t.Errorf("unexpected dsn: %s", got)
+	}
+}
+
+func TestRender_Helpers(t *testing.T) {
+	tmpls := map[string]string{
+		"b64":      "{{ base64 .password }}",
+		"hash":     "{{ bcrypt .password }}",
+		"htpasswd": `{{ htpasswd "admin" .password }}`,
+	}
+	values := map[string][]byte{"password": []byte("secret123")}
+
+	rendered, err := Render(tmpls, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := string(rendered["b64"]); got != base64.StdEncoding.EncodeToString([]byte("secret123")) {
+		t.Errorf("unexpected base64 output: %s", got)
+	}
+	if err := bcrypt.CompareHashAndPassword(rendered["hash"], []byte("secret123")); err != nil {
+		t.Errorf("bcrypt output does not match password: %v", err)
+	}
+
+	user, hash, ok := strings.Cut(string(rendered["htpasswd"]), ":")
+	if !ok || user != "admin" {
+		t.Fatalf("unexpected htpasswd line: %s", rendered["htpasswd"])
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("secret123")); err != nil {
+		t.Errorf("htpasswd hash does not match password: %v", err)
+	}
+}
+
+func TestRender_MissingKey(t *testing.T) {
+	_, err := Render(map[string]string{"dsn": "{{ .missing }}"}, map[string][]byte{"password": []byte("x")})
+	if err == nil {
+		t.Fatal("expected error for missing key, got nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(map[string]string{"dsn": "{{ .password }}"}, []string{"password"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Validate(map[string]string{"dsn": "{{ .password "}, nil); err == nil {
+		t.Error("expected parse error, got nil")
+	}
+	if err := Validate(map[string]string{"password": "{{ .password }}"}, []string{"password"}); err == nil {
+		t.Error("expected collision error, got nil")
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	if Checksum(nil) != "" {
+		t.Error("expected empty checksum for no templates")
+	}
+
+	a := Checksum(map[string]string{"a": "1", "b": "2"})
+	b := Checksum(map[string]string{"b": "2", "a": "1"})
+	if a != b {
+		t.Error("checksum must not depend on map order")
+	}
+	if a == Checksum(map[string]string{"a": "1", "b": "3"}) {
+		t.Error("checksum must change when a template changes")
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var clustersecretclaimlog = logf.Log.WithName("clustersecretclaim-resource")
+
+// SetupClusterSecretClaimWebhookWithManager registers the webhook for ClusterSecretClaim in the manager.
+func SetupClusterSecretClaimWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&secretsv1alpha1.ClusterSecretClaim{}).
+		WithValidator(&ClusterSecretClaimCustomValidator{}).
+		WithDefaulter(&ClusterSecretClaimCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-secrets-myapp-io-v1alpha1-clustersecretclaim,mutating=true,failurePolicy=fail,sideEffects=None,groups=secrets.myapp.io,resources=clustersecretclaims,verbs=create;update,versions=v1alpha1,name=mclustersecretclaim-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterSecretClaimCustomDefaulter sets default values on ClusterSecretClaim objects when they are created or updated.
+type ClusterSecretClaimCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ClusterSecretClaimCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ClusterSecretClaim.
+func (d *ClusterSecretClaimCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	clustersecretclaim, ok := obj.(*secretsv1alpha1.ClusterSecretClaim)
+	if !ok {
+		return fmt.Errorf("expected a ClusterSecretClaim object but got %T", obj)
+	}
+	clustersecretclaimlog.Info("Defaulting for ClusterSecretClaim", "name", clustersecretclaim.GetName())
+
+	defaultSecretClaimSpec(&clustersecretclaim.Spec)
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-secrets-myapp-io-v1alpha1-clustersecretclaim,mutating=false,failurePolicy=fail,sideEffects=None,groups=secrets.myapp.io,resources=clustersecretclaims,verbs=create;update,versions=v1alpha1,name=vclustersecretclaim-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterSecretClaimCustomValidator rejects ClusterSecretClaim specs the controller cannot reconcile.
+type ClusterSecretClaimCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ClusterSecretClaimCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterSecretClaim.
+func (v *ClusterSecretClaimCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clustersecretclaim, ok := obj.(*secretsv1alpha1.ClusterSecretClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterSecretClaim object but got %T", obj)
+	}
+	clustersecretclaimlog.Info("Validation for ClusterSecretClaim upon creation", "name", clustersecretclaim.GetName())
+
+	return nil, validateClusterSecretClaim(clustersecretclaim)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterSecretClaim.
+func (v *ClusterSecretClaimCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	clustersecretclaim, ok := newObj.(*secretsv1alpha1.ClusterSecretClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterSecretClaim object for the newObj but got %T", newObj)
+	}
+	clustersecretclaimlog.Info("Validation for ClusterSecretClaim upon update", "name", clustersecretclaim.GetName())
+
+	if clustersecretclaim.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	return nil, validateClusterSecretClaim(clustersecretclaim)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterSecretClaim.
+func (v *ClusterSecretClaimCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateClusterSecretClaim applies the SecretClaim rules and requires targets:
+// a ClusterSecretClaim has no namespace of its own to write the Secret to, nor
+// one the API server could write hashed values to.
+func validateClusterSecretClaim(claim *secretsv1alpha1.ClusterSecretClaim) error {
+	fldPath := field.NewPath("spec")
+	allErrs := validateSecretClaimSpec(&claim.Spec, fldPath)
+	if claim.Spec.Targets == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targets"), "targets are required for ClusterSecretClaim"))
+	}
+	if len(claim.Spec.DataHashes) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dataHashes"), "dataHashes is not supported for ClusterSecretClaim, use data or encryptedData"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: secretsv1alpha1.GroupVersion.Group, Kind: "ClusterSecretClaim"},
+		claim.Name, allErrs)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+)
+
+var _ = Describe("ClusterSecretClaim Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *secretsv1alpha1.ClusterSecretClaim
+		validator ClusterSecretClaimCustomValidator
+		defaulter ClusterSecretClaimCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &secretsv1alpha1.ClusterSecretClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-token"},
+			Spec: secretsv1alpha1.SecretClaimSpec{
+				Type: "AutoGenerated",
+				Generation: &secretsv1alpha1.GenerationConfig{
+					Length:   16,
+					DataKeys: []string{"token"},
+				},
+				Targets: &secretsv1alpha1.TargetsConfig{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				},
+			},
+		}
+		validator = ClusterSecretClaimCustomValidator{}
+		defaulter = ClusterSecretClaimCustomDefaulter{}
+	})
+
+	It("Should default Encoding like a SecretClaim", func() {
+		Expect(defaulter.Default(ctx, obj)).To(Succeed())
+		Expect(obj.Spec.Generation.Encoding).To(Equal(DefaultEncoding))
+	})
+
+	It("Should admit a claim with a namespace selector", func() {
+		Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should deny a claim without targets", func() {
+		obj.Spec.Targets = nil
+		_, err := validator.ValidateCreate(ctx, obj)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.targets"))
+	})
+
+	It("Should apply the SecretClaim rules to the spec", func() {
+		obj.Spec.Generation.Length = 4
+		_, err := validator.ValidateUpdate(ctx, obj, obj)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.generation.length"))
+	})
+
+	It("Should deny hashed values", func() {
+		obj.Spec.Type = "Opaque"
+		obj.Spec.Generation = nil
+		obj.Spec.DataHashes = map[string]string{"token": "sha256:00112233445566778899aabbccddeeff:" + strings.Repeat("00", 32)}
+		_, err := validator.ValidateCreate(ctx, obj)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("dataHashes is not supported for ClusterSecretClaim"))
+	})
+})
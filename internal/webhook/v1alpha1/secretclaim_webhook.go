@@ -0,0 +1,716 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/certs"
+	"github.com/mogilyoy/k8s-secret-manager/internal/datahash"
+	"github.com/mogilyoy/k8s-secret-manager/internal/keygen"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sealing"
+	"github.com/mogilyoy/k8s-secret-manager/internal/secrettypes"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sources"
+	"github.com/mogilyoy/k8s-secret-manager/internal/templates"
+)
+
+const (
+	// DefaultEncoding is set on AutoGenerated claims that do not specify one.
+	DefaultEncoding = "alphanumeric"
+	// MinGenerationLength is the shortest secret the controller will generate.
+	MinGenerationLength = 8
+)
+
+var (
+	supportedTypes     = []string{"Opaque", "AutoGenerated", "Certificate", "External"}
+	supportedEncodings = []string{"digits", "alphanumeric", "symbols"}
+	supportedPolicies  = []string{
+		secretsv1alpha1.DeletionPolicyDelete,
+		secretsv1alpha1.DeletionPolicyRetain,
+		secretsv1alpha1.DeletionPolicyOrphan,
+	}
+	supportedConflictPolicies = []string{
+		secretsv1alpha1.PushConflictFail,
+		secretsv1alpha1.PushConflictOverwrite,
+	}
+)
+
+// log is for logging in this package.
+var secretclaimlog = logf.Log.WithName("secretclaim-resource")
+
+// SetupSecretClaimWebhookWithManager registers the webhook for SecretClaim in the manager.
+func SetupSecretClaimWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&secretsv1alpha1.SecretClaim{}).
+		WithValidator(&SecretClaimCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&SecretClaimCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-secrets-myapp-io-v1alpha1-secretclaim,mutating=true,failurePolicy=fail,sideEffects=None,groups=secrets.myapp.io,resources=secretclaims,verbs=create;update,versions=v1alpha1,name=msecretclaim-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecretClaimCustomDefaulter sets default values on SecretClaim objects when they are created or updated.
+type SecretClaimCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &SecretClaimCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind SecretClaim.
+func (d *SecretClaimCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	secretclaim, ok := obj.(*secretsv1alpha1.SecretClaim)
+	if !ok {
+		return fmt.Errorf("expected a SecretClaim object but got %T", obj)
+	}
+	secretclaimlog.Info("Defaulting for SecretClaim", "name", secretclaim.GetName())
+
+	defaultSecretClaimSpec(&secretclaim.Spec)
+
+	return nil
+}
+
+// defaultSecretClaimSpec sets the defaults shared by SecretClaim and ClusterSecretClaim.
+func defaultSecretClaimSpec(spec *secretsv1alpha1.SecretClaimSpec) {
+	if spec.Type == "AutoGenerated" && spec.Generation != nil && spec.Generation.Encoding == "" {
+		spec.Generation.Encoding = DefaultEncoding
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-secrets-myapp-io-v1alpha1-secretclaim,mutating=false,failurePolicy=fail,sideEffects=None,groups=secrets.myapp.io,resources=secretclaims,verbs=create;update,versions=v1alpha1,name=vsecretclaim-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// SecretClaimCustomValidator rejects SecretClaim specs the controller cannot
+// reconcile, and targets the requesting user may not write to.
+type SecretClaimCustomValidator struct {
+	// Client creates the SubjectAccessReviews for the targets.
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &SecretClaimCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SecretClaim.
+func (v *SecretClaimCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	secretclaim, ok := obj.(*secretsv1alpha1.SecretClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecretClaim object but got %T", obj)
+	}
+	secretclaimlog.Info("Validation for SecretClaim upon creation", "name", secretclaim.GetName())
+
+	if err := validateSecretClaim(secretclaim); err != nil {
+		return nil, err
+	}
+	return nil, v.authorizeTargets(ctx, secretclaim, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SecretClaim.
+func (v *SecretClaimCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	secretclaim, ok := newObj.(*secretsv1alpha1.SecretClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecretClaim object for the newObj but got %T", newObj)
+	}
+	oldClaim, ok := oldObj.(*secretsv1alpha1.SecretClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecretClaim object for the oldObj but got %T", oldObj)
+	}
+	secretclaimlog.Info("Validation for SecretClaim upon update", "name", secretclaim.GetName())
+
+	// Объект уже удаляется: не блокируем снятие финализаторов и прочие правки метаданных
+	if secretclaim.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	if err := validateSecretClaim(secretclaim); err != nil {
+		return nil, err
+	}
+	return nil, v.authorizeTargets(ctx, secretclaim, oldClaim.Spec.Targets)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SecretClaim.
+func (v *SecretClaimCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// authorizeTargets requires the requesting user to be allowed to create
+// SecretClaims in every target namespace, since the controller writes the
+// Secret there with its own permissions. A namespaceSelector matches namespaces
+// created later too, so it takes that permission in all namespaces. Unchanged
+// targets are not checked again, so that others may still edit the claim.
+func (v *SecretClaimCustomValidator) authorizeTargets(ctx context.Context, claim *secretsv1alpha1.SecretClaim, previous *secretsv1alpha1.TargetsConfig) error {
+	targets := claim.Spec.Targets
+	if targets == nil || equality.Semantic.DeepEqual(targets, previous) {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	namespaces := targets.Namespaces
+	if targets.NamespaceSelector != nil {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, ns := range namespaces {
+		if ns == claim.Namespace {
+			continue
+		}
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   req.UserInfo.Username,
+				Groups: req.UserInfo.Groups,
+				UID:    req.UserInfo.UID,
+				Extra:  make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra)),
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: ns,
+					Verb:      "create",
+					Group:     secretsv1alpha1.GroupVersion.Group,
+					Resource:  "secretclaims",
+				},
+			},
+		}
+		for key, values := range req.UserInfo.Extra {
+			review.Spec.Extra[key] = authorizationv1.ExtraValue(values)
+		}
+		if err := v.Client.Create(ctx, review); err != nil {
+			return apierrors.NewInternalError(fmt.Errorf("failed to review access to the targets: %w", err))
+		}
+		if !review.Status.Allowed || review.Status.Denied {
+			where := "namespace " + ns
+			if ns == metav1.NamespaceAll {
+				where = "all namespaces, which a namespaceSelector needs"
+			}
+			return apierrors.NewForbidden(
+				schema.GroupResource{Group: secretsv1alpha1.GroupVersion.Group, Resource: "secretclaims"}, claim.Name,
+				fmt.Errorf("spec.targets: %s may not create SecretClaims in %s", req.UserInfo.Username, where))
+		}
+	}
+	return nil
+}
+
+func validateSecretClaim(claim *secretsv1alpha1.SecretClaim) error {
+	allErrs := validateSecretClaimSpec(&claim.Spec, field.NewPath("spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: secretsv1alpha1.GroupVersion.Group, Kind: "SecretClaim"},
+		claim.Name, allErrs)
+}
+
+func validateSecretClaimSpec(spec *secretsv1alpha1.SecretClaimSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch spec.Type {
+	case "Opaque":
+		if spec.Generation != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("generation"), "generation is only allowed for AutoGenerated claims"))
+		}
+		allErrs = append(allErrs, validateEncryptedData(spec, fldPath.Child("encryptedData"))...)
+		allErrs = append(allErrs, validateDataHashes(spec, fldPath.Child("dataHashes"))...)
+	case "AutoGenerated":
+		allErrs = append(allErrs, validateGenerationConfig(spec.Generation, fldPath.Child("generation"))...)
+	case "Certificate":
+		if spec.Generation != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("generation"), "generation is only allowed for AutoGenerated claims"))
+		}
+		if len(spec.Data) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("data"), "data is not allowed for Certificate claims"))
+		}
+		allErrs = append(allErrs, validateCertificateConfig(spec.Certificate, fldPath.Child("certificate"))...)
+	case "External":
+		if spec.Generation != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("generation"), "generation is only allowed for AutoGenerated claims"))
+		}
+		if len(spec.Data) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("data"), "data is not allowed for External claims"))
+		}
+		allErrs = append(allErrs, validateSourceConfig(spec.Source, fldPath.Child("source"))...)
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), spec.Type, supportedTypes))
+	}
+
+	if len(spec.EncryptedData) > 0 && spec.Type != "Opaque" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("encryptedData"), "encryptedData is only allowed for Opaque claims"))
+	}
+	if len(spec.DataHashes) > 0 && spec.Type != "Opaque" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dataHashes"), "dataHashes is only allowed for Opaque claims"))
+	}
+	if spec.Certificate != nil && spec.Type != "Certificate" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("certificate"), "certificate is only allowed for Certificate claims"))
+	}
+	if spec.Source != nil && spec.Type != "External" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("source"), "source is only allowed for External claims"))
+	}
+
+	if spec.Rotation != nil {
+		allErrs = append(allErrs, validateRotationConfig(spec, fldPath.Child("rotation"))...)
+	}
+
+	allErrs = append(allErrs, validateSecretType(spec, fldPath)...)
+
+	if spec.DeletionPolicy != "" && !slices.Contains(supportedPolicies, spec.DeletionPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deletionPolicy"), spec.DeletionPolicy, supportedPolicies))
+	}
+
+	if len(spec.Templates) > 0 {
+		allErrs = append(allErrs, validateTemplates(spec, fldPath.Child("templates"))...)
+	}
+
+	if spec.Targets != nil {
+		allErrs = append(allErrs, validateTargets(spec.Targets, fldPath.Child("targets"))...)
+	}
+
+	if spec.Push != nil {
+		if spec.Type == "External" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("push"), "push is not allowed for External claims"))
+		}
+		allErrs = append(allErrs, validatePushConfig(spec.Push, fldPath.Child("push"))...)
+	}
+
+	return allErrs
+}
+
+func validateGenerationConfig(gen *secretsv1alpha1.GenerationConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if gen == nil {
+		return append(allErrs, field.Required(fldPath, "generation is required for AutoGenerated claims"))
+	}
+
+	if gen.Length < MinGenerationLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("length"), gen.Length,
+			fmt.Sprintf("secrets should be at least %d symbols", MinGenerationLength)))
+	}
+
+	if gen.Encoding != "" && !slices.Contains(supportedEncodings, strings.ToLower(gen.Encoding)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("encoding"), gen.Encoding, supportedEncodings))
+	}
+
+	if len(gen.DataKeys) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("dataKeys"), "at least one data key is required"))
+	}
+	seen := make(map[string]struct{}, len(gen.DataKeys))
+	for i, key := range gen.DataKeys {
+		if key == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("dataKeys").Index(i), "data key must not be empty"))
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("dataKeys").Index(i), key))
+		}
+		seen[key] = struct{}{}
+	}
+
+	for key := range gen.KeyTriggers {
+		if _, ok := seen[key]; !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keyTriggers").Key(key), key, "key trigger must reference one of dataKeys"))
+		}
+	}
+
+	for key, cfg := range gen.Keys {
+		if _, ok := seen[key]; !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keys").Key(key), key, "key format must reference one of dataKeys"))
+			continue
+		}
+		if err := keygen.Validate(cfg.Format, cfg.Bits); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keys").Key(key), cfg, err.Error()))
+		}
+		if keygen.IsKeyPair(cfg.Format) {
+			if _, ok := seen[keygen.PublicKey(key)]; ok {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("dataKeys"), keygen.PublicKey(key)))
+			}
+		}
+		allErrs = append(allErrs, validatePasswordPolicy(gen, key, cfg, fldPath.Child("keys").Key(key))...)
+	}
+
+	return allErrs
+}
+
+func validatePasswordPolicy(gen *secretsv1alpha1.GenerationConfig, key string, cfg secretsv1alpha1.KeyGeneration, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cfg.Length != 0 && cfg.Length < MinGenerationLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("length"), cfg.Length,
+			fmt.Sprintf("secrets should be at least %d symbols", MinGenerationLength)))
+	}
+
+	policy := keygen.PasswordPolicy{
+		Length:           cfg.Length,
+		Charset:          cfg.Charset,
+		MinDigits:        cfg.MinDigits,
+		MinSymbols:       cfg.MinSymbols,
+		MinUppercase:     cfg.MinUppercase,
+		ExcludeAmbiguous: cfg.ExcludeAmbiguous,
+		Prefix:           cfg.Prefix,
+	}
+	if cfg.Format != "" && cfg.Format != keygen.FormatPassword {
+		if policy.HasRules() {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "password policy is only supported for the password format"))
+		}
+		return allErrs
+	}
+
+	if policy.Length == 0 {
+		policy.Length = gen.Length
+	}
+	if policy.Charset == "" {
+		policy.Charset = keygen.Charset(gen.Encoding)
+	}
+	if err := policy.Validate(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, key, err.Error()))
+	}
+	return allErrs
+}
+
+func validateRotationConfig(spec *secretsv1alpha1.SecretClaimSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	rotation := spec.Rotation
+
+	if spec.Type != "AutoGenerated" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "rotation is only supported for AutoGenerated claims"))
+	}
+
+	if rotation.Schedule != "" {
+		if _, err := cron.ParseStandard(rotation.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), rotation.Schedule, err.Error()))
+		}
+	} else if rotation.Interval == nil || rotation.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "rotation requires either a positive interval or a cron schedule"))
+	}
+
+	return allErrs
+}
+
+func validateCertificateConfig(cfg *secretsv1alpha1.CertificateConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cfg == nil {
+		return append(allErrs, field.Required(fldPath, "certificate is required for Certificate claims"))
+	}
+
+	req := certs.Request{
+		CommonName:   cfg.CommonName,
+		DNSNames:     cfg.DNSNames,
+		IPAddresses:  cfg.IPAddresses,
+		KeyAlgorithm: cfg.KeyAlgorithm,
+		KeySize:      cfg.KeySize,
+		Duration:     certs.DefaultDuration,
+		IsCA:         cfg.IsCA,
+	}
+	if cfg.Duration != nil {
+		req.Duration = cfg.Duration.Duration
+	}
+	if err := certs.ValidateRequest(req); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, cfg.CommonName, err.Error()))
+	}
+
+	if cfg.RenewBefore != nil && (cfg.RenewBefore.Duration <= 0 || cfg.RenewBefore.Duration >= req.Duration) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), cfg.RenewBefore.Duration.String(),
+			fmt.Sprintf("renewBefore must be positive and shorter than the certificate duration %s", req.Duration)))
+	}
+
+	return allErrs
+}
+
+func validateSourceConfig(cfg *secretsv1alpha1.SourceConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cfg == nil {
+		return append(allErrs, field.Required(fldPath, "source is required for External claims"))
+	}
+	if !slices.Contains(sources.Supported, cfg.Provider) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provider"), cfg.Provider, sources.Supported))
+	}
+
+	if cfg.Path == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), "source path is required"))
+	} else if cfg.Provider == sources.ProviderHTTP && !isHTTPURL(cfg.Path) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), cfg.Path, "http sources require an http or https URL"))
+	}
+
+	if cfg.Provider == sources.ProviderVault {
+		if cfg.Address == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("address"), "vault sources require the server address"))
+		} else if !isHTTPURL(cfg.Address) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), cfg.Address, "address must be an http or https URL"))
+		}
+	} else {
+		if cfg.Address != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("address"), "address is only allowed for vault sources"))
+		}
+		if cfg.Mount != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mount"), "mount is only allowed for vault sources"))
+		}
+	}
+
+	if cfg.CredentialsSecretRef != "" {
+		if cfg.Provider == sources.ProviderFile {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("credentialsSecretRef"), "file sources take no credentials"))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(cfg.CredentialsSecretRef) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("credentialsSecretRef"), cfg.CredentialsSecretRef, msg))
+		}
+	}
+
+	for key, sourceKey := range cfg.Keys {
+		for _, msg := range validation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keys").Key(key), key, msg))
+		}
+		if sourceKey == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("keys").Key(key), "source key must not be empty"))
+		}
+	}
+
+	if cfg.RefreshInterval != nil && cfg.RefreshInterval.Duration < time.Minute {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("refreshInterval"), cfg.RefreshInterval.Duration.String(), "refreshInterval must be at least 1m"))
+	}
+
+	return allErrs
+}
+
+func validatePushConfig(cfg *secretsv1alpha1.PushConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !slices.Contains(sources.PushSupported, cfg.Provider) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provider"), cfg.Provider, sources.PushSupported))
+	}
+
+	if cfg.Path == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), "push path is required"))
+	} else if cfg.Provider == sources.ProviderHTTP && !isHTTPURL(cfg.Path) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), cfg.Path, "http push requires an http or https URL"))
+	}
+
+	if cfg.Provider == sources.ProviderVault {
+		if cfg.Address == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("address"), "vault push requires the server address"))
+		} else if !isHTTPURL(cfg.Address) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), cfg.Address, "address must be an http or https URL"))
+		}
+	} else {
+		if cfg.Address != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("address"), "address is only allowed for vault push"))
+		}
+		if cfg.Mount != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("mount"), "mount is only allowed for vault push"))
+		}
+	}
+
+	if cfg.CredentialsSecretRef != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(cfg.CredentialsSecretRef) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("credentialsSecretRef"), cfg.CredentialsSecretRef, msg))
+		}
+	}
+
+	for key, storeKey := range cfg.Keys {
+		if key == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("keys"), "Secret key must not be empty"))
+		}
+		if storeKey == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("keys").Key(key), "store key must not be empty"))
+		}
+	}
+
+	if cfg.ConflictPolicy != "" && !slices.Contains(supportedConflictPolicies, cfg.ConflictPolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("conflictPolicy"), cfg.ConflictPolicy, supportedConflictPolicies))
+	}
+
+	return allErrs
+}
+
+// isHTTPURL reports whether raw is an absolute http or https URL.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func validateTargets(targets *secretsv1alpha1.TargetsConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(targets.Namespaces) == 0 && targets.NamespaceSelector == nil {
+		return append(allErrs, field.Required(fldPath, "targets require namespaces or a namespaceSelector"))
+	}
+
+	seen := make(map[string]bool, len(targets.Namespaces))
+	for i, ns := range targets.Namespaces {
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaces").Index(i), ns, msg))
+		}
+		if seen[ns] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("namespaces").Index(i), ns))
+		}
+		seen[ns] = true
+	}
+
+	if targets.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(targets.NamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("namespaceSelector"))...)
+	}
+
+	return allErrs
+}
+
+// validateEncryptedData checks the form of the sealed values. Whether they open is
+// only known to the controller holding the private keys.
+func validateEncryptedData(spec *secretsv1alpha1.SecretClaimSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, key := range slices.Sorted(maps.Keys(spec.EncryptedData)) {
+		for _, msg := range validation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, msg))
+		}
+		if _, ok := spec.Data[key]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Key(key), key))
+		}
+		if err := sealing.Check(spec.EncryptedData[key]); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), "<sealed>", err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDataHashes checks the form of the hashes. The values they stand for are
+// only in the created Secret.
+func validateDataHashes(spec *secretsv1alpha1.SecretClaimSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for _, key := range slices.Sorted(maps.Keys(spec.DataHashes)) {
+		for _, msg := range validation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, msg))
+		}
+		_, inData := spec.Data[key]
+		_, inEncryptedData := spec.EncryptedData[key]
+		if inData || inEncryptedData {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Key(key), key))
+		}
+		if err := datahash.Check(spec.DataHashes[key]); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), spec.DataHashes[key], err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateSecretType(spec *secretsv1alpha1.SecretClaimSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.SecretType != "" && !slices.Contains(secrettypes.Supported, spec.SecretType) {
+		return append(allErrs, field.NotSupported(fldPath.Child("secretType"), spec.SecretType, secrettypes.Supported))
+	}
+	if spec.Type == "AutoGenerated" && !secrettypes.Generatable(spec.SecretType, keyFormats(spec.Generation)) {
+		return append(allErrs, field.Forbidden(fldPath.Child("secretType"), "this secret type cannot be generated with the configured key formats, use an Opaque claim"))
+	}
+
+	secretType := spec.SecretType
+	if spec.Type == "Certificate" {
+		if secretType != "" && secretType != string(corev1.SecretTypeTLS) {
+			return append(allErrs, field.Invalid(fldPath.Child("secretType"), secretType, "Certificate claims always create kubernetes.io/tls secrets"))
+		}
+		secretType = string(corev1.SecretTypeTLS)
+	}
+
+	keys := append(slices.Collect(maps.Keys(spec.Data)), sourceKeys(spec)...)
+	keys = append(keys, slices.Collect(maps.Keys(spec.Templates))...)
+
+	var docker *secrettypes.DockerConfig
+	if spec.DockerConfig != nil {
+		docker = &secrettypes.DockerConfig{
+			Registry: spec.DockerConfig.Registry,
+			Username: spec.DockerConfig.Username,
+		}
+	}
+	if err := secrettypes.Validate(secretType, keys, docker); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("secretType"), spec.SecretType, err.Error()))
+	}
+
+	return allErrs
+}
+
+func validateTemplates(spec *secretsv1alpha1.SecretClaimSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	dataKeys := sourceKeys(spec)
+	for k := range spec.Data {
+		dataKeys = append(dataKeys, k)
+	}
+
+	for key, text := range spec.Templates {
+		if err := templates.Validate(map[string]string{key: text}, dataKeys); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), text, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// sourceKeys returns the keys the controller generates, issues, fetches or opens for the claim.
+func sourceKeys(spec *secretsv1alpha1.SecretClaimSpec) []string {
+	switch {
+	case spec.Type == "Opaque":
+		keys := append(slices.Collect(maps.Keys(spec.EncryptedData)), slices.Collect(maps.Keys(spec.DataHashes))...)
+		slices.Sort(keys)
+		return keys
+	case spec.Type == "Certificate":
+		return []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"}
+	case spec.Type == "External" && spec.Source != nil:
+		return slices.Sorted(maps.Keys(spec.Source.Keys))
+	case spec.Generation != nil:
+		keys := slices.Clone(spec.Generation.DataKeys)
+		for key, format := range keyFormats(spec.Generation) {
+			if keygen.IsKeyPair(format) {
+				keys = append(keys, keygen.PublicKey(key))
+			}
+		}
+		return keys
+	}
+	return nil
+}
+
+// keyFormats maps the generated data keys to their configured format.
+func keyFormats(gen *secretsv1alpha1.GenerationConfig) map[string]string {
+	if gen == nil {
+		return nil
+	}
+	formats := make(map[string]string, len(gen.Keys))
+	for key, cfg := range gen.Keys {
+		formats[key] = cfg.Format
+	}
+	return formats
+}
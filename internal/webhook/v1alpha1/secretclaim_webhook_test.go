@@ -0,0 +1,521 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	secretsv1alpha1 "github.com/mogilyoy/k8s-secret-manager/api/v1alpha1"
+	"github.com/mogilyoy/k8s-secret-manager/internal/datahash"
+	"github.com/mogilyoy/k8s-secret-manager/internal/sealing"
+)
+
+var _ = Describe("SecretClaim Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *secretsv1alpha1.SecretClaim
+		validator SecretClaimCustomValidator
+		defaulter SecretClaimCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &secretsv1alpha1.SecretClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-claim", Namespace: "default"},
+			Spec: secretsv1alpha1.SecretClaimSpec{
+				Type: "AutoGenerated",
+				Generation: &secretsv1alpha1.GenerationConfig{
+					Length:   16,
+					DataKeys: []string{"password"},
+				},
+			},
+		}
+		validator = SecretClaimCustomValidator{}
+		defaulter = SecretClaimCustomDefaulter{}
+	})
+
+	Context("When creating SecretClaim under Defaulting Webhook", func() {
+		It("Should default Encoding to alphanumeric", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Generation.Encoding).To(Equal(DefaultEncoding))
+		})
+
+		It("Should keep an explicit Encoding", func() {
+			obj.Spec.Generation.Encoding = "symbols"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Generation.Encoding).To(Equal("symbols"))
+		})
+	})
+
+	Context("When creating or updating SecretClaim under Validating Webhook", func() {
+		It("Should admit a valid AutoGenerated claim", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit a valid Opaque claim", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{Type: "Opaque", Data: map[string]string{"foo": "bar"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an unknown type", func() {
+			obj.Spec.Type = "Magic"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.type"))
+		})
+
+		It("Should deny AutoGenerated without generation", func() {
+			obj.Spec.Generation = nil
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.generation"))
+		})
+
+		It("Should deny a length below 8", func() {
+			obj.Spec.Generation.Length = 4
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("8 symbols"))
+		})
+
+		It("Should deny empty dataKeys", func() {
+			obj.Spec.Generation.DataKeys = nil
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.generation.dataKeys"))
+		})
+
+		It("Should deny a key trigger for an unknown data key", func() {
+			obj.Spec.Generation.KeyTriggers = map[string]string{"missing": "1"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.generation.keyTriggers[missing]"))
+		})
+
+		It("Should admit an ssh-auth secret generated as an SSH key", func() {
+			obj.Spec.SecretType = "kubernetes.io/ssh-auth"
+			obj.Spec.Generation.DataKeys = []string{"ssh-privatekey"}
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"ssh-privatekey": {Format: "ssh-ed25519"}}
+			obj.Spec.Templates = map[string]string{"authorized_keys": "{{ index . \"ssh-privatekey.pub\" }}"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Generation.Keys = nil
+			obj.Spec.Templates = nil
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.secretType"))
+		})
+
+		It("Should deny invalid key formats", func() {
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"missing": {Format: "ssh-ed25519"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.generation.keys[missing]"))
+
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"password": {Format: "ssh-ed25519", Bits: 2048}}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bits"))
+
+			obj.Spec.Generation.DataKeys = []string{"password", "password.pub"}
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"password": {Format: "jwk-ec"}}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("password.pub"))
+		})
+
+		It("Should validate per-key password policies", func() {
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{
+				"password": {Length: 20, Charset: "abcdefghABCDEFGH23456789-_", MinDigits: 2, MinSymbols: 1, MinUppercase: 1, Prefix: "db_"},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"password": {MinDigits: 10, MinUppercase: 10}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exceed the length 16"))
+
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"password": {MinSymbols: 1}}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no symbol characters"))
+
+			obj.Spec.Generation.Keys = map[string]secretsv1alpha1.KeyGeneration{"password": {Format: "ssh-ed25519", Prefix: "x"}}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only supported for the password format"))
+		})
+
+		It("Should deny an unknown deletion policy", func() {
+			obj.Spec.DeletionPolicy = "Keep"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.deletionPolicy"))
+		})
+
+		It("Should deny a TLS secret without the key pair", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:       "Opaque",
+				SecretType: "kubernetes.io/tls",
+				Data:       map[string]string{"tls.crt": "cert"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("tls.key"))
+		})
+
+		It("Should admit a dockerconfigjson secret with a generated password", func() {
+			obj.Spec.SecretType = "kubernetes.io/dockerconfigjson"
+			obj.Spec.DockerConfig = &secretsv1alpha1.DockerConfig{Registry: "registry.example.com", Username: "ci"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should take the dockerconfigjson password from a hashed data key", func() {
+			hash, err := datahash.Sum([]byte("s3cret"))
+			Expect(err).NotTo(HaveOccurred())
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:         "Opaque",
+				SecretType:   "kubernetes.io/dockerconfigjson",
+				DockerConfig: &secretsv1alpha1.DockerConfig{Registry: "registry.example.com", Username: "ci"},
+				DataHashes:   map[string]string{"password": hash},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.DataHashes = map[string]string{"token": hash}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("password"))
+		})
+
+		It("Should admit a Certificate claim", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type: "Certificate",
+				Certificate: &secretsv1alpha1.CertificateConfig{
+					CommonName:  "api.default.svc",
+					DNSNames:    []string{"api.default.svc"},
+					Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: time.Hour},
+				},
+				Templates: map[string]string{"bundle.pem": "{{ index . \"tls.crt\" }}"},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a Certificate claim renewed after it expires", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type: "Certificate",
+				Certificate: &secretsv1alpha1.CertificateConfig{
+					CommonName:  "api",
+					Duration:    &metav1.Duration{Duration: time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.certificate.renewBefore"))
+		})
+
+		It("Should deny a Certificate claim without certificate spec or with another secret type", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{Type: "Certificate", SecretType: "Opaque"}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.certificate"))
+			Expect(err.Error()).To(ContainSubstring("spec.secretType"))
+		})
+
+		It("Should admit an External claim reading from Vault", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type: "External",
+				Source: &secretsv1alpha1.SourceConfig{
+					Provider:             "vault",
+					Address:              "https://vault.example.com",
+					Path:                 "apps/db",
+					CredentialsSecretRef: "vault-token",
+					Keys:                 map[string]string{"tls.crt": "cert", "tls.key": "key"},
+					RefreshInterval:      &metav1.Duration{Duration: 15 * time.Minute},
+				},
+				SecretType: "kubernetes.io/tls",
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid External source", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{Type: "External", Data: map[string]string{"a": "b"}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.source"))
+			Expect(err.Error()).To(ContainSubstring("spec.data"))
+
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type: "External",
+				Source: &secretsv1alpha1.SourceConfig{
+					Provider:        "http",
+					Path:            "config/db",
+					Mount:           "kv",
+					RefreshInterval: &metav1.Duration{Duration: time.Second},
+				},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.source.path"))
+			Expect(err.Error()).To(ContainSubstring("spec.source.mount"))
+			Expect(err.Error()).To(ContainSubstring("spec.source.refreshInterval"))
+
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:   "Opaque",
+				Data:   map[string]string{"a": "b"},
+				Source: &secretsv1alpha1.SourceConfig{Provider: "file", Path: "db"},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("source is only allowed for External claims"))
+		})
+
+		It("Should deny rotation on an Opaque claim", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:     "Opaque",
+				Rotation: &secretsv1alpha1.RotationConfig{Interval: &metav1.Duration{Duration: time.Hour}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.rotation"))
+		})
+
+		It("Should admit valid templates", func() {
+			obj.Spec.Templates = map[string]string{"dsn": "postgres://app:{{ .password | urlquery }}@db:5432/app"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a template that does not parse", func() {
+			obj.Spec.Templates = map[string]string{"dsn": "{{ .password "}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.templates[dsn]"))
+		})
+
+		It("Should deny a template overwriting a generated key", func() {
+			obj.Spec.Templates = map[string]string{"password": "{{ .password }}"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
+		})
+
+		It("Should admit targets with namespaces and a selector", func() {
+			validator.Client = reviewingClient(func(spec authorizationv1.SubjectAccessReviewSpec) bool { return true })
+			obj.Spec.Targets = &secretsv1alpha1.TargetsConfig{
+				Namespaces:        []string{"team-a", "team-b"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			}
+			Expect(validator.ValidateCreate(asUser(ctx, "alice"), obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny targets the user may not create SecretClaims in", func() {
+			var reviewed []string
+			validator.Client = reviewingClient(func(spec authorizationv1.SubjectAccessReviewSpec) bool {
+				reviewed = append(reviewed, spec.ResourceAttributes.Namespace)
+				return spec.User == "alice" && spec.ResourceAttributes.Namespace == "team-a"
+			})
+			obj.Spec.Targets = &secretsv1alpha1.TargetsConfig{Namespaces: []string{"default", "team-a", "team-b"}}
+			_, err := validator.ValidateCreate(asUser(ctx, "alice"), obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("alice may not create SecretClaims in namespace team-b"))
+			// The namespace of the claim needs no review.
+			Expect(reviewed).To(Equal([]string{"team-a", "team-b"}))
+
+			obj.Spec.Targets = &secretsv1alpha1.TargetsConfig{NamespaceSelector: &metav1.LabelSelector{}}
+			_, err = validator.ValidateCreate(asUser(ctx, "alice"), obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("all namespaces"))
+		})
+
+		It("Should not review targets again when they did not change", func() {
+			validator.Client = reviewingClient(func(spec authorizationv1.SubjectAccessReviewSpec) bool { return false })
+			obj.Spec.Targets = &secretsv1alpha1.TargetsConfig{Namespaces: []string{"team-a"}}
+			oldObj := obj.DeepCopy()
+			obj.Spec.Generation.Length = 32
+			Expect(validator.ValidateUpdate(asUser(ctx, "bob"), oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.Targets.Namespaces = append(obj.Spec.Targets.Namespaces, "team-b")
+			_, err := validator.ValidateUpdate(asUser(ctx, "bob"), oldObj, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("Should deny empty, invalid or duplicate targets", func() {
+			obj.Spec.Targets = &secretsv1alpha1.TargetsConfig{}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.targets"))
+
+			obj.Spec.Targets = &secretsv1alpha1.TargetsConfig{
+				Namespaces: []string{"Team_A", "team-b", "team-b"},
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Like"},
+				}},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.targets.namespaces[0]"))
+			Expect(err.Error()).To(ContainSubstring("spec.targets.namespaces[2]"))
+			Expect(err.Error()).To(ContainSubstring("spec.targets.namespaceSelector"))
+		})
+
+		It("Should admit a push to Vault", func() {
+			obj.Spec.Push = &secretsv1alpha1.PushConfig{
+				Provider:             "vault",
+				Address:              "https://vault.example.com",
+				Path:                 "apps/api",
+				CredentialsSecretRef: "vault-token",
+				Keys:                 map[string]string{"password": "api_password"},
+				ConflictPolicy:       "Overwrite",
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid push", func() {
+			obj.Spec.Push = &secretsv1alpha1.PushConfig{
+				Provider:       "http",
+				Path:           "apps/api",
+				Mount:          "kv",
+				ConflictPolicy: "Merge",
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.push.path"))
+			Expect(err.Error()).To(ContainSubstring("spec.push.mount"))
+			Expect(err.Error()).To(ContainSubstring("spec.push.conflictPolicy"))
+
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:   "External",
+				Source: &secretsv1alpha1.SourceConfig{Provider: "file", Path: "db"},
+				Push:   &secretsv1alpha1.PushConfig{Provider: "file", Path: "db"},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("push is not allowed for External claims"))
+			Expect(err.Error()).To(ContainSubstring("spec.push.provider"))
+		})
+
+		It("Should admit sealed values on an Opaque claim", func() {
+			priv, err := sealing.GenerateKey()
+			Expect(err).NotTo(HaveOccurred())
+			sealed, err := sealing.Seal(priv.PublicKey(), sealing.Scope("default", "test-claim"), "password", []byte("s3cret"))
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:          "Opaque",
+				Data:          map[string]string{"username": "app"},
+				EncryptedData: map[string]string{"password": sealed},
+				SecretType:    "kubernetes.io/basic-auth",
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny malformed, duplicate or misplaced sealed values", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:          "Opaque",
+				Data:          map[string]string{"password": "plain"},
+				EncryptedData: map[string]string{"password": "plain", "bad/key": "plain"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.encryptedData[password]: Duplicate value"))
+			Expect(err.Error()).To(ContainSubstring("spec.encryptedData[password]: Invalid value"))
+			Expect(err.Error()).To(ContainSubstring("spec.encryptedData[bad/key]"))
+
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:          "AutoGenerated",
+				Generation:    &secretsv1alpha1.GenerationConfig{Length: 16, DataKeys: []string{"password"}},
+				EncryptedData: map[string]string{"token": "plain"},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("encryptedData is only allowed for Opaque claims"))
+		})
+
+		It("Should admit hashed values on an Opaque claim", func() {
+			hash, err := datahash.Sum([]byte("s3cret"))
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:       "Opaque",
+				Data:       map[string]string{"username": "app"},
+				DataHashes: map[string]string{"password": hash},
+				SecretType: "kubernetes.io/basic-auth",
+				Templates:  map[string]string{"dsn": "{{ .username }}:{{ .password }}"},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny malformed, duplicate or misplaced hashes", func() {
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:       "Opaque",
+				Data:       map[string]string{"password": "plain"},
+				DataHashes: map[string]string{"password": "plain"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.dataHashes[password]: Duplicate value"))
+			Expect(err.Error()).To(ContainSubstring("spec.dataHashes[password]: Invalid value"))
+
+			hash, err := datahash.Sum([]byte("s3cret"))
+			Expect(err).NotTo(HaveOccurred())
+			obj.Spec = secretsv1alpha1.SecretClaimSpec{
+				Type:       "AutoGenerated",
+				Generation: &secretsv1alpha1.GenerationConfig{Length: 16, DataKeys: []string{"password"}},
+				DataHashes: map[string]string{"token": hash},
+			}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dataHashes is only allowed for Opaque claims"))
+		})
+
+		It("Should validate the new object on update", func() {
+			oldObj := obj.DeepCopy()
+			obj.Spec.Generation.Length = 4
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(HaveOccurred())
+		})
+	})
+})
+
+// asUser returns ctx with an admission request made by username.
+func asUser(ctx context.Context, username string) context.Context {
+	return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UserInfo: authenticationv1.UserInfo{Username: username},
+	}})
+}
+
+// reviewingClient answers SubjectAccessReviews with allowed.
+func reviewingClient(allowed func(authorizationv1.SubjectAccessReviewSpec) bool) client.Client {
+	return fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SubjectAccessReview)
+			review.Status.Allowed = allowed(review.Spec)
+			return nil
+		},
+	}).Build()
+}